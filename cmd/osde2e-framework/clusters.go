@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/osde2e-framework/pkg/providers/rosa"
+)
+
+// writeClustersTable writes the cluster summaries as a table
+func writeClustersTable(w io.Writer, clusters []*rosa.ClusterSummary) {
+	fmt.Fprintln(w, "ID\tNAME\tSTATE\tVERSION\tREGION\tHOSTED CP\tCREATED")
+	for _, cluster := range clusters {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%t\t%s\n",
+			cluster.ID, cluster.Name, cluster.State, cluster.Version, cluster.Region, cluster.HostedCP,
+			cluster.CreationTimestamp.Format(time.RFC3339))
+	}
+}
+
+// parseProperties returns the comma separated key=value cluster properties
+func parseProperties(value string) (map[string]string, error) {
+	properties := map[string]string{}
+	for _, property := range strings.Split(value, ",") {
+		if property = strings.TrimSpace(property); property == "" {
+			continue
+		}
+
+		key, val, ok := strings.Cut(property, "=")
+		if !ok {
+			return nil, fmt.Errorf("property %q must be formatted as key=value", property)
+		}
+		properties[key] = val
+	}
+	return properties, nil
+}
+
+// list prints the rosa clusters matching the filters
+func list(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+	namePrefix := flags.String("name-prefix", "", "only list clusters whose name has the prefix")
+	state := flags.String("state", "", "only list clusters in the state (e.g. ready)")
+	properties := flags.String("properties", "", "only list clusters with the comma separated key=value properties")
+	ocmEnv := ocmEnvFlag(flags)
+	output := outputFlag(flags)
+	_ = flags.Parse(args)
+
+	if err := validateOutput(*output); err != nil {
+		log.Println(err)
+		return 2
+	}
+
+	filters := &rosa.ClusterFilters{NamePrefix: *namePrefix, State: *state}
+
+	var err error
+	filters.Properties, err = parseProperties(*properties)
+	if err != nil {
+		log.Println(err)
+		return 2
+	}
+
+	client, err := newOCMClient(ctx, *ocmEnv)
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	clusters, err := rosa.NewFromClient(client).ListClusters(ctx, filters)
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Name < clusters[j].Name })
+
+	err = printOutput(*output, clusters, func(w io.Writer) { writeClustersTable(w, clusters) })
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+
+	return 0
+}
+
+// describe prints the rosa cluster matching the id or name argument
+func describe(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("describe", flag.ExitOnError)
+	ocmEnv := ocmEnvFlag(flags)
+	output := outputFlag(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: describe [flags] <cluster id or name>\n")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	if err := validateOutput(*output); err != nil {
+		log.Println(err)
+		return 2
+	}
+
+	client, err := newOCMClient(ctx, *ocmEnv)
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	cluster, err := rosa.NewFromClient(client).DescribeClusterSummary(ctx, flags.Arg(0))
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+
+	err = printOutput(*output, cluster, func(w io.Writer) {
+		fmt.Fprintf(w, "ID:\t%s\n", cluster.ID)
+		fmt.Fprintf(w, "Name:\t%s\n", cluster.Name)
+		fmt.Fprintf(w, "State:\t%s\n", cluster.State)
+		fmt.Fprintf(w, "Version:\t%s\n", cluster.Version)
+		fmt.Fprintf(w, "Region:\t%s\n", cluster.Region)
+		fmt.Fprintf(w, "Hosted CP:\t%t\n", cluster.HostedCP)
+		fmt.Fprintf(w, "STS:\t%t\n", cluster.STS)
+		fmt.Fprintf(w, "Created:\t%s\n", cluster.CreationTimestamp.Format(time.RFC3339))
+
+		keys := make([]string, 0, len(cluster.Properties))
+		for key := range cluster.Properties {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(w, "Property %s:\t%s\n", key, cluster.Properties[key])
+		}
+	})
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,53 @@
+// osde2e-framework runs framework operations standalone, e.g. as steps of multi-stage pipelines
+//
+//	osde2e-framework verify --timeout 15m <kubeconfig or cluster id>
+//	osde2e-framework list --name-prefix osde2e- -o json
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// command is a cli subcommand, it returns the process exit code
+type command struct {
+	description string
+	run         func(ctx context.Context, args []string) int
+}
+
+// commands are the cli subcommands
+var commands = map[string]command{
+	"describe": {description: "describe a rosa cluster by id or name", run: describe},
+	"list":     {description: "list rosa clusters matching the filters", run: list},
+	"verify":   {description: "run health checks against an existing cluster and print the report", run: verify},
+}
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s <command> [flags]\n\nCommands:\n", os.Args[0])
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(flag.CommandLine.Output(), "  %-10s %s\n", name, commands[name].description)
+	}
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	command, ok := commands[flag.Arg(0)]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+
+	os.Exit(command.run(context.Background(), flag.Args()[1:]))
+}
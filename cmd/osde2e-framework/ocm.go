@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	ocmclient "github.com/openshift/osde2e-framework/pkg/clients/ocm"
+)
+
+// ocmEnvFlag adds the --ocm-env flag selecting the ocm environment to the flag set
+func ocmEnvFlag(flags *flag.FlagSet) *string {
+	return flags.String("ocm-env", "production", "ocm environment (production, stage, integration, fedramp-production, fedramp-stage, fedramp-integration) or ocm url, requires OCM_TOKEN, OCM_TOKEN_FILE or OCM_CLIENT_ID and OCM_CLIENT_SECRET")
+}
+
+// newOCMClient returns an ocm client for the environment authenticated using OCM_TOKEN, OCM_TOKEN_FILE
+// or the OCM_CLIENT_ID and OCM_CLIENT_SECRET service account, it is the callers responsibility to close it
+func newOCMClient(ctx context.Context, env string) (*ocmclient.Client, error) {
+	environment, err := ocmclient.ParseEnvironment(env)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := ocmclient.Credentials{
+		Token:        os.Getenv("OCM_TOKEN"),
+		TokenFile:    os.Getenv("OCM_TOKEN_FILE"),
+		ClientID:     os.Getenv("OCM_CLIENT_ID"),
+		ClientSecret: os.Getenv("OCM_CLIENT_SECRET"),
+	}
+
+	client, err := ocmclient.NewWithCredentials(ctx, credentials, environment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct ocm client: %v", err)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"sigs.k8s.io/yaml"
+)
+
+// outputFormats are the formats selectable using -o
+const outputFormats = "json, yaml, table"
+
+// outputFlag adds the -o flag selecting the output format to the flag set
+func outputFlag(flags *flag.FlagSet) *string {
+	return flags.String("o", "table", fmt.Sprintf("output format (%s)", outputFormats))
+}
+
+// validateOutput verifies the output format is supported
+func validateOutput(format string) error {
+	switch format {
+	case "json", "yaml", "table":
+		return nil
+	}
+	return fmt.Errorf("output format %q is not supported, use one of: %s", format, outputFormats)
+}
+
+// printOutput writes the value to stdout as json or yaml (using its json field names),
+// or as a table written by the table function
+func printOutput(format string, value any, table func(w io.Writer)) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case "yaml":
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode yaml output: %v", err)
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	case "table":
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		table(writer)
+		return writer.Flush()
+	}
+
+	return validateOutput(format)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/openshift/osde2e-framework/pkg/healthcheck"
+)
+
+// splitChecks returns the comma separated health checks
+func splitChecks(value string) []healthcheck.Check {
+	var checks []healthcheck.Check
+	for _, check := range strings.Split(value, ",") {
+		if check = strings.TrimSpace(check); check != "" {
+			checks = append(checks, healthcheck.Check(check))
+		}
+	}
+	return checks
+}
+
+// verify runs the health checks against the kubeconfig or cluster id argument, exiting
+// non-zero when a required check fails
+func verify(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	checks := flags.String("checks", "", "comma separated health checks to run (default nodes-ready,cluster-operators-available)")
+	optional := flags.String("optional", "", "comma separated health checks reported without failing the verification")
+	timeout := flags.Duration("timeout", 0, "how long each health check waits to pass (default 10m)")
+	ocmEnv := ocmEnvFlag(flags)
+	output := outputFlag(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: verify [flags] <kubeconfig or cluster id>\n")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+	target := flags.Arg(0)
+
+	if err := validateOutput(*output); err != nil {
+		log.Println(err)
+		return 2
+	}
+
+	policy := &healthcheck.Policy{
+		Checks:   splitChecks(*checks),
+		Optional: splitChecks(*optional),
+		Timeout:  *timeout,
+	}
+
+	if _, err := os.Stat(target); err != nil {
+		client, err := newOCMClient(ctx, *ocmEnv)
+		if err != nil {
+			log.Println(err)
+			return 1
+		}
+		defer func() {
+			_ = client.Close()
+		}()
+
+		policy.OCMClient = client
+	}
+
+	report, err := healthcheck.Verify(ctx, target, policy)
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+
+	err = printOutput(*output, report, func(w io.Writer) {
+		fmt.Fprint(w, report.String())
+	})
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+
+	if !report.Passed() {
+		return 1
+	}
+
+	return 0
+}
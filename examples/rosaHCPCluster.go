@@ -37,7 +37,7 @@ func main() {
 	}
 
 	defer func() {
-		_ = provider.Connection.Close()
+		_ = provider.Close()
 	}()
 
 	if action == "create" {
@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"io"
 	"os/exec"
+	"strings"
+
+	"github.com/openshift/osde2e-framework/internal/faultinjection"
 )
 
 // Run executes the os.exec command provided
@@ -16,7 +19,12 @@ func Run(command *exec.Cmd) (io.Writer, io.Writer, error) {
 	command.Stdout = &stdout
 	command.Stderr = &stderr
 
-	err := command.Start()
+	err := faultinjection.Inject(strings.Join(Operation(command), " "))
+	if err != nil {
+		return command.Stdout, command.Stderr, err
+	}
+
+	err = command.Start()
 	if err != nil {
 		return command.Stdout, command.Stderr, fmt.Errorf("failed to start command: %v", err)
 	}
@@ -29,6 +37,15 @@ func Run(command *exec.Cmd) (io.Writer, io.Writer, error) {
 	return command.Stdout, command.Stderr, nil
 }
 
+// Operation returns the command and its subcommands, the remaining arguments are left
+// out to avoid leaking sensitive arguments (e.g. tokens) when identifying the command
+func Operation(command *exec.Cmd) []string {
+	if len(command.Args) > 3 {
+		return command.Args[:3]
+	}
+	return command.Args
+}
+
 // ConvertJSONStringToMap converts a json string formatted to a map object
 func ConvertJSONStringToMap(data io.Writer) (map[string]any, error) {
 	var result map[string]any
@@ -0,0 +1,120 @@
+// Package faultinjection randomly fails a percentage of ocm api and cli calls
+// with retryable errors to validate the frameworks retry, rollback and cleanup
+// logic without cloud access. It is disabled unless the
+// OSDE2E_FAULT_INJECTION_PERCENTAGE environment variable is set.
+package faultinjection
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// PercentageEnv is the environment variable holding the percentage (0-100) of calls to fail
+	PercentageEnv = "OSDE2E_FAULT_INJECTION_PERCENTAGE"
+	// SeedEnv is the environment variable holding the random seed, allowing failures to be reproduced
+	SeedEnv = "OSDE2E_FAULT_INJECTION_SEED"
+)
+
+var (
+	mutex  sync.Mutex
+	random *rand.Rand
+)
+
+// Error represents an injected failure
+type Error struct {
+	Operation string
+}
+
+// Error returns the formatted error message when Error is invoked
+func (e *Error) Error() string {
+	return fmt.Sprintf("injected failure for %q", e.Operation)
+}
+
+// Temporary reports the injected failure is retryable
+func (e *Error) Temporary() bool {
+	return true
+}
+
+// percentage returns the configured percentage of calls to fail
+func percentage() float64 {
+	value, err := strconv.ParseFloat(os.Getenv(PercentageEnv), 64)
+	if err != nil || value < 0 {
+		return 0
+	}
+	if value > 100 {
+		return 100
+	}
+	return value
+}
+
+// Enabled returns true when fault injection is configured
+func Enabled() bool {
+	return percentage() > 0
+}
+
+// shouldFail decides whether the current call should fail
+func shouldFail() bool {
+	threshold := percentage()
+	if threshold == 0 {
+		return false
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if random == nil {
+		seed, err := strconv.ParseInt(os.Getenv(SeedEnv), 10, 64)
+		if err != nil {
+			seed = time.Now().UnixNano()
+		}
+		log.Printf("Fault injection enabled, failing %.1f%% of calls (seed=%d)", threshold, seed)
+		random = rand.New(rand.NewSource(seed))
+	}
+
+	return random.Float64()*100 < threshold
+}
+
+// Inject returns a retryable error for the operation when the call is selected to fail
+func Inject(operation string) error {
+	if !shouldFail() {
+		return nil
+	}
+
+	log.Printf("Fault injection: failing %q", operation)
+
+	return &Error{Operation: operation}
+}
+
+// transport wraps a http round tripper responding with service unavailable for selected requests
+type transport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip executes the http request unless it is selected to fail
+func (t *transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if err := Inject(fmt.Sprintf("%s %s", request.Method, request.URL.Path)); err != nil {
+		return &http.Response{
+			Status:     http.StatusText(http.StatusServiceUnavailable),
+			StatusCode: http.StatusServiceUnavailable,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(fmt.Sprintf(`{"kind":"Error","reason":%q}`, err.Error()))),
+			Request:    request,
+		}, nil
+	}
+
+	return t.next.RoundTrip(request)
+}
+
+// TransportWrapper wraps the http round tripper to inject failures into http requests
+func TransportWrapper(next http.RoundTripper) http.RoundTripper {
+	return &transport{next: next}
+}
@@ -3,6 +3,7 @@ package terraform
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/hashicorp/hc-install/product"
 	"github.com/hashicorp/hc-install/releases"
@@ -51,6 +52,12 @@ func (r *runner) Uninstall(ctx context.Context) error {
 	return nil
 }
 
+// SetOutput writes the output of the terraform commands run to the writers provided
+func (r *runner) SetOutput(stdout, stderr io.Writer) {
+	r.runner.SetStdout(stdout)
+	r.runner.SetStderr(stderr)
+}
+
 // Init performs a terraform init using the provided TerraformRunner receiver
 func (r *runner) Init(ctx context.Context) error {
 	err := r.runner.Init(ctx)
@@ -76,6 +83,16 @@ func (r *runner) Plan(ctx context.Context, args ...tfexec.PlanOption) error {
 	return nil
 }
 
+// ShowPlan returns the human readable plan created by Plan
+func (r *runner) ShowPlan(ctx context.Context) (string, error) {
+	plan, err := r.runner.ShowPlanFileRaw(ctx, fmt.Sprintf("%s/plan.out", r.workingDir))
+	if err != nil {
+		return "", fmt.Errorf("error running terraform show: %w", err)
+	}
+
+	return plan, nil
+}
+
 // Apply performs a terraform apply using the provided TerraformRunner receiver
 func (r *runner) Apply(ctx context.Context) error {
 	err := r.runner.Apply(
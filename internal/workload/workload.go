@@ -0,0 +1,85 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const randomSuffixCharacters = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// RandomSuffix returns a random lowercase alphanumeric string of the given length
+func RandomSuffix(length int) string {
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	suffix := make([]byte, length)
+	for i := range suffix {
+		suffix[i] = randomSuffixCharacters[random.Intn(len(randomSuffixCharacters))]
+	}
+	return string(suffix)
+}
+
+// CreateNamespace creates a namespace using the provided prefix followed by a random suffix
+func CreateNamespace(ctx context.Context, client *openshift.Client, prefix string) (*corev1.Namespace, error) {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-%s", prefix, RandomSuffix(5))}}
+	if err := client.Create(ctx, namespace); err != nil {
+		return nil, fmt.Errorf("failed to create namespace %q: %w", namespace.Name, err)
+	}
+	return namespace, nil
+}
+
+// DeleteNamespace deletes the namespace and all resources within it
+func DeleteNamespace(ctx context.Context, client *openshift.Client, namespace *corev1.Namespace) error {
+	if err := client.Delete(ctx, namespace); err != nil {
+		return fmt.Errorf("failed to delete namespace %q: %w", namespace.Name, err)
+	}
+	return nil
+}
+
+// WaitForPodRunning waits for the pod to be running and returns the latest version of it
+func WaitForPodRunning(ctx context.Context, client *openshift.Client, name, namespace string, timeout time.Duration) (*corev1.Pod, error) {
+	var pod corev1.Pod
+
+	err := wait.PollUntilContextTimeout(ctx, 5*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		if err := client.Get(ctx, name, namespace, &pod); err != nil {
+			return false, nil
+		}
+
+		switch pod.Status.Phase {
+		case corev1.PodRunning:
+			for _, condition := range pod.Status.Conditions {
+				if condition.Type == corev1.PodReady {
+					return condition.Status == corev1.ConditionTrue, nil
+				}
+			}
+			return false, nil
+		case corev1.PodFailed, corev1.PodSucceeded:
+			return false, fmt.Errorf("pod %s/%s exited with phase %s", namespace, name, pod.Status.Phase)
+		default:
+			return false, nil
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pod %s/%s failed to become running: %w", namespace, name, err)
+	}
+
+	return &pod, nil
+}
+
+// RestrictedSecurityContext returns a container security context that satisfies the restricted pod security standard
+func RestrictedSecurityContext() *corev1.SecurityContext {
+	allowPrivilegeEscalation := false
+	runAsNonRoot := true
+
+	return &corev1.SecurityContext{
+		AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+		RunAsNonRoot:             &runAsNonRoot,
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+}
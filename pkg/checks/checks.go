@@ -0,0 +1,97 @@
+// Package checks evaluates the same conditions as the gomegamatchers package without
+// depending on gomega, for plain go tests and controllers. Each check returns whether
+// the condition is met and, when it is not, details describing why
+//
+//	if ok, details := checks.DeploymentAvailable(deployment); !ok {
+//		t.Fatalf("deployment is not available: %s", details)
+//	}
+package checks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeploymentAvailable returns whether the deployment has the available condition
+func DeploymentAvailable(deployment *appsv1.Deployment) (bool, string) {
+	if deployment == nil {
+		return false, "deployment is nil"
+	}
+
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentAvailable {
+			if condition.Status == corev1.ConditionTrue {
+				return true, ""
+			}
+			return false, fmt.Sprintf("deployment %q is not available: %s", deployment.Name, condition.Message)
+		}
+	}
+
+	return false, fmt.Sprintf("deployment %q has no available condition", deployment.Name)
+}
+
+// NodeReady returns whether the node has the ready condition
+func NodeReady(node *corev1.Node) (bool, string) {
+	if node == nil {
+		return false, "node is nil"
+	}
+
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			if condition.Status == corev1.ConditionTrue {
+				return true, ""
+			}
+			return false, fmt.Sprintf("node %q is not ready: %s", node.Name, condition.Message)
+		}
+	}
+
+	return false, fmt.Sprintf("node %q has no ready condition", node.Name)
+}
+
+// NodesReady returns whether the list has nodes and all of them are ready
+func NodesReady(nodes *corev1.NodeList) (bool, string) {
+	if nodes == nil || len(nodes.Items) == 0 {
+		return false, "no nodes found"
+	}
+
+	var notReady []string
+	for i := range nodes.Items {
+		if ready, _ := NodeReady(&nodes.Items[i]); !ready {
+			notReady = append(notReady, nodes.Items[i].Name)
+		}
+	}
+
+	if len(notReady) > 0 {
+		sort.Strings(notReady)
+		return false, fmt.Sprintf("nodes not ready: %s", strings.Join(notReady, ", "))
+	}
+
+	return true, ""
+}
+
+// ContainsItemWithPrefix returns whether the kubernetes list object contains an item
+// whose name has the prefix
+func ContainsItemWithPrefix(list runtime.Object, prefix string) (bool, string) {
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return false, fmt.Sprintf("not a list type: %v", err)
+	}
+
+	for _, item := range items {
+		accessor, err := meta.Accessor(item)
+		if err != nil {
+			return false, fmt.Sprintf("unable to get item's objectmeta: %v", err)
+		}
+		if strings.HasPrefix(accessor.GetName(), prefix) {
+			return true, ""
+		}
+	}
+
+	return false, fmt.Sprintf("no item with prefix %s in %d items", prefix, len(items))
+}
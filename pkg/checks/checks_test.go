@@ -0,0 +1,58 @@
+package checks
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Checks", func() {
+	It("should check the deployment is available", func() {
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-deployment"},
+			Status: appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionFalse, Message: "minimum replicas unavailable"},
+			}},
+		}
+
+		available, details := DeploymentAvailable(deployment)
+		Expect(available).To(BeFalse())
+		Expect(details).To(Equal(`deployment "my-deployment" is not available: minimum replicas unavailable`))
+
+		deployment.Status.Conditions[0].Status = corev1.ConditionTrue
+		available, details = DeploymentAvailable(deployment)
+		Expect(available).To(BeTrue())
+		Expect(details).To(BeEmpty())
+	})
+
+	It("should check the nodes are ready", func() {
+		nodes := &corev1.NodeList{Items: []corev1.Node{
+			{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}, Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "worker-2"}},
+		}}
+
+		ready, details := NodesReady(nodes)
+		Expect(ready).To(BeFalse())
+		Expect(details).To(Equal("nodes not ready: worker-2"))
+
+		ready, _ = NodesReady(&corev1.NodeList{})
+		Expect(ready).To(BeFalse())
+	})
+
+	It("should check the list contains an item with the prefix", func() {
+		namespaces := &corev1.NamespaceList{Items: []corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "osde2e-abc"}}}}
+
+		contains, _ := ContainsItemWithPrefix(namespaces, "osde2e-")
+		Expect(contains).To(BeTrue())
+
+		contains, details := ContainsItemWithPrefix(namespaces, "test-")
+		Expect(contains).To(BeFalse())
+		Expect(details).To(Equal("no item with prefix test- in 1 items"))
+
+		contains, _ = ContainsItemWithPrefix(&corev1.Namespace{}, "osde2e-")
+		Expect(contains).To(BeFalse())
+	})
+})
@@ -0,0 +1,610 @@
+// Package kubernetesfake provides an in-memory kubernetes api server and an openshift client
+// connected to it, so test suite authors can unit test their harness code without a live
+// cluster. It serves discovery for the types registered in the client-go scheme (including the
+// openshift api types) and supports get, list (label and field selectors), create, update,
+// patch and delete. Objects are namespaced unless their kind is a well known cluster scoped
+// kind, registered with ClusterScoped or seeded without a namespace. Strategic merge and apply patches are applied as json merge patches and
+// watches are not supported
+//
+//	server, err := kubernetesfake.NewServer(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}})
+//	Expect(err).ShouldNot(HaveOccurred())
+//	defer server.Close()
+//
+//	client, err := server.Client()
+package kubernetesfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/openshift/api"
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+)
+
+// clusterScopedGroups are the api groups whose kinds are all cluster scoped
+var clusterScopedGroups = []string{"config.openshift.io", "operator.openshift.io", "user.openshift.io", "oauth.openshift.io"}
+
+// namespacedKinds are the namespaced kinds of the cluster scoped api groups
+var namespacedKinds = []schema.GroupKind{
+	{Group: "operator.openshift.io", Kind: "IngressController"},
+}
+
+// clusterScopedKinds are the well known cluster scoped kinds of the other api groups
+var clusterScopedKinds = []schema.GroupKind{
+	{Kind: "Namespace"},
+	{Kind: "Node"},
+	{Kind: "PersistentVolume"},
+	{Kind: "ComponentStatus"},
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"},
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"},
+	{Group: "storage.k8s.io", Kind: "StorageClass"},
+	{Group: "storage.k8s.io", Kind: "CSIDriver"},
+	{Group: "storage.k8s.io", Kind: "CSINode"},
+	{Group: "storage.k8s.io", Kind: "VolumeAttachment"},
+	{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"},
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"},
+	{Group: "scheduling.k8s.io", Kind: "PriorityClass"},
+	{Group: "certificates.k8s.io", Kind: "CertificateSigningRequest"},
+	{Group: "networking.k8s.io", Kind: "IngressClass"},
+	{Group: "node.k8s.io", Kind: "RuntimeClass"},
+	{Group: "project.openshift.io", Kind: "Project"},
+	{Group: "security.openshift.io", Kind: "SecurityContextConstraints"},
+	{Group: "image.openshift.io", Kind: "Image"},
+	{Group: "quota.openshift.io", Kind: "ClusterResourceQuota"},
+}
+
+// Server is an in-memory kubernetes api server
+type Server struct {
+	server *httptest.Server
+	scheme *runtime.Scheme
+	// clusterScoped are the kinds served without a namespace
+	clusterScoped map[schema.GroupKind]bool
+
+	mu              sync.Mutex
+	objects         map[schema.GroupVersionResource]map[string]*unstructured.Unstructured
+	resourceVersion int
+}
+
+// NewServer starts the api server with the objects provided, it is the callers
+// responsibility to close it when they are finished (defer server.Close())
+func NewServer(objects ...k8s.Object) (*Server, error) {
+	if err := api.Install(scheme.Scheme); err != nil {
+		return nil, fmt.Errorf("unable to register openshift api schemes: %w", err)
+	}
+
+	s := &Server{
+		scheme:        scheme.Scheme,
+		clusterScoped: map[schema.GroupKind]bool{},
+		objects:       map[schema.GroupVersionResource]map[string]*unstructured.Unstructured{},
+	}
+	s.ClusterScoped(clusterScopedKinds...)
+
+	for _, object := range objects {
+		if err := s.Add(object); err != nil {
+			return nil, err
+		}
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s, nil
+}
+
+// ClusterScoped registers the kinds as cluster scoped (e.g. custom resources), it must be
+// called before creating clients as they cache the api servers discovery
+func (s *Server) ClusterScoped(groupKinds ...schema.GroupKind) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, groupKind := range groupKinds {
+		s.clusterScoped[groupKind] = true
+	}
+}
+
+// namespaced returns true when the kind is namespaced
+func (s *Server) namespaced(groupKind schema.GroupKind) bool {
+	for _, kind := range namespacedKinds {
+		if kind == groupKind {
+			return !s.clusterScoped[groupKind]
+		}
+	}
+
+	return !s.clusterScoped[groupKind] && !contains(clusterScopedGroups, groupKind.Group)
+}
+
+// Client returns an openshift client connected to the api server
+func (s *Server) Client() (*openshift.Client, error) {
+	return openshift.NewFromConfig(s.Config())
+}
+
+// Config returns the rest config to connect to the api server
+func (s *Server) Config() *rest.Config {
+	return &rest.Config{Host: s.server.URL, ContentConfig: rest.ContentConfig{ContentType: runtime.ContentTypeJSON}}
+}
+
+// Close shuts down the api server
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Add stores the object, replacing an existing object with the same name
+func (s *Server) Add(object k8s.Object) error {
+	gvks, _, err := s.scheme.ObjectKinds(object)
+	if err != nil {
+		return fmt.Errorf("failed to get object %q kind: %w", object.GetName(), err)
+	}
+	gvk := gvks[0]
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return fmt.Errorf("failed to convert object %q: %w", object.GetName(), err)
+	}
+
+	obj := &unstructured.Unstructured{Object: content}
+	obj.SetGroupVersionKind(gvk)
+	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if obj.GetNamespace() == "" {
+		s.clusterScoped[gvk.GroupKind()] = true
+	}
+
+	s.initialize(obj)
+	s.store(gvr)[key(obj.GetNamespace(), obj.GetName())] = obj
+
+	return nil
+}
+
+// request represents the parsed api request path
+type request struct {
+	gvr         schema.GroupVersionResource
+	namespace   string
+	name        string
+	subresource string
+}
+
+// serveHTTP routes the discovery and resource requests
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	parts := strings.Split(path, "/")
+
+	switch {
+	case path == "api":
+		writeJSON(w, http.StatusOK, &metav1.APIVersions{Versions: []string{"v1"}})
+		return
+	case path == "apis":
+		writeJSON(w, http.StatusOK, s.groups())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case parts[0] == "api" && len(parts) == 2:
+		writeJSON(w, http.StatusOK, s.resources(schema.GroupVersion{Version: parts[1]}))
+		return
+	case parts[0] == "apis" && len(parts) == 3:
+		writeJSON(w, http.StatusOK, s.resources(schema.GroupVersion{Group: parts[1], Version: parts[2]}))
+		return
+	}
+
+	req, ok := parseRequest(parts)
+	if !ok || r.URL.Query().Get("watch") == "true" {
+		writeStatus(w, apierrors.NewMethodNotSupported(schema.GroupResource{}, r.Method))
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && req.name == "":
+		s.list(w, r, req)
+	case r.Method == http.MethodGet:
+		s.get(w, req)
+	case r.Method == http.MethodPost:
+		s.create(w, r, req)
+	case r.Method == http.MethodPut:
+		s.update(w, r, req)
+	case r.Method == http.MethodPatch:
+		s.patch(w, r, req)
+	case r.Method == http.MethodDelete:
+		s.delete(w, r, req)
+	default:
+		writeStatus(w, apierrors.NewMethodNotSupported(req.gvr.GroupResource(), r.Method))
+	}
+}
+
+// parseRequest parses the resource request path, /api/v1/... or /apis/<group>/<version>/...
+// followed by [namespaces/<namespace>/]<resource>[/<name>[/<subresource>]]
+func parseRequest(parts []string) (*request, bool) {
+	req := &request{}
+
+	switch {
+	case parts[0] == "api" && len(parts) > 2:
+		req.gvr.Version = parts[1]
+		parts = parts[2:]
+	case parts[0] == "apis" && len(parts) > 3:
+		req.gvr.Group, req.gvr.Version = parts[1], parts[2]
+		parts = parts[3:]
+	default:
+		return nil, false
+	}
+
+	if parts[0] == "namespaces" && len(parts) > 2 {
+		req.namespace = parts[1]
+		parts = parts[2:]
+	}
+
+	req.gvr.Resource = parts[0]
+	if len(parts) > 1 {
+		req.name = parts[1]
+	}
+	if len(parts) > 2 {
+		req.subresource = parts[2]
+	}
+
+	return req, len(parts) <= 3
+}
+
+// kinds returns the kinds of the objects registered in the scheme for the group version by resource
+func (s *Server) kinds(gv schema.GroupVersion) map[string]string {
+	kinds := map[string]string{}
+
+	for kind := range s.scheme.KnownTypes(gv) {
+		gvk := gv.WithKind(kind)
+
+		object, err := s.scheme.New(gvk)
+		if err != nil || meta.IsListType(object) {
+			continue
+		}
+		if _, err = meta.Accessor(object); err != nil {
+			continue
+		}
+
+		gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+		kinds[gvr.Resource] = kind
+	}
+
+	return kinds
+}
+
+// groups returns the api groups registered in the scheme
+func (s *Server) groups() *metav1.APIGroupList {
+	versions := map[string][]string{}
+	for gvk := range s.scheme.AllKnownTypes() {
+		if gvk.Group == "" || gvk.Version == runtime.APIVersionInternal {
+			continue
+		}
+		if !contains(versions[gvk.Group], gvk.Version) {
+			versions[gvk.Group] = append(versions[gvk.Group], gvk.Version)
+		}
+	}
+
+	list := &metav1.APIGroupList{TypeMeta: metav1.TypeMeta{Kind: "APIGroupList", APIVersion: "v1"}}
+	for group, groupVersions := range versions {
+		sort.Strings(groupVersions)
+
+		apiGroup := metav1.APIGroup{Name: group}
+		for _, version := range groupVersions {
+			apiGroup.Versions = append(apiGroup.Versions, metav1.GroupVersionForDiscovery{
+				GroupVersion: schema.GroupVersion{Group: group, Version: version}.String(),
+				Version:      version,
+			})
+		}
+		apiGroup.PreferredVersion = apiGroup.Versions[len(apiGroup.Versions)-1]
+
+		list.Groups = append(list.Groups, apiGroup)
+	}
+
+	return list
+}
+
+// resources returns the resources of the group version
+func (s *Server) resources(gv schema.GroupVersion) *metav1.APIResourceList {
+	list := &metav1.APIResourceList{
+		TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+		GroupVersion: gv.String(),
+	}
+
+	for resource, kind := range s.kinds(gv) {
+		list.APIResources = append(list.APIResources, metav1.APIResource{
+			Name:       resource,
+			Namespaced: s.namespaced(gv.WithKind(kind).GroupKind()),
+			Kind:       kind,
+			Verbs:      metav1.Verbs{"get", "list", "create", "update", "patch", "delete"},
+		})
+	}
+
+	return list
+}
+
+// store returns the objects stored for the resource
+func (s *Server) store(gvr schema.GroupVersionResource) map[string]*unstructured.Unstructured {
+	if _, ok := s.objects[gvr]; !ok {
+		s.objects[gvr] = map[string]*unstructured.Unstructured{}
+	}
+	return s.objects[gvr]
+}
+
+// initialize sets the metadata the api server manages for new objects
+func (s *Server) initialize(obj *unstructured.Unstructured) {
+	if obj.GetName() == "" && obj.GetGenerateName() != "" {
+		obj.SetName(obj.GetGenerateName() + string(uuid.NewUUID())[:5])
+	}
+	obj.SetUID(uuid.NewUUID())
+	obj.SetCreationTimestamp(metav1.NewTime(time.Now()))
+	s.bump(obj)
+}
+
+// bump increments the objects resource version
+func (s *Server) bump(obj *unstructured.Unstructured) {
+	s.resourceVersion++
+	obj.SetResourceVersion(strconv.Itoa(s.resourceVersion))
+}
+
+// get writes the object
+func (s *Server) get(w http.ResponseWriter, req *request) {
+	obj, ok := s.store(req.gvr)[key(req.namespace, req.name)]
+	if !ok {
+		writeStatus(w, apierrors.NewNotFound(req.gvr.GroupResource(), req.name))
+		return
+	}
+	writeJSON(w, http.StatusOK, obj.Object)
+}
+
+// list writes the objects in the namespace (all namespaces when undefined) matching the selectors
+func (s *Server) list(w http.ResponseWriter, r *http.Request, req *request) {
+	labelSelector, err := labels.Parse(r.URL.Query().Get("labelSelector"))
+	if err != nil {
+		writeStatus(w, apierrors.NewBadRequest(err.Error()))
+		return
+	}
+
+	fieldSelector, err := fields.ParseSelector(r.URL.Query().Get("fieldSelector"))
+	if err != nil {
+		writeStatus(w, apierrors.NewBadRequest(err.Error()))
+		return
+	}
+
+	keys := make([]string, 0)
+	for k, obj := range s.store(req.gvr) {
+		if req.namespace != "" && obj.GetNamespace() != req.namespace {
+			continue
+		}
+		if !labelSelector.Matches(labels.Set(obj.GetLabels())) {
+			continue
+		}
+		if !fieldSelector.Matches(objectFields(obj)) {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	items := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		items = append(items, s.store(req.gvr)[k].Object)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"apiVersion": req.gvr.GroupVersion().String(),
+		"kind":       s.kinds(req.gvr.GroupVersion())[req.gvr.Resource] + "List",
+		"metadata":   map[string]interface{}{"resourceVersion": strconv.Itoa(s.resourceVersion)},
+		"items":      items,
+	})
+}
+
+// objectFields returns the field selector fields of the object, the metadata name and namespace
+// and the node name of pods
+func objectFields(obj *unstructured.Unstructured) fields.Set {
+	set := fields.Set{"metadata.name": obj.GetName(), "metadata.namespace": obj.GetNamespace()}
+	if nodeName, found, _ := unstructured.NestedString(obj.Object, "spec", "nodeName"); found {
+		set["spec.nodeName"] = nodeName
+	}
+	return set
+}
+
+// create stores the object, unless the request is a dry run
+func (s *Server) create(w http.ResponseWriter, r *http.Request, req *request) {
+	obj, err := decode(r.Body)
+	if err != nil {
+		writeStatus(w, apierrors.NewBadRequest(err.Error()))
+		return
+	}
+
+	if req.namespace != "" {
+		obj.SetNamespace(req.namespace)
+	}
+	s.initialize(obj)
+
+	k := key(obj.GetNamespace(), obj.GetName())
+	if _, ok := s.store(req.gvr)[k]; ok {
+		writeStatus(w, apierrors.NewAlreadyExists(req.gvr.GroupResource(), obj.GetName()))
+		return
+	}
+
+	if !dryRun(r) {
+		s.store(req.gvr)[k] = obj
+	}
+
+	writeJSON(w, http.StatusCreated, obj.Object)
+}
+
+// update replaces the existing object, rejecting stale resource versions
+func (s *Server) update(w http.ResponseWriter, r *http.Request, req *request) {
+	obj, err := decode(r.Body)
+	if err != nil {
+		writeStatus(w, apierrors.NewBadRequest(err.Error()))
+		return
+	}
+
+	existing, ok := s.store(req.gvr)[key(req.namespace, req.name)]
+	if !ok {
+		writeStatus(w, apierrors.NewNotFound(req.gvr.GroupResource(), req.name))
+		return
+	}
+
+	if obj.GetResourceVersion() != "" && obj.GetResourceVersion() != existing.GetResourceVersion() {
+		writeStatus(w, apierrors.NewConflict(req.gvr.GroupResource(), req.name, fmt.Errorf("the object has been modified")))
+		return
+	}
+
+	s.replace(w, r, req, existing, obj)
+}
+
+// patch applies the patch to the existing object, apply patches create the object when it does not exist
+func (s *Server) patch(w http.ResponseWriter, r *http.Request, req *request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeStatus(w, apierrors.NewBadRequest(err.Error()))
+		return
+	}
+
+	patchType := r.Header.Get("Content-Type")
+
+	existing, ok := s.store(req.gvr)[key(req.namespace, req.name)]
+	if !ok {
+		if patchType != string(types.ApplyPatchType) {
+			writeStatus(w, apierrors.NewNotFound(req.gvr.GroupResource(), req.name))
+			return
+		}
+		existing = &unstructured.Unstructured{Object: map[string]interface{}{}}
+		existing.SetName(req.name)
+		existing.SetNamespace(req.namespace)
+		s.initialize(existing)
+	}
+
+	current, err := json.Marshal(existing.Object)
+	if err != nil {
+		writeStatus(w, apierrors.NewInternalError(err))
+		return
+	}
+
+	var patched []byte
+	switch patchType {
+	case string(types.JSONPatchType):
+		var jsonPatch jsonpatch.Patch
+		if jsonPatch, err = jsonpatch.DecodePatch(body); err == nil {
+			patched, err = jsonPatch.Apply(current)
+		}
+	default:
+		patched, err = jsonpatch.MergePatch(current, body)
+	}
+	if err != nil {
+		writeStatus(w, apierrors.NewBadRequest(err.Error()))
+		return
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err = obj.UnmarshalJSON(patched); err != nil {
+		writeStatus(w, apierrors.NewBadRequest(err.Error()))
+		return
+	}
+
+	s.replace(w, r, req, existing, obj)
+}
+
+// replace stores the updated object keeping the metadata the api server manages
+func (s *Server) replace(w http.ResponseWriter, r *http.Request, req *request, existing, obj *unstructured.Unstructured) {
+	obj.SetName(existing.GetName())
+	obj.SetNamespace(existing.GetNamespace())
+	obj.SetUID(existing.GetUID())
+	obj.SetCreationTimestamp(existing.GetCreationTimestamp())
+	s.bump(obj)
+
+	if !dryRun(r) {
+		s.store(req.gvr)[key(obj.GetNamespace(), obj.GetName())] = obj
+	}
+
+	writeJSON(w, http.StatusOK, obj.Object)
+}
+
+// delete removes the object
+func (s *Server) delete(w http.ResponseWriter, r *http.Request, req *request) {
+	k := key(req.namespace, req.name)
+	if _, ok := s.store(req.gvr)[k]; !ok {
+		writeStatus(w, apierrors.NewNotFound(req.gvr.GroupResource(), req.name))
+		return
+	}
+
+	if !dryRun(r) {
+		delete(s.store(req.gvr), k)
+	}
+
+	writeJSON(w, http.StatusOK, &metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusSuccess,
+	})
+}
+
+// key returns the objects store key
+func key(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// dryRun returns true when the request is a server side dry run
+func dryRun(r *http.Request) bool {
+	return len(r.URL.Query()["dryRun"]) > 0
+}
+
+// contains returns true when the value is in the values
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// decode decodes the request body into an unstructured object
+func decode(body io.Reader) (*unstructured.Unstructured, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err = obj.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// writeJSON writes the value as the json response
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// writeStatus writes the api error as a status response
+func writeStatus(w http.ResponseWriter, err *apierrors.StatusError) {
+	status := err.ErrStatus
+	status.TypeMeta = metav1.TypeMeta{Kind: "Status", APIVersion: "v1"}
+	writeJSON(w, int(status.Code), &status)
+}
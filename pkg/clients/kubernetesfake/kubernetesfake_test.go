@@ -0,0 +1,80 @@
+package kubernetesfake
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+)
+
+var _ = Describe("Kubernetes Fake", func() {
+	var (
+		ctx    = context.Background()
+		server *Server
+	)
+
+	BeforeEach(func() {
+		var err error
+		server, err = NewServer(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+			&configv1.ClusterVersion{ObjectMeta: metav1.ObjectMeta{Name: "version"}, Spec: configv1.ClusterVersionSpec{Channel: "stable-4.13"}},
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		DeferCleanup(server.Close)
+	})
+
+	It("should serve the seeded objects", func() {
+		client, err := server.Client()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		var clusterVersion configv1.ClusterVersion
+		Expect(client.Get(ctx, "version", "", &clusterVersion)).To(Succeed())
+		Expect(clusterVersion.Spec.Channel).To(Equal("stable-4.13"))
+
+		var namespaces corev1.NamespaceList
+		Expect(client.List(ctx, &namespaces)).To(Succeed())
+		Expect(namespaces.Items).To(HaveLen(1))
+	})
+
+	It("should create, update, patch and delete objects", func() {
+		client, err := server.Client()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "test", Labels: map[string]string{"app": "e2e"}},
+			Data:       map[string]string{"key": "value"},
+		}
+		Expect(client.Create(ctx, configMap)).To(Succeed())
+		Expect(apierrors.IsAlreadyExists(client.Create(ctx, configMap.DeepCopy()))).To(BeTrue())
+
+		configMap.Data["key"] = "updated"
+		Expect(client.Update(ctx, configMap)).To(Succeed())
+
+		patch := k8s.Patch{PatchType: types.MergePatchType, Data: []byte(`{"data":{"other":"patched"}}`)}
+		Expect(client.Patch(ctx, configMap, patch)).To(Succeed())
+		Expect(configMap.Data).To(Equal(map[string]string{"key": "updated", "other": "patched"}))
+
+		var configMaps corev1.ConfigMapList
+		Expect(client.WithNamespace("test").List(ctx, &configMaps, resources.WithLabelSelector("app=e2e"))).To(Succeed())
+		Expect(configMaps.Items).To(HaveLen(1))
+
+		Expect(client.Delete(ctx, configMap)).To(Succeed())
+		Expect(apierrors.IsNotFound(client.Get(ctx, "settings", "test", &corev1.ConfigMap{}))).To(BeTrue())
+	})
+
+	It("should not persist dry run requests", func() {
+		client, err := server.Client()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(client.DryRunCreate(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "dry-run", Namespace: "test"}})).To(Succeed())
+		Expect(apierrors.IsNotFound(client.Get(ctx, "dry-run", "test", &corev1.ConfigMap{}))).To(BeTrue())
+	})
+})
@@ -0,0 +1,68 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+
+	accountsmgmtv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+)
+
+// CurrentAccount returns the account the client is authenticated as
+func (c *Client) CurrentAccount(ctx context.Context) (*accountsmgmtv1.Account, error) {
+	response, err := c.AccountsMgmt().V1().CurrentAccount().Get().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current account: %v", err)
+	}
+	return response.Body(), nil
+}
+
+// CurrentOrganization returns the organization of the account the client is authenticated as
+func (c *Client) CurrentOrganization(ctx context.Context) (*accountsmgmtv1.Organization, error) {
+	account, err := c.CurrentAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	organizationID := account.Organization().ID()
+	if organizationID == "" {
+		return nil, fmt.Errorf("current account %q has no organization", account.Username())
+	}
+
+	response, err := c.AccountsMgmt().V1().Organizations().Organization(organizationID).Get().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization %q: %v", organizationID, err)
+	}
+
+	return response.Body(), nil
+}
+
+// Subscription returns the subscription including its creators account. Subscriptions outlive their
+// clusters, the status of a deleted clusters subscription is Deprovisioned
+func (c *Client) Subscription(ctx context.Context, subscriptionID string) (*accountsmgmtv1.Subscription, error) {
+	response, err := c.AccountsMgmt().V1().Subscriptions().Subscription(subscriptionID).Get().
+		Parameter("fetchAccounts", true).
+		SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription %q: %v", subscriptionID, err)
+	}
+	return response.Body(), nil
+}
+
+// ClusterSubscription returns the clusters subscription (status, support level, creator, ...)
+func (c *Client) ClusterSubscription(ctx context.Context, clusterID string) (*accountsmgmtv1.Subscription, error) {
+	subscriptionID, err := c.clusterSubscriptionID(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscriptionID == "" {
+		return nil, fmt.Errorf("cluster id %q has no subscription", clusterID)
+	}
+
+	subscription, err := c.Subscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster id %q subscription: %v", clusterID, err)
+	}
+
+	return subscription, nil
+}
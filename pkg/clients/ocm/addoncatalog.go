@@ -0,0 +1,173 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// addonCatalogPageSize is the number of addons or addon versions requested per page
+const addonCatalogPageSize = 100
+
+// AvailableAddons returns the enabled addons of the ocm addon catalog sorted by id
+func (c *Client) AvailableAddons(ctx context.Context) ([]*clustersmgmtv1.AddOn, error) {
+	var addons []*clustersmgmtv1.AddOn
+
+	for page := 1; ; page++ {
+		response, err := c.ClustersMgmt().V1().Addons().List().
+			Search("enabled = 't'").
+			Page(page).
+			Size(addonCatalogPageSize).
+			SendContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list addons: %v", err)
+		}
+
+		addons = append(addons, response.Items().Slice()...)
+
+		if response.Size() < addonCatalogPageSize {
+			break
+		}
+	}
+
+	sort.Slice(addons, func(i, j int) bool { return addons[i].ID() < addons[j].ID() })
+
+	return addons, nil
+}
+
+// CatalogAddon returns the addon from the ocm addon catalog, including its current version,
+// parameters and requirements
+func (c *Client) CatalogAddon(ctx context.Context, addonID string) (*clustersmgmtv1.AddOn, error) {
+	response, err := c.ClustersMgmt().V1().Addons().Addon(addonID).Get().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get addon %q: %v", addonID, err)
+	}
+	return response.Body(), nil
+}
+
+// AddonVersions returns the enabled versions of the addon
+func (c *Client) AddonVersions(ctx context.Context, addonID string) ([]*clustersmgmtv1.AddOnVersion, error) {
+	var versions []*clustersmgmtv1.AddOnVersion
+
+	for page := 1; ; page++ {
+		response, err := c.ClustersMgmt().V1().Addons().Addon(addonID).Versions().List().
+			Page(page).
+			Size(addonCatalogPageSize).
+			SendContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list addon %q versions: %v", addonID, err)
+		}
+
+		for _, version := range response.Items().Slice() {
+			if version.Enabled() {
+				versions = append(versions, version)
+			}
+		}
+
+		if response.Size() < addonCatalogPageSize {
+			return versions, nil
+		}
+	}
+}
+
+// ValidateAddonParameters validates the parameter values against the addons parameters in the
+// catalog: required parameters without a default are provided, parameters exist and values match
+// the parameters options, value type and validation. All problems are returned in the error
+func (c *Client) ValidateAddonParameters(ctx context.Context, addonID string, values map[string]string) error {
+	addon, err := c.CatalogAddon(ctx, addonID)
+	if err != nil {
+		return err
+	}
+
+	parameters := addon.Parameters().Slice()
+	if addon.Version().Parameters().Len() > 0 {
+		parameters = addon.Version().Parameters().Slice()
+	}
+
+	if problems := validateAddonParameters(parameters, values); len(problems) > 0 {
+		return fmt.Errorf("addon %q parameters are invalid: %s", addonID, strings.Join(problems, ", "))
+	}
+
+	return nil
+}
+
+// validateAddonParameters returns the problems with the values for the addon parameters
+func validateAddonParameters(parameters []*clustersmgmtv1.AddOnParameter, values map[string]string) []string {
+	var problems []string
+
+	known := map[string]bool{}
+
+	for _, parameter := range parameters {
+		if !parameter.Enabled() {
+			continue
+		}
+		known[parameter.ID()] = true
+
+		value, ok := values[parameter.ID()]
+		if !ok {
+			if parameter.Required() && parameter.DefaultValue() == "" {
+				problems = append(problems, fmt.Sprintf("%s is required", parameter.ID()))
+			}
+			continue
+		}
+
+		if problem := validateAddonParameter(parameter, value); problem != "" {
+			problems = append(problems, fmt.Sprintf("%s %s", parameter.ID(), problem))
+		}
+	}
+
+	ids := make([]string, 0, len(values))
+	for id := range values {
+		if !known[id] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		problems = append(problems, fmt.Sprintf("%s is not a parameter of the addon", id))
+	}
+
+	return problems
+}
+
+// validateAddonParameter returns why the value is invalid for the parameter, empty when it is valid
+func validateAddonParameter(parameter *clustersmgmtv1.AddOnParameter, value string) string {
+	if options := parameter.Options(); len(options) > 0 {
+		allowed := make([]string, 0, len(options))
+		for _, option := range options {
+			if option.Value() == value {
+				return ""
+			}
+			allowed = append(allowed, option.Value())
+		}
+		return fmt.Sprintf("must be one of %s", strings.Join(allowed, ", "))
+	}
+
+	switch parameter.ValueType() {
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return "must be a boolean"
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "must be a number"
+		}
+	}
+
+	if parameter.Validation() != "" {
+		validation, err := regexp.Compile(parameter.Validation())
+		if err == nil && !validation.MatchString(value) {
+			if parameter.ValidationErrMsg() != "" {
+				return parameter.ValidationErrMsg()
+			}
+			return fmt.Sprintf("must match %s", parameter.Validation())
+		}
+	}
+
+	return ""
+}
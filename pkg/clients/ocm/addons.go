@@ -0,0 +1,180 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	addonPollInterval   = 30 * time.Second
+	defaultAddonTimeout = 30 * time.Minute
+)
+
+// AddonOptions represents data used to install an addon on a cluster
+type AddonOptions struct {
+	// ID is the addon id (e.g. managed-odh)
+	ID string
+	// Parameters are the addon parameter values by parameter id
+	Parameters map[string]string
+	// Timeout is how long to wait for the addon to be ready, defaults to 30 minutes
+	Timeout time.Duration
+	// SkipWait returns once the installation is accepted without waiting for the addon to be ready
+	SkipWait bool
+}
+
+// addonError represents the custom error
+type addonError struct {
+	action string
+	err    error
+}
+
+// Error returns the formatted error message when addonError is invoked
+func (a *addonError) Error() string {
+	return fmt.Sprintf("%s addon failed: %v", a.action, a.err)
+}
+
+// InstallAddon validates the parameters against the addon catalog, installs the addon on the
+// cluster and waits for it to be ready
+func (c *Client) InstallAddon(ctx context.Context, clusterID string, options *AddonOptions) error {
+	const action = "install"
+
+	if options.ID == "" {
+		return &addonError{action: action, err: fmt.Errorf("addon id is required")}
+	}
+
+	if err := c.ValidateAddonParameters(ctx, options.ID, options.Parameters); err != nil {
+		return &addonError{action: action, err: err}
+	}
+
+	ids := make([]string, 0, len(options.Parameters))
+	for id := range options.Parameters {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	parameters := make([]*clustersmgmtv1.AddOnInstallationParameterBuilder, 0, len(ids))
+	for _, id := range ids {
+		parameters = append(parameters, clustersmgmtv1.NewAddOnInstallationParameter().ID(id).Value(options.Parameters[id]))
+	}
+
+	installation, err := clustersmgmtv1.NewAddOnInstallation().
+		ID(options.ID).
+		Addon(clustersmgmtv1.NewAddOn().ID(options.ID)).
+		Parameters(clustersmgmtv1.NewAddOnInstallationParameterList().Items(parameters...)).
+		Build()
+	if err != nil {
+		return &addonError{action: action, err: fmt.Errorf("failed to build addon installation: %v", err)}
+	}
+
+	_, err = c.ClustersMgmt().V1().Clusters().Cluster(clusterID).Addons().Add().Body(installation).SendContext(ctx)
+	if err != nil {
+		return &addonError{action: action, err: fmt.Errorf("failed to install cluster %q addon %q: %v", clusterID, options.ID, err)}
+	}
+
+	log.Printf("Cluster %q addon %q installation requested", clusterID, options.ID)
+
+	if options.SkipWait {
+		return nil
+	}
+
+	return c.WaitForAddonReady(ctx, clusterID, options.ID, options.Timeout)
+}
+
+// WaitForAddonReady waits for the clusters addon installation to report ready, failing
+// once it reports failed. The timeout defaults to 30 minutes
+func (c *Client) WaitForAddonReady(ctx context.Context, clusterID, addonID string, timeout time.Duration) error {
+	if timeout == 0 {
+		timeout = defaultAddonTimeout
+	}
+
+	err := wait.PollUntilContextTimeout(ctx, addonPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		installation, err := c.Addon(ctx, clusterID, addonID)
+		if err != nil {
+			log.Println(err)
+			return false, nil
+		}
+
+		switch installation.State() {
+		case clustersmgmtv1.AddOnInstallationStateReady:
+			log.Printf("Cluster %q addon %q is ready", clusterID, addonID)
+			return true, nil
+		case clustersmgmtv1.AddOnInstallationStateFailed:
+			return false, fmt.Errorf("addon is in failed state: %s", installation.StateDescription())
+		}
+
+		log.Printf("Cluster %q addon %q not ready (state=%s)", clusterID, addonID, installation.State())
+
+		return false, nil
+	})
+	if err != nil {
+		return &addonError{action: "install", err: fmt.Errorf("cluster %q addon %q failed to be ready: %v", clusterID, addonID, err)}
+	}
+
+	return nil
+}
+
+// Addon returns the clusters addon installation
+func (c *Client) Addon(ctx context.Context, clusterID, addonID string) (*clustersmgmtv1.AddOnInstallation, error) {
+	response, err := c.ClustersMgmt().V1().Clusters().Cluster(clusterID).Addons().Addoninstallation(addonID).Get().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster %q addon %q: %v", clusterID, addonID, err)
+	}
+	return response.Body(), nil
+}
+
+// Addons returns the addons installed on the cluster
+func (c *Client) Addons(ctx context.Context, clusterID string) ([]*clustersmgmtv1.AddOnInstallation, error) {
+	response, err := c.ClustersMgmt().V1().Clusters().Cluster(clusterID).Addons().List().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster %q addons: %v", clusterID, err)
+	}
+	return response.Items().Slice(), nil
+}
+
+// UninstallAddon uninstalls the addon from the cluster and waits for the installation to be
+// removed. The timeout defaults to 30 minutes
+func (c *Client) UninstallAddon(ctx context.Context, clusterID, addonID string, timeout time.Duration) error {
+	const action = "uninstall"
+
+	if timeout == 0 {
+		timeout = defaultAddonTimeout
+	}
+
+	addon := c.ClustersMgmt().V1().Clusters().Cluster(clusterID).Addons().Addoninstallation(addonID)
+
+	_, err := addon.Delete().SendContext(ctx)
+	if err != nil {
+		return &addonError{action: action, err: fmt.Errorf("failed to uninstall cluster %q addon %q: %v", clusterID, addonID, err)}
+	}
+
+	log.Printf("Cluster %q addon %q uninstall requested", clusterID, addonID)
+
+	err = wait.PollUntilContextTimeout(ctx, addonPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		response, err := addon.Get().SendContext(ctx)
+		if err != nil {
+			if response != nil && response.Status() == http.StatusNotFound {
+				return true, nil
+			}
+			log.Printf("Failed to get cluster %q addon %q: %v", clusterID, addonID, err)
+			return false, nil
+		}
+
+		log.Printf("Cluster %q addon %q still uninstalling (state=%s)", clusterID, addonID, response.Body().State())
+
+		return false, nil
+	})
+	if err != nil {
+		return &addonError{action: action, err: fmt.Errorf("cluster %q addon %q failed to be removed: %v", clusterID, addonID, err)}
+	}
+
+	log.Printf("Cluster %q addon %q uninstalled", clusterID, addonID)
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	adminCredentialsPollInterval   = 30 * time.Second
+	defaultAdminCredentialsTimeout = 30 * time.Minute
+)
+
+// AdminCredentials are the clusters cluster admin (kubeadmin) username and password
+type AdminCredentials struct {
+	Username string
+	Password string
+}
+
+// ClusterAdminCredentials returns the clusters admin credentials, an error when they are not
+// populated (e.g. the cluster is still installing or was created without them)
+func (c *Client) ClusterAdminCredentials(ctx context.Context, clusterID string) (*AdminCredentials, error) {
+	response, err := c.ClustersMgmt().V1().Clusters().Cluster(clusterID).Credentials().Get().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credentials for cluster id %q: %v", clusterID, err)
+	}
+
+	admin := response.Body().Admin()
+	if admin.User() == "" || admin.Password() == "" {
+		return nil, fmt.Errorf("cluster id %q admin credentials are not populated", clusterID)
+	}
+
+	return &AdminCredentials{Username: admin.User(), Password: admin.Password()}, nil
+}
+
+// WaitForClusterAdminCredentials waits for the clusters admin credentials to be populated after
+// the cluster is installed and returns them. The timeout defaults to 30 minutes
+func (c *Client) WaitForClusterAdminCredentials(ctx context.Context, clusterID string, timeout time.Duration) (*AdminCredentials, error) {
+	if timeout == 0 {
+		timeout = defaultAdminCredentialsTimeout
+	}
+
+	var credentials *AdminCredentials
+
+	err := wait.PollUntilContextTimeout(ctx, adminCredentialsPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		var err error
+		credentials, err = c.ClusterAdminCredentials(ctx, clusterID)
+		if err != nil {
+			log.Println(err)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cluster id %q admin credentials were not populated: %v", clusterID, err)
+	}
+
+	return credentials, nil
+}
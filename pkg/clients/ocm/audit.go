@@ -0,0 +1,126 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LimitedSupportReason represents a reason the cluster was placed into limited support
+type LimitedSupportReason struct {
+	ID            string
+	Summary       string
+	Details       string
+	DetectionType string
+	CreatedAt     time.Time
+}
+
+// ServiceLog represents a service log (notification) sent for the cluster
+type ServiceLog struct {
+	ID           string
+	Severity     string
+	ServiceName  string
+	Summary      string
+	Description  string
+	InternalOnly bool
+	Timestamp    time.Time
+}
+
+// ClusterAudit represents the sre visible events generated for a cluster during a time window
+type ClusterAudit struct {
+	ClusterID             string
+	Since                 time.Time
+	Until                 time.Time
+	LimitedSupportReasons []*LimitedSupportReason
+	ServiceLogs           []*ServiceLog
+}
+
+// Empty returns true when no limited support reasons or service logs were generated
+func (a *ClusterAudit) Empty() bool {
+	return len(a.LimitedSupportReasons) == 0 && len(a.ServiceLogs) == 0
+}
+
+// String returns the audit events formatted for assertion failure messages
+func (a *ClusterAudit) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "cluster %q events between %s and %s:\n", a.ClusterID, a.Since.Format(time.RFC3339), a.Until.Format(time.RFC3339))
+	for _, reason := range a.LimitedSupportReasons {
+		fmt.Fprintf(&b, "  %s limited support: %s (%s)\n", reason.CreatedAt.Format(time.RFC3339), reason.Summary, reason.Details)
+	}
+	for _, serviceLog := range a.ServiceLogs {
+		fmt.Fprintf(&b, "  %s service log [%s] %s: %s\n", serviceLog.Timestamp.Format(time.RFC3339), serviceLog.Severity, serviceLog.ServiceName, serviceLog.Summary)
+	}
+
+	return b.String()
+}
+
+// inWindow returns true when the time is within the audit window
+func (a *ClusterAudit) inWindow(t time.Time) bool {
+	return !t.Before(a.Since) && !t.After(a.Until)
+}
+
+// AuditCluster returns the limited support reasons and service logs generated for the
+// cluster between since and until (now when zero), e.g. the start and end of a test run
+//
+//	audit, err := client.AuditCluster(ctx, clusterID, start, time.Time{})
+//	Expect(err).ShouldNot(HaveOccurred())
+//	Expect(audit.Empty()).To(BeTrue(), audit.String())
+func (c *Client) AuditCluster(ctx context.Context, clusterID string, since, until time.Time) (*ClusterAudit, error) {
+	if until.IsZero() {
+		until = time.Now()
+	}
+
+	audit := &ClusterAudit{ClusterID: clusterID, Since: since, Until: until}
+
+	response, err := c.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster id %q: %v", clusterID, err)
+	}
+	externalID := response.Body().ExternalID()
+
+	if err = c.auditLimitedSupportReasons(ctx, audit); err != nil {
+		return nil, err
+	}
+
+	if err = c.auditServiceLogs(ctx, audit, externalID); err != nil {
+		return nil, err
+	}
+
+	return audit, nil
+}
+
+// auditLimitedSupportReasons adds the clusters limited support reasons created within the audit window
+func (c *Client) auditLimitedSupportReasons(ctx context.Context, audit *ClusterAudit) error {
+	reasons, err := c.GetLimitedSupportReasons(ctx, audit.ClusterID)
+	if err != nil {
+		return err
+	}
+
+	for _, reason := range reasons {
+		if audit.inWindow(reason.CreatedAt) {
+			audit.LimitedSupportReasons = append(audit.LimitedSupportReasons, reason)
+		}
+	}
+
+	return nil
+}
+
+// auditServiceLogs adds the clusters service logs sent within the audit window
+func (c *Client) auditServiceLogs(ctx context.Context, audit *ClusterAudit, externalID string) error {
+	search := fmt.Sprintf("cluster_uuid = '%s' and timestamp >= '%s'", externalID, audit.Since.UTC().Format(time.RFC3339))
+
+	serviceLogs, err := c.listServiceLogs(ctx, audit.ClusterID, search)
+	if err != nil {
+		return err
+	}
+
+	for _, serviceLog := range serviceLogs {
+		if audit.inWindow(serviceLog.Timestamp) {
+			audit.ServiceLogs = append(audit.ServiceLogs, serviceLog)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	breakGlassPollInterval   = 10 * time.Second
+	defaultBreakGlassTimeout = 10 * time.Minute
+)
+
+// BreakGlassCredentialStatus is the issuance state of a break glass credential
+type BreakGlassCredentialStatus string
+
+const (
+	BreakGlassCredentialCreated            BreakGlassCredentialStatus = "created"
+	BreakGlassCredentialIssued             BreakGlassCredentialStatus = "issued"
+	BreakGlassCredentialFailed             BreakGlassCredentialStatus = "failed"
+	BreakGlassCredentialExpired            BreakGlassCredentialStatus = "expired"
+	BreakGlassCredentialAwaitingRevocation BreakGlassCredentialStatus = "awaiting_revocation"
+	BreakGlassCredentialRevoked            BreakGlassCredentialStatus = "revoked"
+)
+
+// BreakGlassCredential represents an emergency kubeconfig issued for a hosted control plane
+// cluster using an external authentication provider
+type BreakGlassCredential struct {
+	ID                  string                     `json:"id"`
+	Username            string                     `json:"username"`
+	Status              BreakGlassCredentialStatus `json:"status"`
+	ExpirationTimestamp time.Time                  `json:"expiration_timestamp,omitempty"`
+	RevocationTimestamp time.Time                  `json:"revocation_timestamp,omitempty"`
+	// Kubeconfig is the credentials kubeconfig, populated once the credential is issued
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+}
+
+// breakGlassCredentialList represents the ocm break glass credentials list response
+type breakGlassCredentialList struct {
+	Items []*BreakGlassCredential `json:"items"`
+}
+
+// externalAuthCluster represents the cluster fields the ocm sdk does not provide for external authentication
+type externalAuthCluster struct {
+	Hypershift struct {
+		Enabled bool `json:"enabled"`
+	} `json:"hypershift"`
+	ExternalAuthConfig struct {
+		Enabled bool `json:"enabled"`
+	} `json:"external_auth_config"`
+}
+
+// breakGlassCredentialsPath returns the ocm api path for the clusters break glass credentials
+func breakGlassCredentialsPath(clusterID string) string {
+	return fmt.Sprintf("/api/clusters_mgmt/v1/clusters/%s/break_glass_credentials", clusterID)
+}
+
+// verifyExternalAuth returns an error when the cluster is not a hosted control plane cluster with
+// external authentication enabled, the only clusters break glass credentials are issued for
+func (c *Client) verifyExternalAuth(ctx context.Context, clusterID string) error {
+	var cluster externalAuthCluster
+	err := send(ctx, c.Get().Path(fmt.Sprintf("/api/clusters_mgmt/v1/clusters/%s", clusterID)), nil, http.StatusOK, &cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get cluster id %q: %v", clusterID, err)
+	}
+
+	if !cluster.Hypershift.Enabled || !cluster.ExternalAuthConfig.Enabled {
+		return fmt.Errorf("cluster id %q is not a hosted control plane cluster with external authentication enabled", clusterID)
+	}
+
+	return nil
+}
+
+// RequestBreakGlassCredential requests a break glass credential for the user on the hosted control
+// plane cluster with external authentication enabled, expiring after the expiration (the ocm
+// default when zero). Use WaitForBreakGlassCredential to wait for it to be issued
+func (c *Client) RequestBreakGlassCredential(ctx context.Context, clusterID, username string, expiration time.Duration) (*BreakGlassCredential, error) {
+	if err := c.verifyExternalAuth(ctx, clusterID); err != nil {
+		return nil, err
+	}
+
+	body := map[string]string{}
+	if username != "" {
+		body["username"] = username
+	}
+	if expiration > 0 {
+		body["expiration_timestamp"] = time.Now().Add(expiration).UTC().Format(time.RFC3339)
+	}
+
+	var credential BreakGlassCredential
+	err := send(ctx, c.Post().Path(breakGlassCredentialsPath(clusterID)), body, http.StatusCreated, &credential)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request break glass credential for cluster id %q: %v", clusterID, err)
+	}
+
+	log.Printf("Cluster id %q break glass credential %q requested for user %q", clusterID, credential.ID, credential.Username)
+
+	return &credential, nil
+}
+
+// BreakGlassCredential returns the clusters break glass credential
+func (c *Client) BreakGlassCredential(ctx context.Context, clusterID, credentialID string) (*BreakGlassCredential, error) {
+	var credential BreakGlassCredential
+	path := fmt.Sprintf("%s/%s", breakGlassCredentialsPath(clusterID), credentialID)
+	err := send(ctx, c.Get().Path(path), nil, http.StatusOK, &credential)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get break glass credential %q for cluster id %q: %v", credentialID, clusterID, err)
+	}
+
+	return &credential, nil
+}
+
+// BreakGlassCredentials returns the clusters break glass credentials
+func (c *Client) BreakGlassCredentials(ctx context.Context, clusterID string) ([]*BreakGlassCredential, error) {
+	var credentials breakGlassCredentialList
+	err := send(ctx, c.Get().Path(breakGlassCredentialsPath(clusterID)), nil, http.StatusOK, &credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get break glass credentials for cluster id %q: %v", clusterID, err)
+	}
+
+	return credentials.Items, nil
+}
+
+// WaitForBreakGlassCredential waits for the break glass credential to be issued and returns it with its
+// kubeconfig, failing once it failed, expired or was revoked. The timeout defaults to 10 minutes
+func (c *Client) WaitForBreakGlassCredential(ctx context.Context, clusterID, credentialID string, timeout time.Duration) (*BreakGlassCredential, error) {
+	if timeout == 0 {
+		timeout = defaultBreakGlassTimeout
+	}
+
+	var credential *BreakGlassCredential
+
+	err := wait.PollUntilContextTimeout(ctx, breakGlassPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		var err error
+		credential, err = c.BreakGlassCredential(ctx, clusterID, credentialID)
+		if err != nil {
+			log.Println(err)
+			return false, nil
+		}
+
+		switch credential.Status {
+		case BreakGlassCredentialIssued:
+			return credential.Kubeconfig != "", nil
+		case BreakGlassCredentialFailed, BreakGlassCredentialExpired, BreakGlassCredentialAwaitingRevocation, BreakGlassCredentialRevoked:
+			return false, fmt.Errorf("break glass credential is %s", credential.Status)
+		}
+
+		log.Printf("Cluster id %q break glass credential %q not issued (status=%s)", clusterID, credentialID, credential.Status)
+
+		return false, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cluster id %q break glass credential %q was not issued: %v", clusterID, credentialID, err)
+	}
+
+	log.Printf("Cluster id %q break glass credential %q issued", clusterID, credentialID)
+
+	return credential, nil
+}
+
+// BreakGlassKubeConfigFile writes the issued break glass credentials kubeconfig to the kubeconfig
+// directory and returns the file
+func (c *Client) BreakGlassKubeConfigFile(clusterID string, credential *BreakGlassCredential) (string, error) {
+	filename := filepath.Join(c.kubeConfigDir(), fmt.Sprintf("%s-break-glass-%s-kubeconfig", clusterID, credential.ID))
+
+	if credential.Kubeconfig == "" {
+		return filename, fmt.Errorf("break glass credential %q has not been issued", credential.ID)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return filename, fmt.Errorf("failed to create kubeconfig directory: %v", err)
+	}
+
+	if err := os.WriteFile(filename, []byte(credential.Kubeconfig), 0o600); err != nil {
+		return filename, fmt.Errorf("failed to write break glass kubeconfig file: %v", err)
+	}
+
+	return filename, nil
+}
+
+// RevokeBreakGlassCredentials revokes all of the clusters break glass credentials
+func (c *Client) RevokeBreakGlassCredentials(ctx context.Context, clusterID string) error {
+	err := send(ctx, c.Delete().Path(breakGlassCredentialsPath(clusterID)), nil, http.StatusNoContent, nil)
+	if err != nil {
+		return fmt.Errorf("failed to revoke break glass credentials for cluster id %q: %v", clusterID, err)
+	}
+
+	return nil
+}
@@ -3,8 +3,12 @@ package ocm
 import (
 	"context"
 	"fmt"
+	"log"
+	"net/http"
+	"net/url"
 
 	ocmsdk "github.com/openshift-online/ocm-sdk-go"
+	"github.com/openshift/osde2e-framework/internal/faultinjection"
 )
 
 type Environment string
@@ -13,20 +17,143 @@ const (
 	Production  Environment = "https://api.openshift.com"
 	Stage       Environment = "https://api.stage.openshift.com"
 	Integration Environment = "https://api.integration.openshift.com"
+
+	// FedRAMP environments serve the aws govcloud regions
+	FedRAMPProduction  Environment = "https://api.openshiftusgov.com"
+	FedRAMPStage       Environment = "https://api.stage.openshiftusgov.com"
+	FedRAMPIntegration Environment = "https://api.int.openshiftusgov.com"
 )
 
+// fedRAMPTokenURLs are the sso token urls of the fedramp environments
+var fedRAMPTokenURLs = map[Environment]string{
+	FedRAMPProduction:  "https://sso.openshiftusgov.com/realms/redhat-external/protocol/openid-connect/token",
+	FedRAMPStage:       "https://sso.stage.openshiftusgov.com/realms/redhat-external/protocol/openid-connect/token",
+	FedRAMPIntegration: "https://sso.int.openshiftusgov.com/realms/redhat-external/protocol/openid-connect/token",
+}
+
+// FedRAMP returns true when the environment is a fedramp (govcloud) environment
+func (e Environment) FedRAMP() bool {
+	_, ok := fedRAMPTokenURLs[e]
+	return ok
+}
+
+// TokenURL returns the url tokens for the environment are issued by, empty for the default
+// red hat sso token url
+func (e Environment) TokenURL() string {
+	return fedRAMPTokenURLs[e]
+}
+
 type Client struct {
 	*ocmsdk.Connection
+
+	// ArtifactDir is the directory kubeconfig files are written to when the kubeconfig directory is unset
+	ArtifactDir string
+
+	// KubeConfigDir is the directory kubeconfig files are written to, defaults to the artifact
+	// directory or the temporary directory when neither is set
+	KubeConfigDir string
+
+	// clientCredentials is true when the connection authenticates using service account client
+	// credentials, its tokens are requested again as they expire
+	clientCredentials bool
+
+	// proxyURL is the proxy the requests are sent through, the proxy from the environment when nil
+	proxyURL *url.URL
+
+	// transport sends the requests instead of the ocm sdk http transport when set, see WithTransport
+	transport http.RoundTripper
+
+	// observer logs and reports the metrics of the requests sent, see SetRequestLogger and SetRequestMetricsHook
+	observer *requestObserver
 }
 
+// Option configures optional settings of the ocm client
+type Option func(*Client)
+
+// WithTransport sends the ocm and sso requests using the round tripper instead of the network, e.g. to
+// unit test code using the client without ocm (see the ocmfake package). Requests are still retried
+// and observed by the client
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.transport = transport
+	}
+}
+
+// New returns an ocm client for the environment authenticated using the offline token
 func New(ctx context.Context, token string, environment Environment) (*Client, error) {
-	connection, err := ocmsdk.NewConnectionBuilder().
+	return NewWithCredentials(ctx, Credentials{Token: token}, environment)
+}
+
+// NewWithCredentials returns an ocm client for the environment authenticated using the credentials,
+// tokens are requested from the environments sso (see Environment.TokenURL). The gateway of custom
+// environments (see ParseEnvironment) is health checked before the client is returned
+func NewWithCredentials(ctx context.Context, credentials Credentials, environment Environment, options ...Option) (*Client, error) {
+	if err := credentials.validate(); err != nil {
+		return nil, fmt.Errorf("failed to create ocm connection: %w", err)
+	}
+
+	client := &Client{clientCredentials: credentials.ClientCredentials(), observer: &requestObserver{}}
+	for _, option := range options {
+		option(client)
+	}
+
+	// throttled and failed requests are retried by the retry transport, which honors
+	// Retry-After and includes the ocm operation ids in the returned errors. The observer
+	// runs within it to record every attempt
+	builder := ocmsdk.NewConnectionBuilder().
 		URL(string(environment)).
-		Tokens(token).
-		BuildContext(ctx)
+		RetryLimit(0).
+		TransportWrapper(retryTransportWrapper).
+		TransportWrapper(client.observer.transportWrapper)
+
+	if credentials.ClientCredentials() {
+		builder = builder.Client(credentials.ClientID, credentials.ClientSecret)
+	} else {
+		token, err := credentials.OfflineToken()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create ocm connection: %w", err)
+		}
+
+		info, err := verifyToken(token, environment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create ocm connection: %w", err)
+		}
+		builder = builder.Tokens(token)
+
+		// refresh tokens are refreshed by the sso that issued them
+		if environment.TokenURL() == "" && info.tokenURL() != "" {
+			builder = builder.TokenURL(info.tokenURL())
+		}
+	}
+
+	if tokenURL := environment.TokenURL(); tokenURL != "" {
+		builder = builder.TokenURL(tokenURL)
+	}
+
+	if faultinjection.Enabled() {
+		builder = builder.TransportWrapper(faultinjection.TransportWrapper)
+	}
+
+	switch {
+	case client.transport != nil:
+		builder = builder.TransportWrapper(func(http.RoundTripper) http.RoundTripper { return client.transport })
+	case client.proxyURL != nil:
+		log.Printf("Sending ocm requests through proxy %s", client.proxyURL.Redacted())
+		builder = builder.TransportWrapper(proxyTransportWrapper(client.proxyURL))
+	}
+
+	connection, err := builder.BuildContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create ocm connection: %w", err)
 	}
+	client.Connection = connection
+
+	if environment.Custom() {
+		if err = client.checkGateway(ctx, environment); err != nil {
+			_ = connection.Close()
+			return nil, err
+		}
+	}
 
-	return &Client{connection}, nil
+	return client, nil
 }
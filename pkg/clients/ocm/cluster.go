@@ -0,0 +1,157 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	accountsmgmtv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osde2e-framework/pkg/provenance"
+)
+
+// ClusterProperties returns the clusters properties
+func (c *Client) ClusterProperties(ctx context.Context, clusterID string) (map[string]string, error) {
+	response, err := c.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster id %q: %v", clusterID, err)
+	}
+	return response.Body().Properties(), nil
+}
+
+// ClusterProvenance returns the provenance data the cluster was stamped with at creation
+func (c *Client) ClusterProvenance(ctx context.Context, clusterID string) (provenance.Provenance, error) {
+	properties, err := c.ClusterProperties(ctx, clusterID)
+	if err != nil {
+		return provenance.Provenance{}, err
+	}
+	return provenance.FromProperties(properties), nil
+}
+
+// SetClusterProperties sets the properties on the cluster, keeping its other properties
+func (c *Client) SetClusterProperties(ctx context.Context, clusterID string, properties map[string]string) error {
+	for key := range properties {
+		if key == "" {
+			return fmt.Errorf("failed to set cluster %q properties: property key is required", clusterID)
+		}
+	}
+
+	existing, err := c.ClusterProperties(ctx, clusterID)
+	if err != nil {
+		return fmt.Errorf("failed to set cluster %q properties: %v", clusterID, err)
+	}
+
+	merged := make(map[string]string, len(existing)+len(properties))
+	for key, value := range existing {
+		merged[key] = value
+	}
+	for key, value := range properties {
+		merged[key] = value
+	}
+
+	cluster, err := clustersmgmtv1.NewCluster().Properties(merged).Build()
+	if err != nil {
+		return fmt.Errorf("failed to build cluster %q properties: %v", clusterID, err)
+	}
+
+	_, err = c.ClustersMgmt().V1().Clusters().Cluster(clusterID).Update().Body(cluster).SendContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to set cluster %q properties: %v", clusterID, err)
+	}
+
+	return nil
+}
+
+// ClusterLabels returns the labels of the clusters subscription
+func (c *Client) ClusterLabels(ctx context.Context, clusterID string) (map[string]string, error) {
+	subscriptionID, err := c.clusterSubscriptionID(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := c.subscriptionLabels(ctx, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster %q labels: %v", clusterID, err)
+	}
+
+	return labels, nil
+}
+
+// subscriptionLabels returns the subscriptions labels
+func (c *Client) subscriptionLabels(ctx context.Context, subscriptionID string) (map[string]string, error) {
+	response, err := c.AccountsMgmt().V1().Subscriptions().Subscription(subscriptionID).Labels().List().
+		Size(-1).
+		SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make(map[string]string, response.Items().Len())
+	for _, label := range response.Items().Slice() {
+		labels[label.Key()] = label.Value()
+	}
+
+	return labels, nil
+}
+
+// AddClusterLabels adds the labels to the clusters subscription (e.g. expiration, owner, job url),
+// updating the value of existing labels
+func (c *Client) AddClusterLabels(ctx context.Context, clusterID string, labels map[string]string) error {
+	subscriptionID, err := c.clusterSubscriptionID(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := c.subscriptionLabels(ctx, subscriptionID)
+	if err != nil {
+		return fmt.Errorf("failed to list cluster %q labels: %v", clusterID, err)
+	}
+
+	labelsClient := c.AccountsMgmt().V1().Subscriptions().Subscription(subscriptionID).Labels()
+
+	for key, value := range labels {
+		if key == "" {
+			return fmt.Errorf("failed to add cluster %q labels: label key is required", clusterID)
+		}
+
+		current, ok := existing[key]
+		if ok && current == value {
+			continue
+		}
+
+		label, err := accountsmgmtv1.NewLabel().Key(key).Value(value).Build()
+		if err != nil {
+			return fmt.Errorf("failed to build cluster %q label %q: %v", clusterID, key, err)
+		}
+
+		if ok {
+			_, err = labelsClient.Label(key).Update().Body(label).SendContext(ctx)
+		} else {
+			_, err = labelsClient.Add().Body(label).SendContext(ctx)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to add cluster %q label %q: %v", clusterID, key, err)
+		}
+	}
+
+	return nil
+}
+
+// RemoveClusterLabels removes the labels from the clusters subscription, labels that do not exist are ignored
+func (c *Client) RemoveClusterLabels(ctx context.Context, clusterID string, keys ...string) error {
+	subscriptionID, err := c.clusterSubscriptionID(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	labelsClient := c.AccountsMgmt().V1().Subscriptions().Subscription(subscriptionID).Labels()
+
+	for _, key := range keys {
+		response, err := labelsClient.Label(key).Delete().SendContext(ctx)
+		if err != nil && response.Status() != http.StatusNotFound {
+			return fmt.Errorf("failed to remove cluster %q label %q: %v", clusterID, key, err)
+		}
+	}
+
+	return nil
+}
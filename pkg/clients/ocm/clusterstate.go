@@ -0,0 +1,110 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	defaultClusterStatePollInterval = time.Minute
+	defaultClusterStateTimeout      = 30 * time.Minute
+)
+
+// ClusterStateDeleted is the state of clusters that no longer exist in ocm, used to wait for
+// clusters to finish uninstalling
+const ClusterStateDeleted clustersmgmtv1.ClusterState = "deleted"
+
+// ClusterStateOptions represents data used to wait for a cluster state
+type ClusterStateOptions struct {
+	// Timeout is how long to wait for the cluster state, defaults to 30 minutes
+	Timeout time.Duration
+	// PollInterval is how often the clusters state is polled, defaults to a minute
+	PollInterval time.Duration
+	// OnPoll is invoked with the clusters state each time it is polled (e.g. to stream the install
+	// logs), an error stops waiting
+	OnPoll func(ctx context.Context, state clustersmgmtv1.ClusterState) error
+	// OnStateChange is invoked each time the clusters state changes (e.g. to dispatch events)
+	OnStateChange func(previous, current clustersmgmtv1.ClusterState)
+}
+
+// WaitForClusterState waits for the cluster to reach the state (ClusterStateDeleted once it no
+// longer exists), failing once the cluster is in error state unless waiting for it to be deleted.
+// The timeout defaults to 30 minutes
+func (c *Client) WaitForClusterState(ctx context.Context, clusterID string, state clustersmgmtv1.ClusterState, timeout time.Duration) error {
+	return c.WaitForClusterStateWithOptions(ctx, clusterID, state, &ClusterStateOptions{Timeout: timeout})
+}
+
+// WaitForClusterStateWithOptions waits for the cluster to reach the state like WaitForClusterState,
+// invoking the options handlers as the cluster is polled
+func (c *Client) WaitForClusterStateWithOptions(ctx context.Context, clusterID string, state clustersmgmtv1.ClusterState, options *ClusterStateOptions) error {
+	if options == nil {
+		options = &ClusterStateOptions{}
+	}
+
+	timeout := options.Timeout
+	if timeout == 0 {
+		timeout = defaultClusterStateTimeout
+	}
+
+	interval := options.PollInterval
+	if interval == 0 {
+		interval = defaultClusterStatePollInterval
+	}
+
+	var previous clustersmgmtv1.ClusterState
+
+	err := wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+		current, message, err := c.clusterState(ctx, clusterID)
+		if err != nil {
+			log.Printf("Failed to get cluster %q state: %v", clusterID, err)
+			return false, nil
+		}
+
+		if current != previous && options.OnStateChange != nil {
+			options.OnStateChange(previous, current)
+		}
+		previous = current
+
+		if options.OnPoll != nil {
+			if err = options.OnPoll(ctx, current); err != nil {
+				return false, err
+			}
+		}
+
+		switch {
+		case current == state:
+			log.Printf("Cluster %q is %s", clusterID, state)
+			return true, nil
+		case current == clustersmgmtv1.ClusterStateError && state != ClusterStateDeleted:
+			return false, fmt.Errorf("cluster %q is in error state: %s", clusterID, message)
+		}
+
+		log.Printf("Cluster %q not %s yet (state=%s)", clusterID, state, current)
+
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("cluster %q failed to enter %s state: %v", clusterID, state, err)
+	}
+
+	return nil
+}
+
+// clusterState returns the clusters state and provision error message, ClusterStateDeleted when it does not exist
+func (c *Client) clusterState(ctx context.Context, clusterID string) (clustersmgmtv1.ClusterState, string, error) {
+	response, err := c.ClustersMgmt().V1().Clusters().Cluster(clusterID).Status().Get().SendContext(ctx)
+	if err != nil {
+		if response != nil && response.Status() == http.StatusNotFound {
+			return ClusterStateDeleted, "", nil
+		}
+		return "", "", err
+	}
+
+	return response.Body().State(), response.Body().ProvisionErrorMessage(), nil
+}
@@ -0,0 +1,70 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const clusterTransfersPath = "/api/accounts_mgmt/v1/cluster_transfers"
+
+// ClusterTransfer represents a request to transfer cluster ownership to another user
+type ClusterTransfer struct {
+	ID                  string     `json:"id,omitempty"`
+	ClusterUUID         string     `json:"cluster_uuid"`
+	Owner               string     `json:"owner"`
+	Recipient           string     `json:"recipient"`
+	Status              string     `json:"status,omitempty"`
+	ExpirationTimestamp *time.Time `json:"expiration_date,omitempty"`
+}
+
+// clusterTransferList represents the ocm cluster transfers list response
+type clusterTransferList struct {
+	Items []*ClusterTransfer `json:"items"`
+}
+
+// CreateClusterTransfer requests to transfer the cluster from the owner to the recipient (usernames)
+func (c *Client) CreateClusterTransfer(ctx context.Context, clusterID, owner, recipient string) (*ClusterTransfer, error) {
+	response, err := c.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster id %q: %v", clusterID, err)
+	}
+
+	body := &ClusterTransfer{ClusterUUID: response.Body().ExternalID(), Owner: owner, Recipient: recipient}
+
+	var transfer ClusterTransfer
+	err = send(ctx, c.Post().Path(clusterTransfersPath), body, http.StatusCreated, &transfer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cluster transfer for cluster id %q: %v", clusterID, err)
+	}
+
+	return &transfer, nil
+}
+
+// ClusterTransfers returns the transfers requested for the cluster
+func (c *Client) ClusterTransfers(ctx context.Context, clusterID string) ([]*ClusterTransfer, error) {
+	response, err := c.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster id %q: %v", clusterID, err)
+	}
+
+	var transfers clusterTransferList
+	request := c.Get().Path(clusterTransfersPath).Parameter("search", fmt.Sprintf("cluster_uuid = '%s'", response.Body().ExternalID()))
+	err = send(ctx, request, nil, http.StatusOK, &transfers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster transfers for cluster id %q: %v", clusterID, err)
+	}
+
+	return transfers.Items, nil
+}
+
+// CancelClusterTransfer cancels the pending cluster transfer
+func (c *Client) CancelClusterTransfer(ctx context.Context, transferID string) error {
+	body := map[string]string{"status": "Rescinded"}
+	err := send(ctx, c.Patch().Path(fmt.Sprintf("%s/%s", clusterTransfersPath, transferID)), body, http.StatusOK, nil)
+	if err != nil {
+		return fmt.Errorf("failed to cancel cluster transfer %q: %v", transferID, err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package ocm
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Credentials authenticate the ocm connection using one of an offline token, a file holding
+// an offline token or a service account client id and secret
+type Credentials struct {
+	Token string
+	// TokenFile is the path of the file holding the offline token
+	TokenFile string
+	// ClientID and ClientSecret are the service account client credentials
+	ClientID     string
+	ClientSecret string
+}
+
+// ClientCredentials returns true when the credentials are service account client credentials
+func (c Credentials) ClientCredentials() bool {
+	return c.ClientID != "" || c.ClientSecret != ""
+}
+
+// validate ensures exactly one authentication method is provided
+func (c Credentials) validate() error {
+	methods := 0
+	if c.Token != "" {
+		methods++
+	}
+	if c.TokenFile != "" {
+		methods++
+	}
+	if c.ClientCredentials() {
+		if c.ClientID == "" || c.ClientSecret == "" {
+			return fmt.Errorf("client credentials require both the client id and client secret")
+		}
+		methods++
+	}
+
+	switch methods {
+	case 0:
+		return fmt.Errorf("no ocm credentials provided, a token, token file or client credentials are required")
+	case 1:
+		return nil
+	default:
+		return fmt.Errorf("only one of a token, token file or client credentials can be provided")
+	}
+}
+
+// OfflineToken returns the offline token, read from the token file when provided.
+// It is empty for client credentials
+func (c Credentials) OfflineToken() (string, error) {
+	if c.TokenFile == "" {
+		return c.Token, nil
+	}
+
+	data, err := os.ReadFile(c.TokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read ocm token file: %v", err)
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("ocm token file %s is empty", c.TokenFile)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,32 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DNSDomain represents a base dns domain reserved in ocm for cluster installs
+type DNSDomain struct {
+	ID      string `json:"id"`
+	Cluster struct {
+		ID string `json:"id"`
+	} `json:"cluster"`
+	Organization struct {
+		ID string `json:"id"`
+	} `json:"organization"`
+	ReservedAt  time.Time `json:"reserved_at"`
+	UserDefined bool      `json:"user_defined"`
+}
+
+// DNSDomain returns the reserved base dns domain
+func (c *Client) DNSDomain(ctx context.Context, domain string) (*DNSDomain, error) {
+	var dnsDomain DNSDomain
+	err := send(ctx, c.Get().Path(fmt.Sprintf("/api/clusters_mgmt/v1/dns_domains/%s", domain)), nil, http.StatusOK, &dnsDomain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dns domain %q: %v", domain, err)
+	}
+
+	return &dnsDomain, nil
+}
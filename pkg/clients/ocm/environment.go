@@ -0,0 +1,77 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// environments are the ocm environments by name
+var environments = map[string]Environment{
+	"production":          Production,
+	"stage":               Stage,
+	"integration":         Integration,
+	"fedramp-production":  FedRAMPProduction,
+	"fedramp-stage":       FedRAMPStage,
+	"fedramp-integration": FedRAMPIntegration,
+}
+
+// tokenIssuers are the sso hosts issuing the tokens each environment accepts
+var tokenIssuers = map[Environment][]string{
+	Production:         {"sso.redhat.com"},
+	Stage:              {"sso.redhat.com", "sso.stage.redhat.com"},
+	Integration:        {"sso.redhat.com", "sso.stage.redhat.com"},
+	FedRAMPProduction:  {"sso.openshiftusgov.com"},
+	FedRAMPStage:       {"sso.stage.openshiftusgov.com"},
+	FedRAMPIntegration: {"sso.int.openshiftusgov.com"},
+}
+
+// tokenIssuers returns the sso hosts issuing the tokens the environment accepts, empty for custom environments
+func (e Environment) tokenIssuers() []string {
+	return tokenIssuers[e]
+}
+
+// ParseEnvironment returns the ocm environment by name (e.g. production, stage, integration,
+// fedramp-stage) or a custom environment for the url of an ocm gateway (e.g. an ephemeral environment)
+func ParseEnvironment(value string) (Environment, error) {
+	if environment, ok := environments[strings.ToLower(value)]; ok {
+		return environment, nil
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		names := make([]string, 0, len(environments))
+		for name := range environments {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return "", fmt.Errorf("unknown ocm environment %q, expected one of %s or an ocm url", value, strings.Join(names, ", "))
+	}
+
+	return Environment(strings.TrimSuffix(value, "/")), nil
+}
+
+// Custom returns true when the environment is not one of the known ocm environments
+func (e Environment) Custom() bool {
+	for _, environment := range environments {
+		if e == environment {
+			return false
+		}
+	}
+	return true
+}
+
+// checkGateway verifies the ocm gateway is serving the clusters management api
+func (c *Client) checkGateway(ctx context.Context, environment Environment) error {
+	response, err := c.ClustersMgmt().V1().Get().SendContext(ctx)
+	if err != nil {
+		return fmt.Errorf("ocm gateway %s health check failed: %v", environment, err)
+	}
+
+	log.Printf("Connected to ocm gateway %s (clusters management version %q)", environment, response.Body().ServerVersion())
+
+	return nil
+}
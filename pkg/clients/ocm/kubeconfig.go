@@ -2,10 +2,20 @@ package ocm
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
 )
 
+// kubeConfigExpiryMargin is how long before its client certificate expires an existing kubeconfig
+// file is no longer reused
+const kubeConfigExpiryMargin = 10 * time.Minute
+
 // KubeConfig returns the clusters kubeconfig content
 func (c *Client) KubeConfig(ctx context.Context, clusterID string) (string, error) {
 	response, err := c.ClustersMgmt().V1().Clusters().Cluster(clusterID).Credentials().Get().SendContext(ctx)
@@ -15,15 +25,42 @@ func (c *Client) KubeConfig(ctx context.Context, clusterID string) (string, erro
 	return response.Body().Kubeconfig(), nil
 }
 
-// KubeConfigFile returns the clusters kubeconfig file
+// kubeConfigDir returns the directory kubeconfig files are written to: the kubeconfig directory,
+// the artifact directory or the temporary directory
+func (c *Client) kubeConfigDir() string {
+	switch {
+	case c.KubeConfigDir != "":
+		return c.KubeConfigDir
+	case c.ArtifactDir != "":
+		return c.ArtifactDir
+	default:
+		return filepath.Join(os.TempDir(), "osde2e-kubeconfigs")
+	}
+}
+
+// kubeConfigFilename returns the path of the clusters kubeconfig file
+func (c *Client) kubeConfigFilename(clusterID string) string {
+	return filepath.Join(c.kubeConfigDir(), fmt.Sprintf("%s-kubeconfig", clusterID))
+}
+
+// KubeConfigFile returns the clusters kubeconfig file written to the kubeconfig directory. An existing
+// file is reused while its current context is valid and its client certificate is not about to expire
 func (c *Client) KubeConfigFile(ctx context.Context, clusterID string) (string, error) {
-	filename := fmt.Sprintf("%s-kubeconfig", clusterID)
+	filename := c.kubeConfigFilename(clusterID)
+
+	if reusableKubeConfig(filename) {
+		return filename, nil
+	}
 
 	kubeConfig, err := c.KubeConfig(ctx, clusterID)
 	if err != nil {
 		return filename, err
 	}
 
+	if err = os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return filename, fmt.Errorf("failed to create kubeconfig directory: %v", err)
+	}
+
 	err = os.WriteFile(filename, []byte(kubeConfig), 0o600)
 	if err != nil {
 		return filename, fmt.Errorf("failed to write kubeconfig file: %v", err)
@@ -31,3 +68,33 @@ func (c *Client) KubeConfigFile(ctx context.Context, clusterID string) (string,
 
 	return filename, nil
 }
+
+// RemoveKubeConfigFile removes the clusters kubeconfig file, e.g. once the cluster is deleted
+func (c *Client) RemoveKubeConfigFile(clusterID string) error {
+	err := os.Remove(c.kubeConfigFilename(clusterID))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove cluster id %q kubeconfig file: %v", clusterID, err)
+	}
+	return nil
+}
+
+// reusableKubeConfig returns true when the kubeconfig file exists, its current context is valid and
+// its client certificate (when it has one) does not expire within the expiry margin
+func reusableKubeConfig(filename string) bool {
+	if _, err := os.Stat(filename); err != nil {
+		return false
+	}
+
+	expiration, err := openshift.KubeConfigCertificateExpiration(filename)
+	if err != nil {
+		log.Printf("Existing kubeconfig %q is invalid and will be replaced: %v", filename, err)
+		return false
+	}
+
+	if !expiration.IsZero() && time.Until(expiration) < kubeConfigExpiryMargin {
+		log.Printf("Existing kubeconfig %q client certificate expires at %s and will be replaced", filename, expiration.UTC().Format(time.RFC3339))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,47 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+)
+
+// limitedSupportReasonsPageSize is the number of limited support reasons requested per page
+const limitedSupportReasonsPageSize = 100
+
+// String returns the reason formatted as its summary and details
+func (r *LimitedSupportReason) String() string {
+	return fmt.Sprintf("%s (%s)", r.Summary, r.Details)
+}
+
+// GetLimitedSupportReasons returns the reasons the cluster is in limited support, empty when it is
+// fully supported
+//
+//	reasons, err := client.GetLimitedSupportReasons(ctx, clusterID)
+//	Expect(err).ShouldNot(HaveOccurred())
+//	Expect(reasons).ShouldNot(gomegamatchers.BeInLimitedSupport())
+func (c *Client) GetLimitedSupportReasons(ctx context.Context, clusterID string) ([]*LimitedSupportReason, error) {
+	client := c.ClustersMgmt().V1().Clusters().Cluster(clusterID).LimitedSupportReasons()
+
+	var reasons []*LimitedSupportReason
+
+	for page := 1; ; page++ {
+		response, err := client.List().Page(page).Size(limitedSupportReasonsPageSize).SendContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list limited support reasons for cluster id %q: %v", clusterID, err)
+		}
+
+		for _, reason := range response.Items().Slice() {
+			reasons = append(reasons, &LimitedSupportReason{
+				ID:            reason.ID(),
+				Summary:       reason.Summary(),
+				Details:       reason.Details(),
+				DetectionType: string(reason.DetectionType()),
+				CreatedAt:     reason.CreationTimestamp(),
+			})
+		}
+
+		if response.Size() < limitedSupportReasonsPageSize {
+			return reasons, nil
+		}
+	}
+}
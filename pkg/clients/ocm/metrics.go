@@ -0,0 +1,108 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	accountsmgmtv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+)
+
+// ResourceUsage represents the used and total capacity of a cluster resource
+type ResourceUsage struct {
+	Used  float64
+	Total float64
+	Unit  string
+}
+
+// Percent returns the used capacity as a percentage of the total, zero when the total is unknown
+func (r ResourceUsage) Percent() float64 {
+	if r.Total == 0 {
+		return 0
+	}
+	return r.Used / r.Total * 100
+}
+
+// String returns the resource usage formatted as used/total unit (percent)
+func (r ResourceUsage) String() string {
+	return fmt.Sprintf("%.2f/%.2f %s (%.0f%%)", r.Used, r.Total, r.Unit, r.Percent())
+}
+
+// NodeCounts represents the number of nodes in the cluster by role
+type NodeCounts struct {
+	Compute int
+	Infra   int
+	Master  int
+	Total   int
+}
+
+// ClusterMetrics represents the cluster metrics reported to ocm by telemetry
+type ClusterMetrics struct {
+	ClusterID string
+	// UpdatedAt is when ocm last received the metrics, zero when the cluster has not reported any
+	UpdatedAt                 time.Time
+	HealthState               string
+	CPU                       ResourceUsage
+	Memory                    ResourceUsage
+	Storage                   ResourceUsage
+	Nodes                     NodeCounts
+	CriticalAlertsFiring      int
+	OperatorsConditionFailing int
+}
+
+// String returns the metrics formatted for the run report
+func (m *ClusterMetrics) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "health: %s (updated %s)\n", m.HealthState, m.UpdatedAt.Format(time.RFC3339))
+	fmt.Fprintf(&b, "cpu: %s\n", m.CPU)
+	fmt.Fprintf(&b, "memory: %s\n", m.Memory)
+	fmt.Fprintf(&b, "storage: %s\n", m.Storage)
+	fmt.Fprintf(&b, "nodes: %d (master=%d, infra=%d, compute=%d)\n", m.Nodes.Total, m.Nodes.Master, m.Nodes.Infra, m.Nodes.Compute)
+	fmt.Fprintf(&b, "critical alerts firing: %d, operators failing: %d", m.CriticalAlertsFiring, m.OperatorsConditionFailing)
+
+	return b.String()
+}
+
+// ClusterMetrics returns the clusters cpu, memory, storage and node metrics from its ocm
+// subscription, making capacity visible without access to the clusters prometheus
+func (c *Client) ClusterMetrics(ctx context.Context, clusterID string) (*ClusterMetrics, error) {
+	subscription, err := c.ClusterSubscription(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := &ClusterMetrics{ClusterID: clusterID}
+
+	items := subscription.Metrics()
+	if len(items) == 0 {
+		return metrics, nil
+	}
+	item := items[0]
+
+	metrics.UpdatedAt = item.Cpu().UpdatedTimestamp()
+	metrics.HealthState = item.HealthState()
+	metrics.CPU = resourceUsage(item.Cpu())
+	metrics.Memory = resourceUsage(item.Memory())
+	metrics.Storage = resourceUsage(item.Storage())
+	metrics.Nodes = NodeCounts{
+		Compute: int(item.Nodes().Compute()),
+		Infra:   int(item.Nodes().Infra()),
+		Master:  int(item.Nodes().Master()),
+		Total:   int(item.Nodes().Total()),
+	}
+	metrics.CriticalAlertsFiring = int(item.CriticalAlertsFiring())
+	metrics.OperatorsConditionFailing = int(item.OperatorsConditionFailing())
+
+	return metrics, nil
+}
+
+// resourceUsage converts the ocm cluster resource into resource usage
+func resourceUsage(resource *accountsmgmtv1.ClusterResource) ResourceUsage {
+	return ResourceUsage{
+		Used:  resource.Used().Value(),
+		Total: resource.Total().Value(),
+		Unit:  resource.Total().Unit(),
+	}
+}
@@ -0,0 +1,82 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// NotificationContact represents an account notified about cluster events
+type NotificationContact struct {
+	ID       string `json:"id"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+}
+
+// notificationContactList represents the ocm notification contacts list response
+type notificationContactList struct {
+	Items []*NotificationContact `json:"items"`
+}
+
+// clusterSubscriptionID returns the subscription id for the cluster
+func (c *Client) clusterSubscriptionID(ctx context.Context, clusterID string) (string, error) {
+	response, err := c.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().SendContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get cluster id %q: %v", clusterID, err)
+	}
+	return response.Body().Subscription().ID(), nil
+}
+
+// notificationContactsPath returns the ocm api path for the subscriptions notification contacts
+func notificationContactsPath(subscriptionID string) string {
+	return fmt.Sprintf("/api/accounts_mgmt/v1/subscriptions/%s/notification_contacts", subscriptionID)
+}
+
+// NotificationContacts returns the clusters notification contacts
+func (c *Client) NotificationContacts(ctx context.Context, clusterID string) ([]*NotificationContact, error) {
+	subscriptionID, err := c.clusterSubscriptionID(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	var contacts notificationContactList
+	err = send(ctx, c.Get().Path(notificationContactsPath(subscriptionID)), nil, http.StatusOK, &contacts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification contacts for cluster id %q: %v", clusterID, err)
+	}
+
+	return contacts.Items, nil
+}
+
+// AddNotificationContact adds the account (username or email) as a notification contact for the cluster
+func (c *Client) AddNotificationContact(ctx context.Context, clusterID, accountIdentifier string) (*NotificationContact, error) {
+	subscriptionID, err := c.clusterSubscriptionID(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	var contact NotificationContact
+	body := map[string]string{"account_identifier": accountIdentifier}
+	err = send(ctx, c.Post().Path(notificationContactsPath(subscriptionID)), body, http.StatusCreated, &contact)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add notification contact %q to cluster id %q: %v", accountIdentifier, clusterID, err)
+	}
+
+	return &contact, nil
+}
+
+// RemoveNotificationContact removes the account id from the clusters notification contacts
+func (c *Client) RemoveNotificationContact(ctx context.Context, clusterID, accountID string) error {
+	subscriptionID, err := c.clusterSubscriptionID(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("%s/%s", notificationContactsPath(subscriptionID), accountID)
+	err = send(ctx, c.Delete().Path(path), nil, http.StatusNoContent, nil)
+	if err != nil {
+		return fmt.Errorf("failed to remove notification contact %q from cluster id %q: %v", accountID, clusterID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package ocm
+
+import (
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RequestMetric is an ocm api request attempt, retried requests are recorded once per attempt
+type RequestMetric struct {
+	Method string
+	Path   string
+	// Status is the response status code, zero when the request failed without a response
+	Status      int
+	Duration    time.Duration
+	OperationID string
+	Err         error
+}
+
+// requestObserver logs and reports the metrics of the ocm api requests to the installed logger and hook
+type requestObserver struct {
+	mu     sync.RWMutex
+	logger *log.Logger
+	hook   func(metric RequestMetric)
+}
+
+// observe logs and reports the request metric when a logger or hook is installed
+func (o *requestObserver) observe(metric RequestMetric) {
+	o.mu.RLock()
+	logger, hook := o.logger, o.hook
+	o.mu.RUnlock()
+
+	if logger != nil {
+		if metric.Err != nil {
+			logger.Printf("OCM %s %s failed after %s: %v", metric.Method, metric.Path, metric.Duration, metric.Err)
+		} else {
+			logger.Printf("OCM %s %s %d in %s (operation id %q)", metric.Method, metric.Path, metric.Status, metric.Duration, metric.OperationID)
+		}
+	}
+
+	if hook != nil {
+		hook(metric)
+	}
+}
+
+// transportWrapper wraps the http round tripper to observe every request sent
+func (o *requestObserver) transportWrapper(next http.RoundTripper) http.RoundTripper {
+	return &observingTransport{next: next, observer: o}
+}
+
+// observingTransport records the method, path, status and latency of the requests it sends
+type observingTransport struct {
+	next     http.RoundTripper
+	observer *requestObserver
+}
+
+// RoundTrip sends the http request and reports its metric to the observer
+func (t *observingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	start := time.Now()
+	response, err := t.next.RoundTrip(request)
+
+	metric := RequestMetric{
+		Method:   request.Method,
+		Path:     request.URL.Path,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	if response != nil {
+		metric.Status = response.StatusCode
+		metric.OperationID = response.Header.Get(operationIDHeader)
+	}
+
+	t.observer.observe(metric)
+
+	return response, err
+}
+
+// getObserver returns the clients request observer, creating it for clients not constructed
+// using New or NewWithCredentials (their requests are not observed)
+func (c *Client) getObserver() *requestObserver {
+	if c.observer == nil {
+		c.observer = &requestObserver{}
+	}
+	return c.observer
+}
+
+// SetRequestLogger logs every ocm api request (method, path, status, latency and operation id)
+// to the logger, nil stops logging
+func (c *Client) SetRequestLogger(logger *log.Logger) {
+	observer := c.getObserver()
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+	observer.logger = logger
+}
+
+// SetRequestMetricsHook invokes the hook with the metric of every ocm api request, e.g. to count
+// the ocm api calls made by a ci run, nil removes the hook. The hook must be safe to call concurrently
+func (c *Client) SetRequestMetricsHook(hook func(metric RequestMetric)) {
+	observer := c.getObserver()
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+	observer.hook = hook
+}
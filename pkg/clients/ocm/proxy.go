@@ -0,0 +1,29 @@
+package ocm
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// WithProxy sends the ocm and sso requests through the http or https proxy instead of the proxy from
+// the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *Client) {
+		c.proxyURL = proxyURL
+	}
+}
+
+// proxyTransportWrapper returns a wrapper setting the proxy of the ocm sdk http transport, it must be
+// the innermost wrapper to receive the transport
+func proxyTransportWrapper(proxyURL *url.URL) func(http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		transport, ok := next.(*http.Transport)
+		if !ok {
+			return next
+		}
+
+		transport = transport.Clone()
+		transport.Proxy = http.ProxyURL(proxyURL)
+		return transport
+	}
+}
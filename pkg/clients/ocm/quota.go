@@ -0,0 +1,154 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	accountsmgmtv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+)
+
+// QuotaResource identifies the resource a cluster consumes quota for (e.g. product OSD,
+// resource type cluster.aws, byoc rhinfra)
+type QuotaResource struct {
+	Product      string
+	ResourceType string
+	// BYOC is byoc for customer cloud subscription clusters and rhinfra otherwise, any matches both
+	BYOC string
+}
+
+// String returns the resource formatted as product/resource type/byoc
+func (q QuotaResource) String() string {
+	return fmt.Sprintf("%s/%s/%s", q.Product, q.ResourceType, q.BYOC)
+}
+
+// matches returns true when the related resource is the resource
+func (q QuotaResource) matches(related *accountsmgmtv1.RelatedResource) bool {
+	byoc := related.BYOC() == "any" || q.BYOC == "" || strings.EqualFold(related.BYOC(), q.BYOC)
+	return byoc && strings.EqualFold(related.Product(), q.Product) && strings.EqualFold(related.ResourceType(), q.ResourceType)
+}
+
+// QuotaUsage is the quota consumed and allowed by an organization for the resources costing it
+type QuotaUsage struct {
+	QuotaID   string
+	Allowed   int
+	Consumed  int
+	Resources []QuotaResourceCost
+}
+
+// QuotaResourceCost is the quota a resource costs
+type QuotaResourceCost struct {
+	QuotaResource
+	CloudProvider string
+	BillingModel  string
+	Cost          int
+}
+
+// Remaining returns the quota left, zero when it is exhausted
+func (q *QuotaUsage) Remaining() int {
+	if remaining := q.Allowed - q.Consumed; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// QuotaCost returns the organizations consumed and allowed quota per quota, the current accounts
+// organization when the organization id is empty
+func (c *Client) QuotaCost(ctx context.Context, organizationID string) ([]*QuotaUsage, error) {
+	quotaCosts, err := c.organizationQuotaCost(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make([]*QuotaUsage, 0, len(quotaCosts))
+	for _, quotaCost := range quotaCosts {
+		usage := &QuotaUsage{
+			QuotaID:  quotaCost.QuotaID(),
+			Allowed:  quotaCost.Allowed(),
+			Consumed: quotaCost.Consumed(),
+		}
+		for _, related := range quotaCost.RelatedResources() {
+			usage.Resources = append(usage.Resources, QuotaResourceCost{
+				QuotaResource: QuotaResource{
+					Product:      related.Product(),
+					ResourceType: related.ResourceType(),
+					BYOC:         related.BYOC(),
+				},
+				CloudProvider: related.CloudProvider(),
+				BillingModel:  related.BillingModel(),
+				Cost:          related.Cost(),
+			})
+		}
+		usages = append(usages, usage)
+	}
+
+	return usages, nil
+}
+
+// CheckQuota verifies the current accounts organization has the quota to consume count of the
+// resource, returning an error naming the quota when it is insufficient. Resources that cost
+// nothing or have no quota are not enforced
+func (c *Client) CheckQuota(ctx context.Context, resource QuotaResource, count int) error {
+	quotaCosts, err := c.organizationQuotaCost(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	return checkQuotaCost(quotaCosts, resource, count)
+}
+
+// organizationQuotaCost returns the organizations quota cost including the related resources, the
+// current accounts organization when the organization id is empty
+func (c *Client) organizationQuotaCost(ctx context.Context, organizationID string) ([]*accountsmgmtv1.QuotaCost, error) {
+	if organizationID == "" {
+		account, err := c.CurrentAccount(ctx)
+		if err != nil {
+			return nil, err
+		}
+		organizationID = account.Organization().ID()
+	}
+
+	quotaCost, err := c.AccountsMgmt().V1().Organizations().Organization(organizationID).QuotaCost().List().
+		Parameter("fetchRelatedResources", true).
+		Size(-1).
+		SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization %q quota cost: %v", organizationID, err)
+	}
+
+	return quotaCost.Items().Slice(), nil
+}
+
+// checkQuotaCost returns an error when none of the quotas for the resource have the quota remaining
+// for count of the resource
+func checkQuotaCost(quotaCosts []*accountsmgmtv1.QuotaCost, resource QuotaResource, count int) error {
+	var exhausted []string
+
+	for _, quotaCost := range quotaCosts {
+		for _, related := range quotaCost.RelatedResources() {
+			if !resource.matches(related) {
+				continue
+			}
+
+			if related.Cost() == 0 {
+				return nil
+			}
+
+			remaining := quotaCost.Allowed() - quotaCost.Consumed()
+			if remaining >= related.Cost()*count {
+				return nil
+			}
+
+			exhausted = append(exhausted, fmt.Sprintf("%s (allowed=%d, consumed=%d, cost=%d)",
+				quotaCost.QuotaID(), quotaCost.Allowed(), quotaCost.Consumed(), related.Cost()))
+		}
+	}
+
+	if len(exhausted) == 0 {
+		log.Printf("No quota found for %s, quota is not enforced", resource)
+		return nil
+	}
+
+	return fmt.Errorf("insufficient quota for %d %s: %s", count, resource, strings.Join(exhausted, ", "))
+}
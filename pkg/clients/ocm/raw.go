@@ -0,0 +1,39 @@
+package ocm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	ocmsdk "github.com/openshift-online/ocm-sdk-go"
+)
+
+// send sends the raw ocm api request for endpoints the ocm sdk does not provide
+// typed clients for. The body (when not nil) is encoded as json and the response
+// is decoded into result (when not nil) if the response status is expected
+func send(ctx context.Context, request *ocmsdk.Request, body any, expectedStatus int, result any) error {
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %v", err)
+		}
+		request = request.Bytes(data)
+	}
+
+	response, err := request.SendContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to send request to %s: %v", request.GetPath(), err)
+	}
+
+	if response.Status() != expectedStatus {
+		return fmt.Errorf("request to %s failed with status %d: %s", request.GetPath(), response.Status(), response.String())
+	}
+
+	if result != nil {
+		if err = json.Unmarshal(response.Bytes(), result); err != nil {
+			return fmt.Errorf("failed to decode response from %s: %v", request.GetPath(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package ocm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// retryLimit is how many times throttled and failed ocm requests are retried
+	retryLimit = 5
+	// retryInterval is the wait before the first retry, doubled for each retry
+	retryInterval = 2 * time.Second
+	// maxRetryInterval caps the wait between retries, including waits requested by Retry-After
+	maxRetryInterval = time.Minute
+	// operationIDHeader is the response header holding the ocm operation id of the request
+	operationIDHeader = "X-Operation-Id"
+	// maxErrorBodySize is how much of a non json error response is kept in the error reason
+	maxErrorBodySize = 512
+)
+
+// retryTransport retries throttled (429) and failed (5xx) ocm requests with exponential backoff
+// honoring Retry-After. Requests ocm may have processed (5xx other than 503) are only retried when
+// their method is idempotent. Non json error responses (e.g. from the gateway) are converted to ocm
+// errors so the ocm operation id is included in the returned error
+type retryTransport struct {
+	next     http.RoundTripper
+	limit    int
+	interval time.Duration
+}
+
+// retryTransportWrapper wraps the http round tripper to retry throttled and failed requests
+func retryTransportWrapper(next http.RoundTripper) http.RoundTripper {
+	return &retryTransport{next: next, limit: retryLimit, interval: retryInterval}
+}
+
+// RoundTrip executes the http request retrying it while it is throttled or failing
+func (t *retryTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind %s %s request body: %w", request.Method, request.URL.Path, err)
+			}
+			request = request.Clone(request.Context())
+			request.Body = body
+		}
+
+		response, err := t.next.RoundTrip(request)
+
+		if attempt >= t.limit || !retryable(request, response, err) {
+			if err != nil {
+				return nil, err
+			}
+			return withOperationID(response), nil
+		}
+
+		delay := t.backoff(attempt, response)
+
+		reason := fmt.Sprintf("%v", err)
+		if response != nil {
+			reason = fmt.Sprintf("status %d (operation id %q)", response.StatusCode, response.Header.Get(operationIDHeader))
+			_, _ = io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+
+		log.Printf("OCM request %s %s failed with %s, retrying in %s (%d/%d)", request.Method, request.URL.Path, reason, delay, attempt+1, t.limit)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, request.Context().Err()
+		}
+	}
+}
+
+// retryable returns true when the request can be sent again after the response or error
+func retryable(request *http.Request, response *http.Response, err error) bool {
+	if request.Context().Err() != nil {
+		return false
+	}
+
+	if request.Body != nil && request.Body != http.NoBody && request.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		return idempotent(request.Method)
+	}
+
+	switch {
+	case response.StatusCode == http.StatusTooManyRequests || response.StatusCode == http.StatusServiceUnavailable:
+		// the request was not processed, it can be retried regardless of the method
+		return true
+	case response.StatusCode >= http.StatusInternalServerError:
+		return idempotent(request.Method)
+	default:
+		return false
+	}
+}
+
+// idempotent returns true when sending a request with the method more than once has the same effect as sending it once
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns the wait before retrying, the interval doubled for each attempt or the
+// responses Retry-After when longer, capped at the max retry interval
+func (t *retryTransport) backoff(attempt int, response *http.Response) time.Duration {
+	delay := t.interval << attempt
+
+	if response != nil {
+		if retryAfter := retryAfter(response.Header.Get("Retry-After")); retryAfter > delay {
+			delay = retryAfter
+		}
+	}
+
+	if delay > maxRetryInterval || delay <= 0 {
+		delay = maxRetryInterval
+	}
+
+	return delay
+}
+
+// retryAfter parses the Retry-After header value (seconds or http date), zero when unset or invalid
+func retryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return time.Until(at)
+	}
+
+	return 0
+}
+
+// withOperationID converts non json error responses to ocm errors including the operation id, the
+// ocm sdk otherwise fails decoding them and returns an error without the status or operation id
+func withOperationID(response *http.Response) *http.Response {
+	if response.StatusCode < http.StatusBadRequest {
+		return response
+	}
+
+	if contentType, _, _ := mime.ParseMediaType(response.Header.Get("Content-Type")); contentType == "application/json" {
+		return response
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+	response.Body.Close()
+
+	reason := http.StatusText(response.StatusCode)
+	if len(bytes.TrimSpace(body)) > 0 {
+		reason = fmt.Sprintf("%s: %s", reason, bytes.TrimSpace(body))
+	}
+
+	var data bytes.Buffer
+	encoder := json.NewEncoder(&data)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(map[string]string{
+		"kind":         "Error",
+		"reason":       reason,
+		"operation_id": response.Header.Get(operationIDHeader),
+	})
+
+	response.Header.Set("Content-Type", "application/json")
+	response.Header.Del("Content-Length")
+	response.ContentLength = int64(data.Len())
+	response.Body = io.NopCloser(&data)
+
+	return response
+}
@@ -0,0 +1,152 @@
+package ocm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// roundTripperFunc is a function sending http requests
+type roundTripperFunc func(request *http.Request) (*http.Response, error)
+
+// RoundTrip sends the request using the function
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// response returns a response with the status, content type and body
+func response(status int, contentType, body string) *http.Response {
+	recorder := httptest.NewRecorder()
+	if contentType != "" {
+		recorder.Header().Set("Content-Type", contentType)
+	}
+	recorder.Header().Set(operationIDHeader, "op-123")
+	recorder.WriteHeader(status)
+	_, _ = recorder.WriteString(body)
+	return recorder.Result()
+}
+
+var _ = Describe("Retry Transport", func() {
+	newRequest := func(method, body string) *http.Request {
+		var reader io.Reader
+		if body != "" {
+			reader = strings.NewReader(body)
+		}
+		request, err := http.NewRequest(method, "https://api.openshift.com/api/clusters_mgmt/v1/clusters", reader)
+		Expect(err).ShouldNot(HaveOccurred())
+		return request
+	}
+
+	It("should retry throttled and unavailable requests regardless of the method", func() {
+		for _, status := range []int{http.StatusTooManyRequests, http.StatusServiceUnavailable} {
+			Expect(retryable(newRequest(http.MethodPost, "{}"), response(status, "", ""), nil)).To(BeTrue())
+		}
+	})
+
+	It("should only retry failed requests with idempotent methods", func() {
+		Expect(retryable(newRequest(http.MethodGet, ""), response(http.StatusInternalServerError, "", ""), nil)).To(BeTrue())
+		Expect(retryable(newRequest(http.MethodDelete, ""), response(http.StatusBadGateway, "", ""), nil)).To(BeTrue())
+		Expect(retryable(newRequest(http.MethodPost, "{}"), response(http.StatusInternalServerError, "", ""), nil)).To(BeFalse())
+		Expect(retryable(newRequest(http.MethodPatch, "{}"), response(http.StatusBadGateway, "", ""), nil)).To(BeFalse())
+		Expect(retryable(newRequest(http.MethodGet, ""), nil, errors.New("connection reset"))).To(BeTrue())
+		Expect(retryable(newRequest(http.MethodPost, "{}"), nil, errors.New("connection reset"))).To(BeFalse())
+		Expect(retryable(newRequest(http.MethodGet, ""), response(http.StatusNotFound, "", ""), nil)).To(BeFalse())
+	})
+
+	It("should not retry requests whose body cannot be rewound", func() {
+		request := newRequest(http.MethodPut, "{}")
+		request.GetBody = nil
+		Expect(retryable(request, response(http.StatusServiceUnavailable, "", ""), nil)).To(BeFalse())
+	})
+
+	It("should parse the retry after seconds and http dates", func() {
+		Expect(retryAfter("")).To(BeZero())
+		Expect(retryAfter("soon")).To(BeZero())
+		Expect(retryAfter("3")).To(Equal(3 * time.Second))
+		Expect(retryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))).To(BeNumerically("~", time.Minute, 2*time.Second))
+	})
+
+	It("should double the wait for each retry honoring a longer retry after up to the max interval", func() {
+		transport := &retryTransport{interval: 2 * time.Second}
+		Expect(transport.backoff(0, nil)).To(Equal(2 * time.Second))
+		Expect(transport.backoff(2, nil)).To(Equal(8 * time.Second))
+		Expect(transport.backoff(10, nil)).To(Equal(maxRetryInterval))
+
+		throttled := response(http.StatusTooManyRequests, "", "")
+		throttled.Header.Set("Retry-After", "30")
+		Expect(transport.backoff(0, throttled)).To(Equal(30 * time.Second))
+
+		throttled.Header.Set("Retry-After", "3600")
+		Expect(transport.backoff(0, throttled)).To(Equal(maxRetryInterval))
+	})
+
+	It("should resend the request body until the request succeeds", func() {
+		var bodies []string
+		transport := &retryTransport{limit: 5, interval: time.Millisecond, next: roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(request.Body)
+			Expect(err).ShouldNot(HaveOccurred())
+			bodies = append(bodies, string(body))
+			if len(bodies) < 3 {
+				return response(http.StatusServiceUnavailable, "text/html", "unavailable"), nil
+			}
+			return response(http.StatusCreated, "application/json", "{}"), nil
+		})}
+
+		result, err := transport.RoundTrip(newRequest(http.MethodPost, `{"name":"my-cluster"}`))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.StatusCode).To(Equal(http.StatusCreated))
+		Expect(bodies).To(Equal([]string{`{"name":"my-cluster"}`, `{"name":"my-cluster"}`, `{"name":"my-cluster"}`}))
+	})
+
+	It("should return the last response once the retry limit is reached", func() {
+		attempts := 0
+		transport := &retryTransport{limit: 2, interval: time.Millisecond, next: roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			attempts++
+			return response(http.StatusTooManyRequests, "application/json", `{"kind":"Error","reason":"throttled"}`), nil
+		})}
+
+		result, err := transport.RoundTrip(newRequest(http.MethodGet, ""))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.StatusCode).To(Equal(http.StatusTooManyRequests))
+		Expect(attempts).To(Equal(3))
+	})
+
+	It("should stop waiting to retry once the context is done", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		transport := &retryTransport{limit: 5, interval: time.Hour, next: roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			return response(http.StatusServiceUnavailable, "", ""), nil
+		})}
+
+		_, err := transport.RoundTrip(newRequest(http.MethodGet, "").WithContext(ctx))
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+	})
+
+	It("should convert non json error responses to ocm errors with the operation id", func() {
+		converted := withOperationID(response(http.StatusBadGateway, "text/html", "<html>bad gateway</html>"))
+		Expect(converted.Header.Get("Content-Type")).To(Equal("application/json"))
+
+		body, err := io.ReadAll(converted.Body)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(body)).To(MatchJSON(`{"kind":"Error","reason":"Bad Gateway: <html>bad gateway</html>","operation_id":"op-123"}`))
+		Expect(converted.ContentLength).To(Equal(int64(len(body))))
+	})
+
+	It("should leave json error and successful responses unchanged", func() {
+		body, err := io.ReadAll(withOperationID(response(http.StatusNotFound, "application/json", `{"kind":"Error","reason":"not found"}`)).Body)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(body)).To(Equal(`{"kind":"Error","reason":"not found"}`))
+
+		body, err = io.ReadAll(withOperationID(response(http.StatusOK, "text/plain", "ok")).Body)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(body)).To(Equal("ok"))
+	})
+})
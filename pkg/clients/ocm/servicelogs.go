@@ -0,0 +1,121 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	servicelogsv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
+)
+
+// defaultServiceLogServiceName is the service name of the service logs posted when undefined
+const defaultServiceLogServiceName = "osde2e"
+
+// serviceLogSeverities are the severities service logs can be posted with
+var serviceLogSeverities = map[string]bool{
+	string(servicelogsv1.SeverityDebug):   true,
+	string(servicelogsv1.SeverityInfo):    true,
+	string(servicelogsv1.SeverityWarning): true,
+	string(servicelogsv1.SeverityError):   true,
+	string(servicelogsv1.SeverityFatal):   true,
+}
+
+// ClusterServiceLogs returns the clusters service logs, oldest first
+func (c *Client) ClusterServiceLogs(ctx context.Context, clusterID string) ([]*ServiceLog, error) {
+	response, err := c.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster id %q: %v", clusterID, err)
+	}
+
+	return c.listServiceLogs(ctx, clusterID, fmt.Sprintf("cluster_uuid = '%s'", response.Body().ExternalID()))
+}
+
+// PostServiceLog posts the service log (summary required, severity defaults to Info and service
+// name to osde2e) to the cluster returning the posted service log
+//
+//	_, err := client.PostServiceLog(ctx, clusterID, &ocm.ServiceLog{Summary: "osde2e test run", Description: "..."})
+func (c *Client) PostServiceLog(ctx context.Context, clusterID string, serviceLog *ServiceLog) (*ServiceLog, error) {
+	if serviceLog.Summary == "" {
+		return nil, fmt.Errorf("service log summary is required")
+	}
+
+	severity := serviceLog.Severity
+	if severity == "" {
+		severity = string(servicelogsv1.SeverityInfo)
+	}
+	if !serviceLogSeverities[severity] {
+		return nil, fmt.Errorf("service log severity %q is invalid (Debug, Info, Warning, Error or Fatal)", severity)
+	}
+
+	serviceName := serviceLog.ServiceName
+	if serviceName == "" {
+		serviceName = defaultServiceLogServiceName
+	}
+
+	response, err := c.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster id %q: %v", clusterID, err)
+	}
+	cluster := response.Body()
+
+	entry, err := servicelogsv1.NewLogEntry().
+		ClusterID(clusterID).
+		ClusterUUID(cluster.ExternalID()).
+		SubscriptionID(cluster.Subscription().ID()).
+		Severity(servicelogsv1.Severity(severity)).
+		ServiceName(serviceName).
+		Summary(serviceLog.Summary).
+		Description(serviceLog.Description).
+		InternalOnly(serviceLog.InternalOnly).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build service log: %v", err)
+	}
+
+	added, err := c.ServiceLogs().V1().ClusterLogs().Add().Body(entry).SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to post service log to cluster id %q: %v", clusterID, err)
+	}
+
+	log.Printf("Posted service log %q to cluster %q", serviceLog.Summary, clusterID)
+
+	return newServiceLog(added.Body()), nil
+}
+
+// listServiceLogs returns the service logs matching the search, oldest first
+func (c *Client) listServiceLogs(ctx context.Context, clusterID, search string) ([]*ServiceLog, error) {
+	var serviceLogs []*ServiceLog
+
+	for page := 1; ; page++ {
+		response, err := c.ServiceLogs().V1().ClusterLogs().List().
+			Search(search).
+			Order("timestamp asc").
+			Page(page).
+			Size(100).
+			SendContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list service logs for cluster id %q: %v", clusterID, err)
+		}
+
+		for _, entry := range response.Items().Slice() {
+			serviceLogs = append(serviceLogs, newServiceLog(entry))
+		}
+
+		if response.Size() < 100 {
+			return serviceLogs, nil
+		}
+	}
+}
+
+// newServiceLog converts the ocm log entry to a service log
+func newServiceLog(entry *servicelogsv1.LogEntry) *ServiceLog {
+	return &ServiceLog{
+		ID:           entry.ID(),
+		Severity:     string(entry.Severity()),
+		ServiceName:  entry.ServiceName(),
+		Summary:      entry.Summary(),
+		Description:  entry.Description(),
+		InternalOnly: entry.InternalOnly(),
+		Timestamp:    entry.Timestamp(),
+	}
+}
@@ -0,0 +1,148 @@
+package ocm
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TokenExpiration returns when the connections tokens expire and can no longer be refreshed:
+// the refresh tokens expiry when one is used, otherwise the access tokens expiry. Zero is
+// returned when the tokens do not expire (e.g. offline refresh tokens) or are requested again
+// using client credentials
+func (c *Client) TokenExpiration(ctx context.Context) (time.Time, error) {
+	if c.clientCredentials {
+		return time.Time{}, nil
+	}
+
+	accessToken, refreshToken, err := c.Connection.TokensContext(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get ocm tokens: %v", err)
+	}
+
+	token := accessToken
+	if refreshToken != "" {
+		token = refreshToken
+	}
+
+	return ParseTokenExpiration(token)
+}
+
+// TokenType is the kind of sso token passed to the ocm client, from the jwt typ claim
+type TokenType string
+
+const (
+	// OfflineToken is the long lived refresh token from https://console.redhat.com/openshift/token
+	OfflineToken TokenType = "Offline"
+	// RefreshToken is a refresh token of an sso session, it expires with the session
+	RefreshToken TokenType = "Refresh"
+	// AccessToken is a short lived access token, it cannot be refreshed
+	AccessToken TokenType = "Bearer"
+)
+
+// TokenInfo represents the claims of an sso token
+type TokenInfo struct {
+	Type TokenType
+	// Issuer is the sso realm the token was issued by (e.g. https://sso.redhat.com/auth/realms/redhat-external)
+	Issuer string
+	// ExpiresAt is when the token expires, zero when it does not expire
+	ExpiresAt time.Time
+}
+
+// Expired returns true when the token has expired
+func (t *TokenInfo) Expired() bool {
+	return !t.ExpiresAt.IsZero() && time.Now().After(t.ExpiresAt)
+}
+
+// ParseToken returns the type, issuer and expiry of the jwt token
+func ParseToken(token string) (*TokenInfo, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("failed to parse ocm token: not a jwt")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode ocm token claims: %v", err)
+	}
+
+	var claims struct {
+		Typ string `json:"typ"`
+		Iss string `json:"iss"`
+		Exp int64  `json:"exp"`
+	}
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("failed to decode ocm token claims: %v", err)
+	}
+
+	info := &TokenInfo{Type: TokenType(claims.Typ), Issuer: claims.Iss}
+	if claims.Exp != 0 {
+		info.ExpiresAt = time.Unix(claims.Exp, 0)
+	}
+
+	return info, nil
+}
+
+// ParseTokenExpiration returns the jwt tokens exp claim, zero when the claim is unset or zero
+func ParseTokenExpiration(token string) (time.Time, error) {
+	info, err := ParseToken(token)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ExpiresAt, nil
+}
+
+// verifyToken returns an error explaining why the token cannot be used with the environment: it is
+// not a jwt, has expired or was issued by an sso the environment does not accept
+func verifyToken(token string, environment Environment) (*TokenInfo, error) {
+	info, err := ParseToken(token)
+	if err != nil {
+		return nil, fmt.Errorf("%v, copy the offline token from https://console.redhat.com/openshift/token", err)
+	}
+
+	switch info.Type {
+	case OfflineToken, RefreshToken, AccessToken:
+	default:
+		return nil, fmt.Errorf("ocm token type %q is not supported, expected an offline, refresh or access token", info.Type)
+	}
+
+	if info.Expired() {
+		return nil, fmt.Errorf("ocm %s token expired at %s, request a new token", strings.ToLower(string(info.Type)), info.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+
+	if issuers := environment.tokenIssuers(); info.Issuer != "" && len(issuers) > 0 {
+		issuer, err := url.Parse(info.Issuer)
+		if err != nil {
+			return nil, fmt.Errorf("ocm token issuer %q is invalid: %v", info.Issuer, err)
+		}
+
+		accepted := false
+		for _, host := range issuers {
+			accepted = accepted || issuer.Host == host
+		}
+		if !accepted {
+			return nil, fmt.Errorf("ocm token was issued by %s which is not accepted by %s (expected %s), request a token for the environment",
+				issuer.Host, environment, strings.Join(issuers, " or "))
+		}
+	}
+
+	if info.Type == AccessToken {
+		log.Printf("OCM access token cannot be refreshed, requests fail once it expires at %s", info.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+
+	return info, nil
+}
+
+// tokenURL returns the token url of the realm that issued the token, used to refresh tokens
+// issued by an sso other than the default red hat sso (e.g. the stage sso)
+func (t *TokenInfo) tokenURL() string {
+	if t.Issuer == "" || t.Type == AccessToken {
+		return ""
+	}
+	return strings.TrimSuffix(t.Issuer, "/") + "/protocol/openid-connect/token"
+}
@@ -0,0 +1,101 @@
+package ocm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// jwt returns an unsigned jwt with the claims
+func jwt(claims map[string]any) string {
+	payload, err := json.Marshal(claims)
+	Expect(err).ShouldNot(HaveOccurred())
+	return "eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+}
+
+var _ = Describe("Tokens", func() {
+	const (
+		redHatSSO = "https://sso.redhat.com/auth/realms/redhat-external"
+		stageSSO  = "https://sso.stage.redhat.com/auth/realms/redhat-external"
+	)
+
+	var (
+		future = time.Now().Add(time.Hour).Unix()
+		past   = time.Now().Add(-time.Hour).Unix()
+	)
+
+	DescribeTable("should parse the token type, issuer and expiry",
+		func(typ TokenType, exp int64) {
+			info, err := ParseToken(jwt(map[string]any{"typ": typ, "iss": redHatSSO, "exp": exp}))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(info.Type).To(Equal(typ))
+			Expect(info.Issuer).To(Equal(redHatSSO))
+			if exp == 0 {
+				Expect(info.ExpiresAt).To(BeZero())
+			} else {
+				Expect(info.ExpiresAt).To(Equal(time.Unix(exp, 0)))
+			}
+		},
+		Entry("offline token without expiry", OfflineToken, int64(0)),
+		Entry("refresh token", RefreshToken, future),
+		Entry("access token", AccessToken, future),
+	)
+
+	It("should fail parsing tokens that are not jwts", func() {
+		_, err := ParseToken("not-a-jwt")
+		Expect(err).To(MatchError(ContainSubstring("not a jwt")))
+
+		_, err = ParseToken("header.!!!.signature")
+		Expect(err).To(MatchError(ContainSubstring("failed to decode")))
+	})
+
+	DescribeTable("should verify the token can be used with the environment",
+		func(claims map[string]any, environment Environment, expected string) {
+			_, err := verifyToken(jwt(claims), environment)
+			if expected == "" {
+				Expect(err).ShouldNot(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expected)))
+			}
+		},
+		Entry("offline token", map[string]any{"typ": "Offline", "iss": redHatSSO}, Production, ""),
+		Entry("refresh token", map[string]any{"typ": "Refresh", "iss": redHatSSO, "exp": future}, Production, ""),
+		Entry("access token", map[string]any{"typ": "Bearer", "iss": redHatSSO, "exp": future}, Production, ""),
+		Entry("unsupported token type", map[string]any{"typ": "ID", "iss": redHatSSO}, Production, `type "ID" is not supported`),
+		Entry("expired refresh token", map[string]any{"typ": "Refresh", "iss": redHatSSO, "exp": past}, Production, "ocm refresh token expired"),
+		Entry("expired access token", map[string]any{"typ": "Bearer", "iss": redHatSSO, "exp": past}, Production, "ocm bearer token expired"),
+		Entry("stage token with production", map[string]any{"typ": "Offline", "iss": stageSSO}, Production, "sso.stage.redhat.com which is not accepted"),
+		Entry("production token with stage", map[string]any{"typ": "Offline", "iss": redHatSSO}, Stage, ""),
+		Entry("stage token with stage", map[string]any{"typ": "Offline", "iss": stageSSO}, Stage, ""),
+		Entry("red hat token with fedramp", map[string]any{"typ": "Offline", "iss": redHatSSO}, FedRAMPProduction, "not accepted"),
+		Entry("any token with a custom environment", map[string]any{"typ": "Offline", "iss": stageSSO}, Environment("http://127.0.0.1:8000"), ""),
+	)
+
+	It("should ask for the offline token when the token is not a jwt", func() {
+		_, err := verifyToken("not-a-jwt", Production)
+		Expect(err).To(MatchError(ContainSubstring("https://console.redhat.com/openshift/token")))
+	})
+
+	DescribeTable("should return the token url of the issuing realm",
+		func(info *TokenInfo, expected string) {
+			Expect(info.tokenURL()).To(Equal(expected))
+		},
+		Entry("offline token", &TokenInfo{Type: OfflineToken, Issuer: stageSSO}, stageSSO+"/protocol/openid-connect/token"),
+		Entry("issuer with a trailing slash", &TokenInfo{Type: RefreshToken, Issuer: redHatSSO + "/"}, redHatSSO+"/protocol/openid-connect/token"),
+		Entry("access token", &TokenInfo{Type: AccessToken, Issuer: redHatSSO}, ""),
+		Entry("no issuer", &TokenInfo{Type: OfflineToken}, ""),
+	)
+
+	DescribeTable("should return the sso hosts issuing the tokens the environment accepts",
+		func(environment Environment, expected []string) {
+			Expect(environment.tokenIssuers()).To(Equal(expected))
+		},
+		Entry("production", Production, []string{"sso.redhat.com"}),
+		Entry("stage", Stage, []string{"sso.redhat.com", "sso.stage.redhat.com"}),
+		Entry("fedramp stage", FedRAMPStage, []string{"sso.stage.openshiftusgov.com"}),
+		Entry("custom", Environment("http://127.0.0.1:8000"), nil),
+	)
+})
@@ -0,0 +1,122 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver"
+)
+
+const listVersionsPageSize = 100
+
+// Version represents an enabled openshift version clusters can be created with
+type Version struct {
+	ID           string
+	Version      *semver.Version
+	ChannelGroup string
+	Default      bool
+	// ROSA is true when rosa clusters can be created with the version
+	ROSA bool
+	// HostedCP is true when hosted control plane clusters can be created with the version
+	HostedCP          bool
+	AvailableUpgrades []string
+	EndOfLife         time.Time
+}
+
+// Versions represents the enabled openshift versions in a channel group
+type Versions struct {
+	ChannelGroup string
+	// Available are the versions sorted from oldest to newest
+	Available []*Version
+}
+
+// Default returns the channel groups default version, nil when the channel group has no default
+func (v *Versions) Default() *Version {
+	for _, version := range v.Available {
+		if version.Default {
+			return version
+		}
+	}
+	return nil
+}
+
+// Latest returns the newest version of the minor release minorsBehind the newest minor
+// release (0 is the newest, 1 is y-1)
+//
+//	versions, err := client.Versions(ctx, "stable")
+//	Expect(err).ShouldNot(HaveOccurred())
+//	previous, err := versions.Latest(1)
+func (v *Versions) Latest(minorsBehind int) (*Version, error) {
+	var (
+		minors  []string
+		latests = map[string]*Version{}
+	)
+
+	for _, version := range v.Available {
+		minor := fmt.Sprintf("%d.%d", version.Version.Major(), version.Version.Minor())
+		if _, ok := latests[minor]; !ok {
+			minors = append(minors, minor)
+		}
+		latests[minor] = version
+	}
+
+	if minorsBehind < 0 || minorsBehind >= len(minors) {
+		return nil, fmt.Errorf("no version %d minor releases behind the newest in channel group %q (%d minor releases available)",
+			minorsBehind, v.ChannelGroup, len(minors))
+	}
+
+	return latests[minors[len(minors)-1-minorsBehind]], nil
+}
+
+// Versions returns the enabled openshift versions for the channel group (e.g. stable, candidate),
+// the channel groups default version and whether rosa and hosted control plane clusters can use them
+func (c *Client) Versions(ctx context.Context, channelGroup string) (*Versions, error) {
+	if channelGroup == "" {
+		channelGroup = "stable"
+	}
+
+	versions := &Versions{ChannelGroup: channelGroup}
+	search := fmt.Sprintf("enabled = 't' and channel_group = '%s'", strings.ReplaceAll(channelGroup, "'", "''"))
+
+	for page := 1; ; page++ {
+		response, err := c.ClustersMgmt().V1().Versions().List().
+			Search(search).
+			Page(page).
+			Size(listVersionsPageSize).
+			SendContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list versions: %v", err)
+		}
+
+		for _, item := range response.Items().Slice() {
+			version, err := semver.NewVersion(item.RawID())
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse version %q: %v", item.RawID(), err)
+			}
+
+			versions.Available = append(versions.Available, &Version{
+				ID:                item.RawID(),
+				Version:           version,
+				ChannelGroup:      item.ChannelGroup(),
+				Default:           item.Default(),
+				ROSA:              item.ROSAEnabled(),
+				HostedCP:          item.HostedControlPlaneEnabled(),
+				AvailableUpgrades: item.AvailableUpgrades(),
+				EndOfLife:         item.EndOfLifeTimestamp(),
+			})
+		}
+
+		if response.Size() < listVersionsPageSize {
+			break
+		}
+	}
+
+	sort.Slice(versions.Available, func(i, j int) bool {
+		return versions.Available[i].Version.LessThan(versions.Available[j].Version)
+	})
+
+	return versions, nil
+}
@@ -0,0 +1,434 @@
+// Package ocmfake provides an in-memory openshift cluster manager "ocm" api and an ocm client
+// connected to it, so test suite authors can unit test their harness code without ocm. It
+// serves the clusters_mgmt metadata and clusters collection (list, get, create, patch, delete,
+// status and credentials), other endpoints are registered with Handle. Cluster list searches only support
+// equality terms joined with AND and one parenthesized group of terms joined with OR. Clients whose
+// requests are answered by a round tripper instead of the api are constructed using NewClient
+//
+//	server := ocmfake.NewServer()
+//	defer server.Close()
+//
+//	cluster, _ := clustersmgmtv1.NewCluster().ID("abc").Name("my-cluster").Build()
+//	Expect(server.AddCluster(cluster)).To(Succeed())
+//
+//	client, err := server.Client(ctx)
+package ocmfake
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmclient "github.com/openshift/osde2e-framework/pkg/clients/ocm"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+const (
+	// clustersPath is the path of the clusters collection
+	clustersPath = "/api/clusters_mgmt/v1/clusters"
+	// metadataPath is the path of the clusters management metadata, used to health check the gateway
+	metadataPath = "/api/clusters_mgmt/v1"
+)
+
+// searchTerm matches the equality terms of an ocm search query (e.g. name = 'my-cluster')
+var searchTerm = regexp.MustCompile(`([a-z_.]+)\s*=\s*'([^']*)'`)
+
+// Server is an in-memory ocm api
+type Server struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	clusters map[string]map[string]interface{}
+	// kubeConfigs are the kubeconfigs returned by the clusters credentials endpoint
+	kubeConfigs map[string]string
+	// adminCredentials are the admin user and password returned by the clusters credentials endpoint
+	adminCredentials map[string]map[string]string
+	handlers         map[string]http.HandlerFunc
+}
+
+// NewServer starts the ocm api, it is the callers responsibility to close
+// it when they are finished (defer server.Close())
+func NewServer() *Server {
+	s := &Server{
+		clusters:         map[string]map[string]interface{}{},
+		kubeConfigs:      map[string]string{},
+		adminCredentials: map[string]map[string]string{},
+		handlers:         map[string]http.HandlerFunc{},
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Client returns an ocm client connected to the api
+func (s *Server) Client(ctx context.Context) (*ocmclient.Client, error) {
+	return ocmclient.New(ctx, token(), ocmclient.Environment(s.server.URL))
+}
+
+// RoundTripperFunc is a function sending http requests, e.g. to respond to the requests of NewClient
+type RoundTripperFunc func(request *http.Request) (*http.Response, error)
+
+// RoundTrip sends the request using the function
+func (f RoundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// NewClient returns an ocm client, authenticated with a fake token, whose requests are sent using
+// the transport instead of a server, so code using the client can be unit tested without ocm
+//
+//	client, err := ocmfake.NewClient(ctx, ocmfake.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+//		recorder := httptest.NewRecorder()
+//		recorder.Header().Set("Content-Type", "application/json")
+//		_, _ = recorder.WriteString(`{"kind":"Account","username":"tester"}`)
+//		return recorder.Result(), nil
+//	}))
+func NewClient(ctx context.Context, transport http.RoundTripper) (*ocmclient.Client, error) {
+	if transport == nil {
+		return nil, fmt.Errorf("transport is required")
+	}
+	return ocmclient.NewWithCredentials(ctx, ocmclient.Credentials{Token: token()}, ocmclient.Production, ocmclient.WithTransport(transport))
+}
+
+// URL returns the url of the api
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close shuts down the api
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// AddCluster stores the cluster, replacing an existing cluster with the same id
+func (s *Server) AddCluster(cluster *clustersmgmtv1.Cluster) error {
+	var buffer bytes.Buffer
+	if err := clustersmgmtv1.MarshalCluster(cluster, &buffer); err != nil {
+		return fmt.Errorf("failed to marshal cluster %q: %w", cluster.Name(), err)
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal(buffer.Bytes(), &object); err != nil {
+		return fmt.Errorf("failed to decode cluster %q: %w", cluster.Name(), err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.store(object)
+
+	return nil
+}
+
+// SetKubeConfig sets the kubeconfig the clusters credentials endpoint returns
+func (s *Server) SetKubeConfig(clusterID, kubeConfig string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.kubeConfigs[clusterID] = kubeConfig
+}
+
+// SetAdminCredentials sets the admin user and password the clusters credentials endpoint returns
+func (s *Server) SetAdminCredentials(clusterID, user, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.adminCredentials[clusterID] = map[string]string{"user": user, "password": password}
+}
+
+// Handle registers the handler for the method and path (e.g. GET /api/accounts_mgmt/v1/current_account),
+// registered handlers take precedence over the clusters collection
+func (s *Server) Handle(method, path string, handler http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[method+" "+path] = handler
+}
+
+// serveHTTP routes the requests to the registered handlers and clusters collection
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	handler, ok := s.handlers[r.Method+" "+r.URL.Path]
+	s.mu.Unlock()
+
+	if ok {
+		handler(w, r)
+		return
+	}
+
+	if r.URL.Path == metadataPath && r.Method == http.MethodGet {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"server_version": "ocmfake"})
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, clustersPath) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s is not served by the fake", r.Method, r.URL.Path))
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, clustersPath), "/"), "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case parts[0] == "" && r.Method == http.MethodGet:
+		s.list(w, r)
+	case parts[0] == "" && r.Method == http.MethodPost:
+		s.create(w, r)
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		s.get(w, parts[0])
+	case len(parts) == 1 && r.Method == http.MethodPatch:
+		s.patch(w, r, parts[0])
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		s.delete(w, parts[0])
+	case len(parts) == 2 && parts[1] == "status" && r.Method == http.MethodGet:
+		s.status(w, parts[0])
+	case len(parts) == 2 && parts[1] == "credentials" && r.Method == http.MethodGet:
+		s.credentials(w, parts[0])
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s is not served by the fake", r.Method, r.URL.Path))
+	}
+}
+
+// store stores the cluster, setting the fields ocm manages
+func (s *Server) store(cluster map[string]interface{}) {
+	id, _ := cluster["id"].(string)
+	if id == "" {
+		id = strings.ReplaceAll(string(uuid.NewUUID()), "-", "")[:32]
+		cluster["id"] = id
+	}
+
+	cluster["kind"] = "Cluster"
+	cluster["href"] = clustersPath + "/" + id
+	if _, ok := cluster["state"]; !ok {
+		cluster["state"] = string(clustersmgmtv1.ClusterStatePending)
+	}
+	if _, ok := cluster["creation_timestamp"]; !ok {
+		cluster["creation_timestamp"] = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	s.clusters[id] = cluster
+}
+
+// list writes the clusters matching the search query
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	size, err := strconv.Atoi(query.Get("size"))
+	if err != nil || size < 1 {
+		size = 100
+	}
+
+	ids := make([]string, 0, len(s.clusters))
+	for id, cluster := range s.clusters {
+		if matches(cluster, query.Get("search")) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	items := []interface{}{}
+	for i := (page - 1) * size; i < len(ids) && i < page*size; i++ {
+		items = append(items, s.clusters[ids[i]])
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"kind":  "ClusterList",
+		"page":  page,
+		"size":  len(items),
+		"total": len(ids),
+		"items": items,
+	})
+}
+
+// get writes the cluster
+func (s *Server) get(w http.ResponseWriter, id string) {
+	cluster, ok := s.clusters[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Cluster '%s' not found", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, cluster)
+}
+
+// create stores the cluster in the request body
+func (s *Server) create(w http.ResponseWriter, r *http.Request) {
+	var cluster map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&cluster); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.store(cluster)
+
+	writeJSON(w, http.StatusCreated, cluster)
+}
+
+// patch merges the request body into the cluster
+func (s *Server) patch(w http.ResponseWriter, r *http.Request, id string) {
+	cluster, ok := s.clusters[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Cluster '%s' not found", id))
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	current, err := json.Marshal(cluster)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	patched, err := jsonpatch.MergePatch(current, body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var updated map[string]interface{}
+	if err = json.Unmarshal(patched, &updated); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	updated["id"] = id
+	s.store(updated)
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// delete removes the cluster
+func (s *Server) delete(w http.ResponseWriter, id string) {
+	if _, ok := s.clusters[id]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Cluster '%s' not found", id))
+		return
+	}
+
+	delete(s.clusters, id)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// status writes the clusters state
+func (s *Server) status(w http.ResponseWriter, id string) {
+	cluster, ok := s.clusters[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Cluster '%s' not found", id))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"kind":  "ClusterStatus",
+		"id":    id,
+		"state": cluster["state"],
+	})
+}
+
+// credentials writes the clusters kubeconfig and admin credentials
+func (s *Server) credentials(w http.ResponseWriter, id string) {
+	if _, ok := s.clusters[id]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Cluster '%s' not found", id))
+		return
+	}
+
+	credentials := map[string]interface{}{
+		"kind":       "ClusterCredentials",
+		"id":         id,
+		"kubeconfig": s.kubeConfigs[id],
+	}
+	if admin, ok := s.adminCredentials[id]; ok {
+		credentials["admin"] = admin
+	}
+
+	writeJSON(w, http.StatusOK, credentials)
+}
+
+// matches returns true when the cluster matches the search query equality terms joined with
+// AND, where one of the terms of a parenthesized group joined with OR must match
+func matches(cluster map[string]interface{}, search string) bool {
+	required, oneOf := search, ""
+	if start, end := strings.Index(search, "("), strings.LastIndex(search, ")"); start >= 0 && end > start {
+		required = search[:start] + search[end+1:]
+		oneOf = search[start+1 : end]
+	}
+
+	for _, term := range searchTerm.FindAllStringSubmatch(required, -1) {
+		if field(cluster, term[1]) != term[2] {
+			return false
+		}
+	}
+
+	terms := searchTerm.FindAllStringSubmatch(oneOf, -1)
+	for _, term := range terms {
+		if field(cluster, term[1]) == term[2] {
+			return true
+		}
+	}
+
+	return len(terms) == 0
+}
+
+// field returns the string value of the clusters dotted field path (e.g. product.id)
+func field(cluster map[string]interface{}, path string) string {
+	var value interface{} = cluster
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = object[key]
+	}
+
+	result, _ := value.(string)
+	return result
+}
+
+// token returns an unsigned access token the ocm sdk accepts, the fake does not authenticate requests
+func token() string {
+	encode := func(value string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(value))
+	}
+
+	claims := fmt.Sprintf(`{"typ":"Bearer","exp":%d}`, time.Now().Add(24*time.Hour).Unix())
+
+	return fmt.Sprintf("%s.%s.", encode(`{"alg":"none","typ":"JWT"}`), encode(claims))
+}
+
+// writeJSON writes the value as the json response
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// writeError writes the ocm error response
+func writeError(w http.ResponseWriter, status int, reason string) {
+	writeJSON(w, status, map[string]interface{}{
+		"kind":   "Error",
+		"id":     strconv.Itoa(status),
+		"code":   fmt.Sprintf("CLUSTERS-MGMT-%d", status),
+		"reason": reason,
+	})
+}
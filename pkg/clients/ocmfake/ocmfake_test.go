@@ -0,0 +1,182 @@
+package ocmfake
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmclient "github.com/openshift/osde2e-framework/pkg/clients/ocm"
+)
+
+var _ = Describe("OCM Fake", func() {
+	var (
+		ctx    = context.Background()
+		server *Server
+	)
+
+	BeforeEach(func() {
+		server = NewServer()
+		DeferCleanup(server.Close)
+
+		for _, name := range []string{"first", "second"} {
+			cluster, err := clustersmgmtv1.NewCluster().
+				ID(name + "-id").
+				Name(name).
+				Product(clustersmgmtv1.NewProduct().ID("rosa")).
+				Properties(map[string]string{"owner": name}).
+				Build()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(server.AddCluster(cluster)).To(Succeed())
+		}
+	})
+
+	It("should fail constructing clients when the gateway is unhealthy", func() {
+		server.Handle(http.MethodGet, "/api/clusters_mgmt/v1", func(w http.ResponseWriter, r *http.Request) {
+			writeError(w, http.StatusNotFound, "no route")
+		})
+
+		_, err := server.Client(ctx)
+		Expect(err).To(MatchError(ContainSubstring("health check failed")))
+	})
+
+	It("should serve the clusters", func() {
+		client, err := server.Client(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer client.Close()
+
+		properties, err := client.ClusterProperties(ctx, "first-id")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(properties).To(HaveKeyWithValue("owner", "first"))
+
+		response, err := client.ClustersMgmt().V1().Clusters().List().
+			Search("product.id = 'rosa' AND (id = 'second' OR name = 'second')").
+			SendContext(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(response.Total()).To(Equal(1))
+		Expect(response.Items().Slice()[0].ID()).To(Equal("second-id"))
+	})
+
+	It("should update and delete clusters", func() {
+		client, err := server.Client(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer client.Close()
+
+		body, err := clustersmgmtv1.NewCluster().Properties(map[string]string{"owner": "updated"}).Build()
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = client.ClustersMgmt().V1().Clusters().Cluster("first-id").Update().Body(body).SendContext(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		properties, err := client.ClusterProperties(ctx, "first-id")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(properties).To(HaveKeyWithValue("owner", "updated"))
+
+		_, err = client.ClustersMgmt().V1().Clusters().Cluster("first-id").Delete().SendContext(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		response, err := client.ClustersMgmt().V1().Clusters().Cluster("first-id").Get().SendContext(ctx)
+		Expect(err).Should(HaveOccurred())
+		Expect(response.Status()).To(Equal(http.StatusNotFound))
+	})
+
+	It("should reuse the kubeconfig file until it is removed", func() {
+		requests := 0
+		server.Handle(http.MethodGet, "/api/clusters_mgmt/v1/clusters/first-id/credentials", func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"kind":       "ClusterCredentials",
+				"kubeconfig": "apiVersion: v1\nkind: Config\ncurrent-context: first\ncontexts:\n- name: first\n  context:\n    cluster: first\n    user: admin\n",
+			})
+		})
+
+		client, err := server.Client(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer client.Close()
+		client.KubeConfigDir = GinkgoT().TempDir()
+
+		for i := 0; i < 2; i++ {
+			kubeConfigFile, err := client.KubeConfigFile(ctx, "first-id")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(kubeConfigFile).To(Equal(filepath.Join(client.KubeConfigDir, "first-id-kubeconfig")))
+		}
+		Expect(requests).To(Equal(1))
+
+		Expect(client.RemoveKubeConfigFile("first-id")).To(Succeed())
+		Expect(filepath.Join(client.KubeConfigDir, "first-id-kubeconfig")).ToNot(BeAnExistingFile())
+
+		_, err = client.KubeConfigFile(ctx, "first-id")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(requests).To(Equal(2))
+	})
+
+	It("should serve the admin credentials", func() {
+		client, err := server.Client(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer client.Close()
+
+		_, err = client.ClusterAdminCredentials(ctx, "first-id")
+		Expect(err).To(MatchError(ContainSubstring("not populated")))
+
+		server.SetAdminCredentials("first-id", "kubeadmin", "secret")
+
+		credentials, err := client.WaitForClusterAdminCredentials(ctx, "first-id", time.Minute)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(credentials).To(Equal(&ocmclient.AdminCredentials{Username: "kubeadmin", Password: "secret"}))
+	})
+
+	It("should report the request metrics", func() {
+		client, err := server.Client(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer client.Close()
+
+		var metrics []ocmclient.RequestMetric
+		client.SetRequestMetricsHook(func(metric ocmclient.RequestMetric) {
+			metrics = append(metrics, metric)
+		})
+
+		_, err = client.ClustersMgmt().V1().Clusters().Cluster("missing-id").Get().SendContext(ctx)
+		Expect(err).Should(HaveOccurred())
+
+		Expect(metrics).To(HaveLen(1))
+		Expect(metrics[0].Method).To(Equal(http.MethodGet))
+		Expect(metrics[0].Path).To(Equal("/api/clusters_mgmt/v1/clusters/missing-id"))
+		Expect(metrics[0].Status).To(Equal(http.StatusNotFound))
+		Expect(metrics[0].Err).ShouldNot(HaveOccurred())
+	})
+
+	It("should send the requests of clients using the transport", func() {
+		var paths []string
+		client, err := NewClient(ctx, RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			paths = append(paths, r.URL.Path)
+			recorder := httptest.NewRecorder()
+			writeJSON(recorder, http.StatusOK, map[string]interface{}{"kind": "Account", "username": "tester"})
+			return recorder.Result(), nil
+		}))
+		Expect(err).ShouldNot(HaveOccurred())
+		defer client.Close()
+
+		account, err := client.CurrentAccount(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(account.Username()).To(Equal("tester"))
+		Expect(paths).To(Equal([]string{"/api/accounts_mgmt/v1/current_account"}))
+	})
+
+	It("should serve the registered handlers", func() {
+		server.Handle(http.MethodGet, "/api/accounts_mgmt/v1/current_account", func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"kind": "Account", "username": "tester"})
+		})
+
+		client, err := server.Client(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer client.Close()
+
+		response, err := client.AccountsMgmt().V1().CurrentAccount().Get().SendContext(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(response.Body().Username()).To(Equal("tester"))
+	})
+})
@@ -0,0 +1,54 @@
+package openshift
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// KubeConfigCertificateExpiration returns when the kubeconfigs current context client
+// certificate expires, zero when the context does not authenticate using a certificate
+func KubeConfigCertificateExpiration(kubeConfigFile string) (time.Time, error) {
+	config, err := clientcmd.LoadFromFile(kubeConfigFile)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to load kubeconfig %q: %w", kubeConfigFile, err)
+	}
+
+	context, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return time.Time{}, fmt.Errorf("kubeconfig %q current context %q not found", kubeConfigFile, config.CurrentContext)
+	}
+
+	authInfo, ok := config.AuthInfos[context.AuthInfo]
+	if !ok {
+		return time.Time{}, nil
+	}
+
+	data := authInfo.ClientCertificateData
+	if len(data) == 0 && authInfo.ClientCertificate != "" {
+		data, err = os.ReadFile(authInfo.ClientCertificate)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to read client certificate %q: %w", authInfo.ClientCertificate, err)
+		}
+	}
+
+	if len(data) == 0 {
+		return time.Time{}, nil
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return time.Time{}, fmt.Errorf("kubeconfig %q client certificate is not pem encoded", kubeConfigFile)
+	}
+
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse kubeconfig %q client certificate: %w", kubeConfigFile, err)
+	}
+
+	return certificate.NotAfter, nil
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/openshift/api"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
 )
@@ -17,6 +19,25 @@ func New() (*Client, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get kubernetes config: %w", err)
 	}
+	return newClient(cfg)
+}
+
+// NewFromKubeconfig constructs the client using the provided kubeconfig file
+// instead of relying on the KUBECONFIG environment variable
+func NewFromKubeconfig(kubeConfigFile string) (*Client, error) {
+	cfg, err := clientcmd.BuildConfigFromFlags("", kubeConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubernetes config from %q: %w", kubeConfigFile, err)
+	}
+	return newClient(cfg)
+}
+
+// NewFromConfig constructs the client using the provided rest config (e.g. a fake api server)
+func NewFromConfig(cfg *rest.Config) (*Client, error) {
+	return newClient(cfg)
+}
+
+func newClient(cfg *rest.Config) (*Client, error) {
 	client, err := resources.New(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to created dynamic client: %w", err)
@@ -0,0 +1,30 @@
+package openshift
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+)
+
+// DryRunCreate submits the object for creation using server-side dry-run so
+// admission webhooks are exercised without persisting the object. The error
+// returned contains the webhooks rejection message when the request is denied
+func (c *Client) DryRunCreate(ctx context.Context, obj k8s.Object) error {
+	return c.GetControllerRuntimeClient().Create(ctx, obj, client.DryRunAll)
+}
+
+// DryRunApply submits the object using server-side apply with dry-run so
+// admission webhooks are exercised against create and update requests alike.
+// The object must have its apiVersion and kind set
+func (c *Client) DryRunApply(ctx context.Context, obj k8s.Object, fieldManager string) error {
+	if obj.GetObjectKind().GroupVersionKind().Kind == "" {
+		return fmt.Errorf("object %q must have its apiVersion and kind set for server-side apply", obj.GetName())
+	}
+
+	obj.SetManagedFields(nil)
+	obj.SetResourceVersion("")
+
+	return c.GetControllerRuntimeClient().Patch(ctx, obj, client.Apply, client.DryRunAll, client.FieldOwner(fieldManager), client.ForceOwnership)
+}
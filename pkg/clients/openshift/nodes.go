@@ -0,0 +1,70 @@
+package openshift
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	workerNodeRoleLabel = "node-role.kubernetes.io/worker"
+	infraNodeRoleLabel  = "node-role.kubernetes.io/infra"
+)
+
+// WorkerNodes returns the number of ready worker nodes and the number of worker nodes,
+// infra nodes are not worker nodes
+func (c *Client) WorkerNodes(ctx context.Context) (ready int, total int, err error) {
+	var nodes corev1.NodeList
+	if err := c.List(ctx, &nodes); err != nil {
+		return 0, 0, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	for _, node := range nodes.Items {
+		_, worker := node.Labels[workerNodeRoleLabel]
+		_, infra := node.Labels[infraNodeRoleLabel]
+		if !worker || infra {
+			continue
+		}
+		total++
+
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue && !node.Spec.Unschedulable {
+				ready++
+			}
+		}
+	}
+
+	return ready, total, nil
+}
+
+// WaitForWorkerNodes waits for the cluster to have exactly count worker nodes, all of them
+// ready, so added nodes have joined and removed nodes have drained and been deleted
+func (c *Client) WaitForWorkerNodes(ctx context.Context, count int, timeout time.Duration) error {
+	var ready, total int
+
+	err := wait.PollUntilContextTimeout(ctx, 30*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		var err error
+		ready, total, err = c.WorkerNodes(ctx)
+		if err != nil {
+			log.Println(err)
+			return false, nil
+		}
+
+		if ready == count && total == count {
+			return true, nil
+		}
+
+		log.Printf("Waiting for %d worker nodes (ready=%d, total=%d)", count, ready, total)
+
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("cluster failed to have %d ready worker nodes (ready=%d, total=%d): %w", count, ready, total, err)
+	}
+
+	return nil
+}
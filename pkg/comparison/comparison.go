@@ -0,0 +1,207 @@
+package comparison
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const managedNamespacePrefix = "openshift-"
+
+// ComponentKind represents the type of managed component being compared
+type ComponentKind string
+
+const (
+	ClusterOperatorKind ComponentKind = "ClusterOperator"
+	DeploymentKind      ComponentKind = "Deployment"
+)
+
+// component represents the state of a managed component on a single cluster
+type component struct {
+	kind    ComponentKind
+	name    string
+	healthy bool
+}
+
+// Difference represents a managed component that differs between two clusters
+type Difference struct {
+	Kind    ComponentKind
+	Name    string
+	Present [2]bool
+	Healthy [2]bool
+}
+
+// String returns a human readable description of the difference
+func (d Difference) String() string {
+	switch {
+	case !d.Present[0]:
+		return fmt.Sprintf("%s %q only exists on the second cluster", d.Kind, d.Name)
+	case !d.Present[1]:
+		return fmt.Sprintf("%s %q only exists on the first cluster", d.Kind, d.Name)
+	default:
+		return fmt.Sprintf("%s %q health differs (first=%t, second=%t)", d.Kind, d.Name, d.Healthy[0], d.Healthy[1])
+	}
+}
+
+// Result contains the outcome of comparing the managed components of two clusters
+type Result struct {
+	Differences []Difference
+}
+
+// Equal returns true when no differences were found between the clusters
+func (r *Result) Equal() bool {
+	return len(r.Differences) == 0
+}
+
+// String returns the result formatted with one difference per line
+func (r *Result) String() string {
+	if r.Equal() {
+		return "no differences found"
+	}
+
+	lines := make([]string, 0, len(r.Differences))
+	for _, difference := range r.Differences {
+		lines = append(lines, difference.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// comparisonError represents the custom error
+type comparisonError struct {
+	err error
+}
+
+// Error returns the formatted error message when comparisonError is invoked
+func (c *comparisonError) Error() string {
+	return fmt.Sprintf("cluster comparison failed: %v", c.err)
+}
+
+// CompareClusters compares the presence and health of managed components
+// (cluster operators and deployments in openshift-* namespaces) between two
+// clusters and returns the differences found
+//
+//	result, err := comparison.CompareClusters(ctx, classicClient, hcpClient)
+//	Expect(err).ShouldNot(HaveOccurred())
+//	Expect(result.Equal()).To(BeTrue(), result.String())
+func CompareClusters(ctx context.Context, first, second *openshift.Client) (*Result, error) {
+	firstComponents, err := managedComponents(ctx, first)
+	if err != nil {
+		return nil, &comparisonError{err: fmt.Errorf("first cluster: %v", err)}
+	}
+
+	secondComponents, err := managedComponents(ctx, second)
+	if err != nil {
+		return nil, &comparisonError{err: fmt.Errorf("second cluster: %v", err)}
+	}
+
+	return compare(firstComponents, secondComponents), nil
+}
+
+// compare builds a result from the managed components of each cluster
+func compare(first, second map[string]component) *Result {
+	keys := map[string]struct{}{}
+	for key := range first {
+		keys[key] = struct{}{}
+	}
+	for key := range second {
+		keys[key] = struct{}{}
+	}
+
+	result := &Result{}
+	for key := range keys {
+		firstComponent, inFirst := first[key]
+		secondComponent, inSecond := second[key]
+
+		if inFirst && inSecond && firstComponent.healthy == secondComponent.healthy {
+			continue
+		}
+
+		difference := Difference{
+			Present: [2]bool{inFirst, inSecond},
+			Healthy: [2]bool{firstComponent.healthy, secondComponent.healthy},
+		}
+		if inFirst {
+			difference.Kind, difference.Name = firstComponent.kind, firstComponent.name
+		} else {
+			difference.Kind, difference.Name = secondComponent.kind, secondComponent.name
+		}
+
+		result.Differences = append(result.Differences, difference)
+	}
+
+	sort.Slice(result.Differences, func(i, j int) bool {
+		if result.Differences[i].Kind != result.Differences[j].Kind {
+			return result.Differences[i].Kind < result.Differences[j].Kind
+		}
+		return result.Differences[i].Name < result.Differences[j].Name
+	})
+
+	return result
+}
+
+// managedComponents returns the managed components found on the cluster keyed by kind/name
+func managedComponents(ctx context.Context, client *openshift.Client) (map[string]component, error) {
+	components := map[string]component{}
+
+	var clusterOperators configv1.ClusterOperatorList
+	if err := client.List(ctx, &clusterOperators); err != nil {
+		return nil, fmt.Errorf("failed to list cluster operators: %w", err)
+	}
+
+	for _, clusterOperator := range clusterOperators.Items {
+		healthy := false
+		for _, condition := range clusterOperator.Status.Conditions {
+			if condition.Type == configv1.OperatorAvailable {
+				healthy = condition.Status == configv1.ConditionTrue
+			}
+		}
+		add(components, component{kind: ClusterOperatorKind, name: clusterOperator.Name, healthy: healthy})
+	}
+
+	var namespaces corev1.NamespaceList
+	if err := client.List(ctx, &namespaces); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	// the resources client is namespaced in place, restore it to all namespaces once finished
+	defer client.WithNamespace("")
+
+	for _, namespace := range namespaces.Items {
+		if !strings.HasPrefix(namespace.Name, managedNamespacePrefix) {
+			continue
+		}
+
+		var deployments appsv1.DeploymentList
+		if err := client.WithNamespace(namespace.Name).List(ctx, &deployments); err != nil {
+			return nil, fmt.Errorf("failed to list deployments in namespace %q: %w", namespace.Name, err)
+		}
+
+		for _, deployment := range deployments.Items {
+			healthy := false
+			for _, condition := range deployment.Status.Conditions {
+				if condition.Type == appsv1.DeploymentAvailable {
+					healthy = condition.Status == corev1.ConditionTrue
+				}
+			}
+			add(components, component{
+				kind:    DeploymentKind,
+				name:    fmt.Sprintf("%s/%s", deployment.Namespace, deployment.Name),
+				healthy: healthy,
+			})
+		}
+	}
+
+	return components, nil
+}
+
+// add inserts the component into the components map
+func add(components map[string]component, c component) {
+	components[fmt.Sprintf("%s/%s", c.kind, c.name)] = c
+}
@@ -0,0 +1,63 @@
+package comparison
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/osde2e-framework/pkg/clients/kubernetesfake"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("compare", func() {
+	It("should report no differences", func() {
+		components := map[string]component{
+			"ClusterOperator/dns": {kind: ClusterOperatorKind, name: "dns", healthy: true},
+		}
+		Expect(compare(components, components).Equal()).To(BeTrue())
+	})
+
+	It("should report missing and unhealthy components", func() {
+		first := map[string]component{
+			"ClusterOperator/dns":     {kind: ClusterOperatorKind, name: "dns", healthy: true},
+			"ClusterOperator/console": {kind: ClusterOperatorKind, name: "console", healthy: true},
+		}
+		second := map[string]component{
+			"ClusterOperator/dns": {kind: ClusterOperatorKind, name: "dns", healthy: false},
+		}
+
+		report := compare(first, second)
+		Expect(report.Differences).To(HaveLen(2))
+		Expect(report.Differences[0].Name).To(Equal("console"))
+		Expect(report.Differences[0].Present).To(Equal([2]bool{true, false}))
+		Expect(report.Differences[1].Name).To(Equal("dns"))
+		Expect(report.Differences[1].Healthy).To(Equal([2]bool{true, false}))
+	})
+})
+
+var _ = Describe("CompareClusters", func() {
+	It("should leave the clients listing all namespaces", func(ctx context.Context) {
+		server, err := kubernetesfake.NewServer(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openshift-dns"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "my-app"}},
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "openshift-dns"}},
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "my-app"}},
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		DeferCleanup(server.Close)
+
+		client, err := server.Client()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		result, err := CompareClusters(ctx, client, client)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(result.Equal()).To(BeTrue())
+
+		var deployments appsv1.DeploymentList
+		Expect(client.List(ctx, &deployments)).To(Succeed())
+		Expect(deployments.Items).To(HaveLen(2))
+	})
+})
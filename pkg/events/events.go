@@ -0,0 +1,171 @@
+// Package events notifies hooks of cluster provisioning progress (e.g. to feed a dashboard
+// from ci). The providers invoke the hooks when phases start and end (create, install,
+// health checks, delete, upgrade), when the cluster state changes while waiting and when
+// a warning is raised (e.g. credentials about to expire). Hooks are only invoked when the provisioning-events feature flag is enabled
+package events
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/openshift/osde2e-framework/pkg/featureflags"
+)
+
+// PhaseEvent represents a provisioning phase starting or ending
+type PhaseEvent struct {
+	Phase       string
+	ClusterID   string
+	ClusterName string
+	Time        time.Time
+	// Duration is the time the phase took, zero when the phase is starting
+	Duration time.Duration
+	// Err is the error the phase failed with, nil when starting or successful
+	Err error
+}
+
+// StateChangeEvent represents the cluster state changing while the provider waits on it
+type StateChangeEvent struct {
+	ClusterID   string
+	ClusterName string
+	From        string
+	To          string
+	Time        time.Time
+}
+
+// WarningEvent represents a condition operators may need to act on during the run
+type WarningEvent struct {
+	// Source is what raised the warning (e.g. ocm token)
+	Source  string
+	Message string
+	// ExpiresAt is when the source expires, zero when the warning is not about an expiry
+	ExpiresAt time.Time
+	Time      time.Time
+}
+
+// Hook receives provisioning events, hooks are invoked synchronously and must return quickly
+type Hook interface {
+	OnPhaseStart(event PhaseEvent)
+	OnPhaseEnd(event PhaseEvent)
+	OnStateChange(event StateChangeEvent)
+	OnWarning(event WarningEvent)
+}
+
+// Funcs implements Hook using the functions defined, undefined functions are skipped
+type Funcs struct {
+	PhaseStart  func(event PhaseEvent)
+	PhaseEnd    func(event PhaseEvent)
+	StateChange func(event StateChangeEvent)
+	Warning     func(event WarningEvent)
+}
+
+// OnPhaseStart invokes PhaseStart when defined
+func (f Funcs) OnPhaseStart(event PhaseEvent) {
+	if f.PhaseStart != nil {
+		f.PhaseStart(event)
+	}
+}
+
+// OnPhaseEnd invokes PhaseEnd when defined
+func (f Funcs) OnPhaseEnd(event PhaseEvent) {
+	if f.PhaseEnd != nil {
+		f.PhaseEnd(event)
+	}
+}
+
+// OnStateChange invokes StateChange when defined
+func (f Funcs) OnStateChange(event StateChangeEvent) {
+	if f.StateChange != nil {
+		f.StateChange(event)
+	}
+}
+
+// OnWarning invokes Warning when defined
+func (f Funcs) OnWarning(event WarningEvent) {
+	if f.Warning != nil {
+		f.Warning(event)
+	}
+}
+
+// Dispatcher invokes the hooks added to it, the zero value is ready to use
+type Dispatcher struct {
+	mu    sync.RWMutex
+	hooks []Hook
+}
+
+// Add adds the hooks to the dispatcher
+func (d *Dispatcher) Add(hooks ...Hook) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.hooks = append(d.hooks, hooks...)
+}
+
+// PhaseStart notifies the hooks the phase started and returns its start time
+func (d *Dispatcher) PhaseStart(phase, clusterID, clusterName string) time.Time {
+	start := time.Now()
+
+	d.dispatch(func(hook Hook) {
+		hook.OnPhaseStart(PhaseEvent{Phase: phase, ClusterID: clusterID, ClusterName: clusterName, Time: start})
+	})
+
+	return start
+}
+
+// PhaseEnd notifies the hooks the phase that started at start ended
+func (d *Dispatcher) PhaseEnd(phase, clusterID, clusterName string, start time.Time, err error) {
+	end := time.Now()
+
+	d.dispatch(func(hook Hook) {
+		hook.OnPhaseEnd(PhaseEvent{
+			Phase:       phase,
+			ClusterID:   clusterID,
+			ClusterName: clusterName,
+			Time:        end,
+			Duration:    end.Sub(start),
+			Err:         err,
+		})
+	})
+}
+
+// StateChange notifies the hooks the cluster state changed, nothing is sent when the state is unchanged
+func (d *Dispatcher) StateChange(clusterID, clusterName, from, to string) {
+	if from == to {
+		return
+	}
+
+	d.dispatch(func(hook Hook) {
+		hook.OnStateChange(StateChangeEvent{ClusterID: clusterID, ClusterName: clusterName, From: from, To: to, Time: time.Now()})
+	})
+}
+
+// Warning notifies the hooks of the warning
+func (d *Dispatcher) Warning(source, message string, expiresAt time.Time) {
+	d.dispatch(func(hook Hook) {
+		hook.OnWarning(WarningEvent{Source: source, Message: message, ExpiresAt: expiresAt, Time: time.Now()})
+	})
+}
+
+// dispatch invokes the function for each hook when events are enabled, a panicking hook
+// is logged rather than failing provisioning
+func (d *Dispatcher) dispatch(invoke func(hook Hook)) {
+	if d == nil || !featureflags.Enabled(featureflags.ProvisioningEvents) {
+		return
+	}
+
+	d.mu.RLock()
+	hooks := make([]Hook, len(d.hooks))
+	copy(hooks, d.hooks)
+	d.mu.RUnlock()
+
+	for _, hook := range hooks {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("Provisioning event hook %T panicked: %v", hook, r)
+				}
+			}()
+			invoke(hook)
+		}()
+	}
+}
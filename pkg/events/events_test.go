@@ -0,0 +1,58 @@
+package events
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/osde2e-framework/pkg/featureflags"
+)
+
+var _ = Describe("Events", func() {
+	var (
+		dispatcher *Dispatcher
+		phases     []PhaseEvent
+		changes    []StateChangeEvent
+	)
+
+	BeforeEach(func() {
+		phases, changes = nil, nil
+		dispatcher = &Dispatcher{}
+		dispatcher.Add(Funcs{
+			PhaseEnd:    func(event PhaseEvent) { phases = append(phases, event) },
+			StateChange: func(event StateChangeEvent) { changes = append(changes, event) },
+		})
+	})
+
+	AfterEach(func() {
+		Expect(featureflags.Global().Set(featureflags.ProvisioningEvents, false)).To(Succeed())
+	})
+
+	It("should not notify the hooks when the feature flag is disabled", func() {
+		dispatcher.PhaseEnd("install", "abc", "my-cluster", time.Now(), nil)
+		Expect(phases).To(BeEmpty())
+	})
+
+	It("should notify the hooks", func() {
+		Expect(featureflags.Global().Set(featureflags.ProvisioningEvents, true)).To(Succeed())
+
+		start := dispatcher.PhaseStart("install", "abc", "my-cluster")
+		dispatcher.PhaseEnd("install", "abc", "my-cluster", start, fmt.Errorf("timed out"))
+		dispatcher.StateChange("abc", "my-cluster", "", "installing")
+		dispatcher.StateChange("abc", "my-cluster", "installing", "installing")
+
+		Expect(phases).To(HaveLen(1))
+		Expect(phases[0].Err).To(MatchError("timed out"))
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].To).To(Equal("installing"))
+	})
+
+	It("should recover from panicking hooks", func() {
+		Expect(featureflags.Global().Set(featureflags.ProvisioningEvents, true)).To(Succeed())
+
+		dispatcher.Add(Funcs{PhaseStart: func(PhaseEvent) { panic("boom") }})
+		Expect(func() { dispatcher.PhaseStart("install", "abc", "my-cluster") }).ToNot(Panic())
+	})
+})
@@ -0,0 +1,110 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Expiry returns when a credential expires, zero when it does not expire
+type Expiry func(ctx context.Context) (time.Time, error)
+
+// ExpiryWatcher periodically checks when credentials (ocm tokens, aws sessions, kubeconfig
+// certificates) expire, logging and sending a warning event once a credential is within the
+// warning window so operators can intervene before authentication failures cascade
+type ExpiryWatcher struct {
+	dispatcher *Dispatcher
+	warnBefore time.Duration
+
+	mu          sync.Mutex
+	credentials map[string]Expiry
+	// warned records the expiry each credential was warned about, so each expiry is warned once
+	warned map[string]time.Time
+}
+
+// NewExpiryWatcher returns a watcher warning about credentials expiring within warnBefore
+func NewExpiryWatcher(dispatcher *Dispatcher, warnBefore time.Duration) *ExpiryWatcher {
+	return &ExpiryWatcher{
+		dispatcher:  dispatcher,
+		warnBefore:  warnBefore,
+		credentials: map[string]Expiry{},
+		warned:      map[string]time.Time{},
+	}
+}
+
+// Watch adds the credential, replacing an existing credential with the same name
+func (w *ExpiryWatcher) Watch(name string, expiry Expiry) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.credentials[name] = expiry
+}
+
+// Unwatch removes the credential, e.g. the kubeconfig of a deleted cluster
+func (w *ExpiryWatcher) Unwatch(name string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.credentials, name)
+	delete(w.warned, name)
+}
+
+// Check checks the credentials expiry, returning the warnings raised
+func (w *ExpiryWatcher) Check(ctx context.Context) []WarningEvent {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	names := make([]string, 0, len(w.credentials))
+	for name := range w.credentials {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var warnings []WarningEvent
+	now := time.Now()
+
+	for _, name := range names {
+		expiresAt, err := w.credentials[name](ctx)
+		if err != nil {
+			log.Printf("Failed to check %s expiry: %v", name, err)
+			continue
+		}
+
+		if expiresAt.IsZero() || expiresAt.Sub(now) > w.warnBefore || w.warned[name].Equal(expiresAt) {
+			continue
+		}
+		w.warned[name] = expiresAt
+
+		message := fmt.Sprintf("%s expires in %s (%s)", name, expiresAt.Sub(now).Round(time.Second), expiresAt.Format(time.RFC3339))
+		if !expiresAt.After(now) {
+			message = fmt.Sprintf("%s expired at %s", name, expiresAt.Format(time.RFC3339))
+		}
+
+		log.Printf("WARNING: %s", message)
+		w.dispatcher.Warning(name, message, expiresAt)
+		warnings = append(warnings, WarningEvent{Source: name, Message: message, ExpiresAt: expiresAt, Time: now})
+	}
+
+	return warnings
+}
+
+// Run checks the credentials every interval until the context is done
+//
+//	go watcher.Run(ctx, 5*time.Minute)
+func (w *ExpiryWatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		w.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
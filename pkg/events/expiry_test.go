@@ -0,0 +1,62 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/osde2e-framework/pkg/featureflags"
+)
+
+var _ = Describe("ExpiryWatcher", func() {
+	var (
+		watcher  *ExpiryWatcher
+		warnings []WarningEvent
+	)
+
+	BeforeEach(func() {
+		Expect(featureflags.Global().Set(featureflags.ProvisioningEvents, true)).To(Succeed())
+
+		warnings = nil
+		dispatcher := &Dispatcher{}
+		dispatcher.Add(Funcs{Warning: func(event WarningEvent) { warnings = append(warnings, event) }})
+		watcher = NewExpiryWatcher(dispatcher, time.Hour)
+	})
+
+	AfterEach(func() {
+		Expect(featureflags.Global().Set(featureflags.ProvisioningEvents, false)).To(Succeed())
+	})
+
+	expiresIn := func(d time.Duration) Expiry {
+		expiresAt := time.Now().Add(d).Truncate(time.Second)
+		return func(context.Context) (time.Time, error) { return expiresAt, nil }
+	}
+
+	It("should warn once about credentials expiring within the window", func() {
+		watcher.Watch("ocm token", expiresIn(30*time.Minute))
+		watcher.Watch("aws credentials", expiresIn(2*time.Hour))
+		watcher.Watch("offline token", func(context.Context) (time.Time, error) { return time.Time{}, nil })
+
+		Expect(watcher.Check(context.Background())).To(HaveLen(1))
+		Expect(watcher.Check(context.Background())).To(BeEmpty())
+		Expect(warnings).To(HaveLen(1))
+		Expect(warnings[0].Source).To(Equal("ocm token"))
+	})
+
+	It("should warn about expired credentials", func() {
+		watcher.Watch("cluster abc kubeconfig", expiresIn(-time.Minute))
+
+		result := watcher.Check(context.Background())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Message).To(ContainSubstring("expired at"))
+	})
+
+	It("should stop checking unwatched credentials", func() {
+		watcher.Watch("cluster abc kubeconfig", expiresIn(-time.Minute))
+		watcher.Unwatch("cluster abc kubeconfig")
+
+		Expect(watcher.Check(context.Background())).To(BeEmpty())
+	})
+})
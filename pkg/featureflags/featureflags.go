@@ -0,0 +1,155 @@
+// Package featureflags toggles experimental framework behaviors per run so they can
+// ship disabled without affecting existing consumers. Flags are set in code or with the
+// OSDE2E_FEATURE_FLAGS environment variable, a comma separated list of flags to enable
+// where a leading - disables the flag (e.g. "async-health-checks,-provisioning-events")
+package featureflags
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Flag represents the name of an experimental behavior
+type Flag string
+
+const (
+	// SDKClusterCreation creates clusters using the ocm sdk rather than the rosa cli
+	SDKClusterCreation Flag = "sdk-cluster-creation"
+	// ProvisioningEvents invokes the providers event hooks during cluster provisioning
+	ProvisioningEvents Flag = "provisioning-events"
+	// AsyncHealthChecks runs the cluster health checks concurrently
+	AsyncHealthChecks Flag = "async-health-checks"
+)
+
+// Env is the environment variable holding the flags to enable or disable
+const Env = "OSDE2E_FEATURE_FLAGS"
+
+// flag represents a registered flag
+type flag struct {
+	description string
+	enabled     bool
+}
+
+// Flags represents the registered flags and whether they are enabled for the run
+type Flags struct {
+	mu    sync.RWMutex
+	flags map[Flag]*flag
+}
+
+var (
+	globalFlags *Flags
+	globalOnce  sync.Once
+)
+
+// New returns the framework flags, disabled by default, with the environment variable applied.
+// Unknown flags in the environment variable are logged and ignored
+func New() *Flags {
+	f := &Flags{flags: map[Flag]*flag{}}
+
+	f.Register(SDKClusterCreation, "create clusters using the ocm sdk rather than the rosa cli", false)
+	f.Register(ProvisioningEvents, "invoke the providers event hooks during cluster provisioning", false)
+	f.Register(AsyncHealthChecks, "run the cluster health checks concurrently", false)
+
+	if err := f.Parse(os.Getenv(Env)); err != nil {
+		log.Printf("Ignoring invalid %s: %v", Env, err)
+	}
+
+	return f
+}
+
+// Global returns the flags the framework checks, loaded from the environment on first use
+func Global() *Flags {
+	globalOnce.Do(func() {
+		globalFlags = New()
+	})
+	return globalFlags
+}
+
+// Enabled returns whether the flag is enabled in the global flags
+func Enabled(name Flag) bool {
+	return Global().Enabled(name)
+}
+
+// Register adds the flag, consumers may register their own experiments alongside the
+// frameworks. Registering an existing flag updates its description and default
+func (f *Flags) Register(name Flag, description string, enabled bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.flags[name] = &flag{description: description, enabled: enabled}
+}
+
+// Enabled returns whether the flag is enabled, unregistered flags are disabled
+func (f *Flags) Enabled(name Flag) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if registered, ok := f.flags[name]; ok {
+		return registered.enabled
+	}
+	return false
+}
+
+// Set enables or disables the registered flag
+func (f *Flags) Set(name Flag, enabled bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	registered, ok := f.flags[name]
+	if !ok {
+		return fmt.Errorf("feature flag %q is not registered", name)
+	}
+	registered.enabled = enabled
+
+	return nil
+}
+
+// Parse applies a comma separated list of flags to enable, a leading - disables the flag.
+// All known flags in the list are applied even when others are unknown
+func (f *Flags) Parse(value string) error {
+	var unknown []string
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		enabled := !strings.HasPrefix(item, "-")
+		name := Flag(strings.TrimPrefix(item, "-"))
+
+		if err := f.Set(name, enabled); err != nil {
+			unknown = append(unknown, string(name))
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown feature flags: %s", strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// String returns the registered flags and whether they are enabled sorted by name
+func (f *Flags) String() string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	names := make([]string, 0, len(f.flags))
+	for name := range f.flags {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		registered := f.flags[Flag(name)]
+		lines = append(lines, fmt.Sprintf("%s=%t (%s)", name, registered.enabled, registered.description))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,32 @@
+package featureflags
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Feature Flags", func() {
+	It("should disable the framework flags by default", func() {
+		flags := &Flags{flags: map[Flag]*flag{}}
+		flags.Register(AsyncHealthChecks, "", false)
+		Expect(flags.Enabled(AsyncHealthChecks)).To(BeFalse())
+		Expect(flags.Enabled("unregistered")).To(BeFalse())
+	})
+
+	It("should apply the flags list", func() {
+		flags := &Flags{flags: map[Flag]*flag{}}
+		flags.Register(AsyncHealthChecks, "", false)
+		flags.Register(ProvisioningEvents, "", true)
+
+		err := flags.Parse("async-health-checks, -provisioning-events,unknown")
+		Expect(err).To(MatchError(ContainSubstring("unknown")))
+		Expect(flags.Enabled(AsyncHealthChecks)).To(BeTrue())
+		Expect(flags.Enabled(ProvisioningEvents)).To(BeFalse())
+		Expect(flags.String()).To(ContainSubstring("async-health-checks=true"))
+	})
+
+	It("should reject setting unregistered flags", func() {
+		flags := &Flags{flags: map[Flag]*flag{}}
+		Expect(flags.Set("unknown", true)).ToNot(Succeed())
+	})
+})
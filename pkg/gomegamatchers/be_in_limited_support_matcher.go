@@ -0,0 +1,45 @@
+package gomegamatchers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	ocmclient "github.com/openshift/osde2e-framework/pkg/clients/ocm"
+)
+
+type beInLimitedSupportMatcher struct {
+	reasons []*ocmclient.LimitedSupportReason
+}
+
+// BeInLimitedSupport is a gomega matcher that can be used to assert that a cluster has
+// limited support reasons, the reasons are listed when a cluster is unexpectedly in limited support
+//
+//	Eventually(ctx, func(ctx context.Context) ([]*ocm.LimitedSupportReason, error) {
+//		return client.GetLimitedSupportReasons(ctx, clusterID)
+//	}).WithTimeout(10 * time.Minute).ShouldNot(BeInLimitedSupport())
+func BeInLimitedSupport() types.GomegaMatcher {
+	return &beInLimitedSupportMatcher{}
+}
+
+func (matcher *beInLimitedSupportMatcher) Match(actual any) (bool, error) {
+	reasons, ok := actual.([]*ocmclient.LimitedSupportReason)
+	if !ok {
+		return false, fmt.Errorf("BeInLimitedSupport expected a []*ocm.LimitedSupportReason but got %s", format.Object(actual, 1))
+	}
+	matcher.reasons = reasons
+	return len(reasons) > 0, nil
+}
+
+func (matcher *beInLimitedSupportMatcher) FailureMessage(actual any) string {
+	return "Expected the cluster to be in limited support"
+}
+
+func (matcher *beInLimitedSupportMatcher) NegatedFailureMessage(actual any) string {
+	summaries := make([]string, 0, len(matcher.reasons))
+	for _, reason := range matcher.reasons {
+		summaries = append(summaries, reason.String())
+	}
+	return fmt.Sprintf("Expected the cluster not to be in limited support: %s", strings.Join(summaries, ", "))
+}
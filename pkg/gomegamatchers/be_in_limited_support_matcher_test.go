@@ -0,0 +1,23 @@
+package gomegamatchers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	ocmclient "github.com/openshift/osde2e-framework/pkg/clients/ocm"
+)
+
+var _ = Describe("limited support", func() {
+	It("should be in limited support", func() {
+		reasons := []*ocmclient.LimitedSupportReason{{Summary: "Cluster is unreachable", Details: "the api server is down"}}
+		Expect(reasons).Should(BeInLimitedSupport())
+
+		matcher := BeInLimitedSupport()
+		_, err := matcher.Match(reasons)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(matcher.NegatedFailureMessage(reasons)).To(ContainSubstring("Cluster is unreachable (the api server is down)"))
+	})
+
+	It("should not be in limited support", func() {
+		Expect([]*ocmclient.LimitedSupportReason{}).ShouldNot(BeInLimitedSupport())
+	})
+})
@@ -0,0 +1,46 @@
+package gomegamatchers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type beRejectedWithMatcher struct {
+	message string
+}
+
+// BeRejectedWith is a gomega matcher that can be used to assert that a
+// request was rejected by the api server (e.g. by an admission webhook)
+// with an error message containing the provided message
+//
+//	err = client.DryRunCreate(ctx, &namespace)
+//	Expect(err).Should(BeRejectedWith("creating namespaces is not allowed"))
+func BeRejectedWith(message string) types.GomegaMatcher {
+	return &beRejectedWithMatcher{message}
+}
+
+func (matcher *beRejectedWithMatcher) Match(actual any) (bool, error) {
+	if actual == nil {
+		return false, nil
+	}
+	err, ok := actual.(error)
+	if !ok {
+		return false, fmt.Errorf("BeRejectedWith expected an error but got %s", format.Object(actual, 1))
+	}
+	if !apierrors.IsForbidden(err) && !apierrors.IsInvalid(err) && !apierrors.IsBadRequest(err) {
+		return false, nil
+	}
+	return strings.Contains(err.Error(), matcher.message), nil
+}
+
+func (matcher *beRejectedWithMatcher) FailureMessage(actual any) string {
+	return format.Message(actual, fmt.Sprintf("to be rejected with message %q", matcher.message))
+}
+
+func (matcher *beRejectedWithMatcher) NegatedFailureMessage(actual any) string {
+	return format.Message(actual, fmt.Sprintf("not to be rejected with message %q", matcher.message))
+}
@@ -0,0 +1,22 @@
+package gomegamatchers
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var _ = Describe("rejection", func() {
+	It("should be rejected with the message", func() {
+		err := apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "test", errors.New("admission webhook denied the request: not allowed"))
+		Expect(err).Should(BeRejectedWith("not allowed"))
+	})
+
+	It("should not be rejected", func() {
+		Expect(nil).ShouldNot(BeRejectedWith("not allowed"))
+		Expect(errors.New("connection refused")).ShouldNot(BeRejectedWith("not allowed"))
+	})
+})
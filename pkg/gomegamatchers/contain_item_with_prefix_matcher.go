@@ -3,10 +3,10 @@ package gomegamatchers
 import (
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/onsi/gomega/format"
 	"github.com/onsi/gomega/types"
+	"github.com/openshift/osde2e-framework/pkg/checks"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -31,20 +31,11 @@ func (matcher *containItemWithPrefixMatcher) Match(actual any) (bool, error) {
 	if !ok {
 		return false, errors.New("type must be a runtime.Object")
 	}
-	items, err := meta.ExtractList(obj)
-	if err != nil {
+	if _, err := meta.ExtractList(obj); err != nil {
 		return false, fmt.Errorf("not a list type: %w", err)
 	}
-	for _, item := range items {
-		accessor, err := meta.Accessor(item)
-		if err != nil {
-			return false, fmt.Errorf("unable to get item's objectmeta: %w", err)
-		}
-		if strings.HasPrefix(accessor.GetName(), matcher.prefix) {
-			return true, nil
-		}
-	}
-	return false, nil
+	contains, _ := checks.ContainsItemWithPrefix(obj, matcher.prefix)
+	return contains, nil
 }
 
 func (matcher *containItemWithPrefixMatcher) FailureMessage(actual any) string {
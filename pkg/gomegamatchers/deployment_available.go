@@ -5,8 +5,8 @@ import (
 
 	"github.com/onsi/gomega/format"
 	"github.com/onsi/gomega/types"
+	"github.com/openshift/osde2e-framework/pkg/checks"
 	appsv1 "k8s.io/api/apps/v1"
-	corev1 "k8s.io/api/core/v1"
 )
 
 type beAvailableMatcher struct{}
@@ -20,12 +20,8 @@ func (d *beAvailableMatcher) Match(actual any) (bool, error) {
 	if !ok {
 		return false, fmt.Errorf("BeAvailable expected an appsv1.Deployment object but got %s", format.Object(actual, 1))
 	}
-	for _, cond := range deployment.Status.Conditions {
-		if cond.Type == appsv1.DeploymentAvailable {
-			return cond.Status == corev1.ConditionTrue, nil
-		}
-	}
-	return false, nil
+	available, _ := checks.DeploymentAvailable(deployment)
+	return available, nil
 }
 
 func (d *beAvailableMatcher) FailureMessage(actual any) string {
@@ -0,0 +1,43 @@
+package gomegamatchers
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"github.com/openshift/osde2e-framework/pkg/checks"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type haveNodesReadyMatcher struct {
+	details string
+}
+
+// HaveNodesReady is a gomega matcher that can be used to assert that a node list
+// has nodes and all of them are ready
+//
+//	var nodes corev1.NodeList
+//	err = k8s.List(ctx, &nodes)
+//	Expect(err).ShouldNot(HaveOccurred(), "failed to list nodes")
+//	Expect(&nodes).Should(HaveNodesReady())
+func HaveNodesReady() types.GomegaMatcher {
+	return &haveNodesReadyMatcher{}
+}
+
+func (matcher *haveNodesReadyMatcher) Match(actual any) (bool, error) {
+	nodes, ok := actual.(*corev1.NodeList)
+	if !ok {
+		return false, fmt.Errorf("HaveNodesReady expected a corev1.NodeList object but got %s", format.Object(actual, 1))
+	}
+	var ready bool
+	ready, matcher.details = checks.NodesReady(nodes)
+	return ready, nil
+}
+
+func (matcher *haveNodesReadyMatcher) FailureMessage(actual any) string {
+	return fmt.Sprintf("Expected all nodes to be ready: %s", matcher.details)
+}
+
+func (matcher *haveNodesReadyMatcher) NegatedFailureMessage(actual any) string {
+	return "Expected not all nodes to be ready"
+}
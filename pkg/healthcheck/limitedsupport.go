@@ -0,0 +1,32 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ocmclient "github.com/openshift/osde2e-framework/pkg/clients/ocm"
+)
+
+// NotInLimitedSupport verifies the cluster is fully supported, returning an error listing the
+// clusters limited support reasons when it is in limited support
+//
+//	err := healthcheck.NotInLimitedSupport(ctx, ocmClient, clusterID)
+//	Expect(err).ShouldNot(HaveOccurred())
+func NotInLimitedSupport(ctx context.Context, client *ocmclient.Client, clusterID string) error {
+	reasons, err := client.GetLimitedSupportReasons(ctx, clusterID)
+	if err != nil {
+		return &healthCheckError{name: "limited support", err: err}
+	}
+
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	summaries := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		summaries = append(summaries, reason.String())
+	}
+
+	return &healthCheckError{name: "limited support", err: fmt.Errorf("cluster id %q is in limited support: %s", clusterID, strings.Join(summaries, ", "))}
+}
@@ -0,0 +1,50 @@
+package healthcheck_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/osde2e-framework/pkg/clients/ocmfake"
+	"github.com/openshift/osde2e-framework/pkg/healthcheck"
+)
+
+var _ = Describe("NotInLimitedSupport", func() {
+	const reasonsPath = "/api/clusters_mgmt/v1/clusters/abc/limited_support_reasons"
+
+	var server *ocmfake.Server
+
+	BeforeEach(func() {
+		server = ocmfake.NewServer()
+		DeferCleanup(server.Close)
+	})
+
+	It("should pass when the cluster has no limited support reasons", func(ctx context.Context) {
+		server.Handle(http.MethodGet, reasonsPath, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"kind":"LimitedSupportReasonList","page":1,"size":0,"total":0,"items":[]}`))
+		})
+
+		client, err := server.Client(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		DeferCleanup(client.Close)
+
+		Expect(healthcheck.NotInLimitedSupport(ctx, client, "abc")).To(Succeed())
+	})
+
+	It("should list the limited support reasons", func(ctx context.Context) {
+		server.Handle(http.MethodGet, reasonsPath, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"kind":"LimitedSupportReasonList","page":1,"size":1,"total":1,"items":[{"kind":"LimitedSupportReason","id":"1","summary":"Cluster is unreachable","details":"the api server is down"}]}`))
+		})
+
+		client, err := server.Client(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		DeferCleanup(client.Close)
+
+		err = healthcheck.NotInLimitedSupport(ctx, client, "abc")
+		Expect(err).To(MatchError(ContainSubstring("Cluster is unreachable (the api server is down)")))
+	})
+})
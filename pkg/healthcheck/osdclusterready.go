@@ -0,0 +1,159 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+	"github.com/openshift/osde2e-framework/pkg/summary"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	osdClusterReadyJobName      = "osd-cluster-ready"
+	osdClusterReadyJobNamespace = "openshift-monitoring"
+	osdClusterReadyPollInterval = 30 * time.Second
+)
+
+// healthCheckError represents the custom error
+type healthCheckError struct {
+	name string
+	err  error
+}
+
+// Error returns the formatted error message when healthCheckError is invoked
+func (h *healthCheckError) Error() string {
+	return fmt.Sprintf("%s health check failed: %v", h.name, h.err)
+}
+
+// OSDClusterReady waits for the osd-cluster-ready job, created on the cluster once installed,
+// to succeed the same way legacy osde2e does. The job pod logs are written to a file named after
+// the clusters api host in the artifact directory (the current directory when empty), recorded in
+// the run summary, when the job fails or does not complete within the timeout
+//
+//	err := healthcheck.OSDClusterReady(ctx, client, artifactDir, 45*time.Minute)
+//	Expect(err).ShouldNot(HaveOccurred())
+func OSDClusterReady(ctx context.Context, client *openshift.Client, artifactDir string, timeout time.Duration) error {
+	log.Printf("Waiting up to %s for the %s job to succeed", timeout, osdClusterReadyJobName)
+
+	var failed bool
+	err := wait.PollUntilContextTimeout(ctx, osdClusterReadyPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		var job batchv1.Job
+		err := client.Get(ctx, osdClusterReadyJobName, osdClusterReadyJobNamespace, &job)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				log.Printf("%s job does not exist yet", osdClusterReadyJobName)
+				return false, nil
+			}
+			log.Printf("Failed to get %s job: %v", osdClusterReadyJobName, err)
+			return false, nil
+		}
+
+		if job.Status.Succeeded > 0 {
+			return true, nil
+		}
+
+		for _, condition := range job.Status.Conditions {
+			if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+				failed = true
+				return false, fmt.Errorf("%s job failed: %s", osdClusterReadyJobName, condition.Message)
+			}
+		}
+
+		log.Printf("%s job is running (active: %d, failed: %d)", osdClusterReadyJobName, job.Status.Active, job.Status.Failed)
+
+		return false, nil
+	})
+	if err == nil {
+		log.Printf("%s job succeeded", osdClusterReadyJobName)
+		return nil
+	}
+
+	if !failed {
+		err = fmt.Errorf("%s job did not succeed within %s: %v", osdClusterReadyJobName, timeout, err)
+	}
+
+	// The logs are captured using a new context as the callers context may have expired
+	logCtx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	filename, logErr := persistOSDClusterReadyLogs(logCtx, client, artifactDir)
+	if logErr != nil {
+		log.Printf("Failed to capture %s job logs: %v", osdClusterReadyJobName, logErr)
+	} else {
+		err = fmt.Errorf("%v (job logs: %s)", err, filename)
+	}
+
+	return &healthCheckError{name: osdClusterReadyJobName, err: err}
+}
+
+// persistOSDClusterReadyLogs writes the logs of the osd-cluster-ready job pods to a file in the
+// artifact directory and returns the file name
+func persistOSDClusterReadyLogs(ctx context.Context, client *openshift.Client, artifactDir string) (string, error) {
+	clientset, err := kubernetes.NewForConfig(client.GetConfig())
+	if err != nil {
+		return "", fmt.Errorf("failed to construct kubernetes client: %v", err)
+	}
+
+	pods, err := clientset.CoreV1().Pods(osdClusterReadyJobNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("job-name=%s", osdClusterReadyJobName),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list %s job pods: %v", osdClusterReadyJobName, err)
+	}
+
+	if len(pods.Items) == 0 {
+		return "", fmt.Errorf("no %s job pods found", osdClusterReadyJobName)
+	}
+
+	var content strings.Builder
+	for _, pod := range pods.Items {
+		fmt.Fprintf(&content, "==> pod %s (%s) <==\n", pod.Name, pod.Status.Phase)
+
+		logs, err := clientset.CoreV1().Pods(osdClusterReadyJobNamespace).GetLogs(pod.Name, &corev1.PodLogOptions{}).DoRaw(ctx)
+		if err != nil {
+			fmt.Fprintf(&content, "failed to get logs: %v\n", err)
+			continue
+		}
+
+		content.Write(logs)
+	}
+
+	if artifactDir != "" {
+		if err = os.MkdirAll(artifactDir, 0o755); err != nil {
+			return "", fmt.Errorf("failed to create artifact directory: %v", err)
+		}
+	}
+
+	filename := osdClusterReadyLogFilename(artifactDir, client.GetConfig().Host)
+
+	if err = os.WriteFile(filename, []byte(content.String()), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write %s job log file: %v", osdClusterReadyJobName, err)
+	}
+
+	summary.Global().Artifact(filename)
+
+	return filename, nil
+}
+
+// osdClusterReadyLogFilename returns the path of the job log file in the artifact directory, named
+// after the clusters api host so the logs of clusters checked concurrently do not overwrite each other
+func osdClusterReadyLogFilename(artifactDir, apiURL string) string {
+	host := apiURL
+	if parsed, err := url.Parse(apiURL); err == nil && parsed.Hostname() != "" {
+		host = parsed.Hostname()
+	}
+
+	return filepath.Join(artifactDir, fmt.Sprintf("%s-%s.log", strings.ReplaceAll(host, "/", "-"), osdClusterReadyJobName))
+}
@@ -0,0 +1,292 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/osde2e-framework/pkg/checks"
+	ocmclient "github.com/openshift/osde2e-framework/pkg/clients/ocm"
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Check is a health check run by Verify
+type Check string
+
+const (
+	// NodesReady checks all nodes are ready
+	NodesReady Check = "nodes-ready"
+	// ClusterOperatorsAvailable checks all cluster operators are available and not degraded
+	ClusterOperatorsAvailable Check = "cluster-operators-available"
+	// OSDClusterReadyJob checks the osd-cluster-ready job succeeded (classic clusters only)
+	OSDClusterReadyJob Check = "osd-cluster-ready"
+)
+
+// verifyPollInterval is how often failing checks are re-evaluated until the policy timeout
+const verifyPollInterval = 15 * time.Second
+
+// Policy represents which health checks Verify runs and how long they wait to pass
+type Policy struct {
+	// Checks are the health checks run, defaults to nodes-ready and cluster-operators-available
+	Checks []Check
+	// Optional are checks reported without failing the report when they fail
+	Optional []Check
+	// Timeout is how long each check waits to pass, defaults to 10 minutes
+	Timeout time.Duration
+	// OCMClient fetches the clusters kubeconfig when verifying a cluster by id
+	OCMClient *ocmclient.Client
+	// ArtifactDir is the directory the checks logs (e.g. the osd-cluster-ready job logs) are
+	// written to, defaults to the current directory
+	ArtifactDir string
+}
+
+// CheckResult represents the outcome of a health check
+type CheckResult struct {
+	Check    Check         `json:"check"`
+	Passed   bool          `json:"passed"`
+	Optional bool          `json:"optional,omitempty"`
+	Details  string        `json:"details,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Report represents the outcome of verifying a cluster
+type Report struct {
+	Cluster  string         `json:"cluster"`
+	Started  time.Time      `json:"started"`
+	Duration time.Duration  `json:"duration"`
+	Results  []*CheckResult `json:"results"`
+}
+
+// Passed returns whether all required checks passed
+func (r *Report) Passed() bool {
+	return len(r.Failed()) == 0
+}
+
+// Failed returns the required checks that failed
+func (r *Report) Failed() []*CheckResult {
+	var failed []*CheckResult
+	for _, result := range r.Results {
+		if !result.Passed && !result.Optional {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// String returns the report as a human readable checklist
+func (r *Report) String() string {
+	var builder strings.Builder
+
+	status := "PASSED"
+	if !r.Passed() {
+		status = "FAILED"
+	}
+	fmt.Fprintf(&builder, "Cluster %s verification %s (%s)\n", r.Cluster, status, r.Duration.Round(time.Second))
+
+	for _, result := range r.Results {
+		mark := "PASS"
+		if !result.Passed {
+			mark = "FAIL"
+			if result.Optional {
+				mark = "WARN"
+			}
+		}
+
+		fmt.Fprintf(&builder, "  [%s] %s (%s)", mark, result.Check, result.Duration.Round(time.Second))
+		if result.Details != "" {
+			fmt.Fprintf(&builder, ": %s", result.Details)
+		}
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
+
+// setDefaults sets the policy defaults for undefined fields
+func (p *Policy) setDefaults() {
+	if len(p.Checks) == 0 {
+		p.Checks = []Check{NodesReady, ClusterOperatorsAvailable}
+	}
+
+	if p.Timeout == 0 {
+		p.Timeout = 10 * time.Minute
+	}
+}
+
+// Verify runs the policies health checks against an existing cluster without provisioning
+// anything, identified by a kubeconfig file or an ocm cluster id (requires the policies ocm
+// client). An error is only returned when the checks could not be run, failed checks are
+// reported in the report, e.g. as a gate between provisioning and test stages
+//
+//	report, err := healthcheck.Verify(ctx, "/tmp/kubeconfig", &healthcheck.Policy{})
+//	Expect(err).ShouldNot(HaveOccurred())
+//	Expect(report.Passed()).To(BeTrue(), report.String())
+func Verify(ctx context.Context, kubeConfigOrClusterID string, policy *Policy) (*Report, error) {
+	if policy == nil {
+		policy = &Policy{}
+	}
+	policy.setDefaults()
+
+	client, err := verifyClient(ctx, kubeConfigOrClusterID, policy.OCMClient)
+	if err != nil {
+		return nil, &healthCheckError{name: "verify", err: err}
+	}
+
+	return VerifyClient(ctx, client, kubeConfigOrClusterID, policy)
+}
+
+// VerifyClient runs the policies health checks using the client, the cluster names the cluster in the report
+func VerifyClient(ctx context.Context, client *openshift.Client, cluster string, policy *Policy) (*Report, error) {
+	if policy == nil {
+		policy = &Policy{}
+	}
+	policy.setDefaults()
+
+	optional := map[Check]bool{}
+	for _, check := range policy.Optional {
+		optional[check] = true
+	}
+
+	report := &Report{Cluster: cluster, Started: time.Now()}
+
+	for _, check := range policy.Checks {
+		evaluate, ok := verifyChecks[check]
+		if !ok {
+			return nil, &healthCheckError{name: "verify", err: fmt.Errorf("unknown check %q", check)}
+		}
+
+		log.Printf("Running %s health check", check)
+
+		start := time.Now()
+		passed, details := evaluate(ctx, client, policy)
+
+		report.Results = append(report.Results, &CheckResult{
+			Check:    check,
+			Passed:   passed,
+			Optional: optional[check],
+			Details:  details,
+			Duration: time.Since(start),
+		})
+	}
+
+	report.Duration = time.Since(report.Started)
+
+	return report, nil
+}
+
+// verifyClient returns the client for the kubeconfig file, or the clusters kubeconfig fetched from ocm
+func verifyClient(ctx context.Context, kubeConfigOrClusterID string, ocmClient *ocmclient.Client) (*openshift.Client, error) {
+	if kubeConfigOrClusterID == "" {
+		return nil, fmt.Errorf("kubeconfig or cluster id is required")
+	}
+
+	kubeConfigFile := kubeConfigOrClusterID
+
+	if _, err := os.Stat(kubeConfigOrClusterID); err != nil {
+		if ocmClient == nil {
+			return nil, fmt.Errorf("%q is not a kubeconfig file and no ocm client was provided to fetch it", kubeConfigOrClusterID)
+		}
+
+		kubeConfigFile, err = ocmClient.KubeConfigFile(ctx, kubeConfigOrClusterID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return openshift.NewFromKubeconfig(kubeConfigFile)
+}
+
+// checkFunc evaluates a health check, returning whether it passed and the details when it did not
+type checkFunc func(ctx context.Context, client *openshift.Client, policy *Policy) (bool, string)
+
+// verifyChecks are the health checks supported by Verify
+var verifyChecks = map[Check]checkFunc{
+	NodesReady:                pollCheck(nodesReady),
+	ClusterOperatorsAvailable: pollCheck(clusterOperatorsAvailable),
+	OSDClusterReadyJob: func(ctx context.Context, client *openshift.Client, policy *Policy) (bool, string) {
+		if err := OSDClusterReady(ctx, client, policy.ArtifactDir, policy.Timeout); err != nil {
+			return false, err.Error()
+		}
+		return true, ""
+	},
+}
+
+// pollCheck re-evaluates the check until it passes or the timeout is reached, the details
+// of the last evaluation are returned
+func pollCheck(evaluate func(ctx context.Context, client *openshift.Client) (bool, string, error)) checkFunc {
+	return func(ctx context.Context, client *openshift.Client, policy *Policy) (bool, string) {
+		var details string
+
+		err := wait.PollUntilContextTimeout(ctx, verifyPollInterval, policy.Timeout, true, func(ctx context.Context) (bool, error) {
+			passed, reason, err := evaluate(ctx, client)
+			if err != nil {
+				details = err.Error()
+				return false, nil
+			}
+			details = reason
+			return passed, nil
+		})
+		if err != nil {
+			return false, details
+		}
+
+		return true, ""
+	}
+}
+
+// nodesReady evaluates whether all nodes are ready
+func nodesReady(ctx context.Context, client *openshift.Client) (bool, string, error) {
+	var nodes corev1.NodeList
+	if err := client.List(ctx, &nodes); err != nil {
+		return false, "", fmt.Errorf("failed to list nodes: %v", err)
+	}
+
+	ready, details := checks.NodesReady(&nodes)
+	return ready, details, nil
+}
+
+// clusterOperatorsAvailable evaluates whether all cluster operators are available and not degraded
+func clusterOperatorsAvailable(ctx context.Context, client *openshift.Client) (bool, string, error) {
+	var operators configv1.ClusterOperatorList
+	if err := client.List(ctx, &operators); err != nil {
+		return false, "", fmt.Errorf("failed to list cluster operators: %v", err)
+	}
+
+	if len(operators.Items) == 0 {
+		return false, "no cluster operators found", nil
+	}
+
+	var unhealthy []string
+	for _, operator := range operators.Items {
+		available, degraded := false, false
+		for _, condition := range operator.Status.Conditions {
+			switch condition.Type {
+			case configv1.OperatorAvailable:
+				available = condition.Status == configv1.ConditionTrue
+			case configv1.OperatorDegraded:
+				degraded = condition.Status == configv1.ConditionTrue
+			}
+		}
+
+		switch {
+		case !available:
+			unhealthy = append(unhealthy, fmt.Sprintf("%s (unavailable)", operator.Name))
+		case degraded:
+			unhealthy = append(unhealthy, fmt.Sprintf("%s (degraded)", operator.Name))
+		}
+	}
+
+	if len(unhealthy) > 0 {
+		sort.Strings(unhealthy)
+		return false, fmt.Sprintf("cluster operators not healthy: %s", strings.Join(unhealthy, ", ")), nil
+	}
+
+	return true, "", nil
+}
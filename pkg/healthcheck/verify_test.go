@@ -0,0 +1,65 @@
+package healthcheck_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/osde2e-framework/pkg/clients/kubernetesfake"
+	"github.com/openshift/osde2e-framework/pkg/healthcheck"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Verify", func() {
+	node := func(name string, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}}},
+		}
+	}
+
+	operator := func(name string, degraded configv1.ConditionStatus) *configv1.ClusterOperator {
+		return &configv1.ClusterOperator{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: configv1.ClusterOperatorStatus{Conditions: []configv1.ClusterOperatorStatusCondition{
+				{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+				{Type: configv1.OperatorDegraded, Status: degraded},
+			}},
+		}
+	}
+
+	It("should report the failed checks", func() {
+		server, err := kubernetesfake.NewServer(
+			node("worker-1", corev1.ConditionTrue),
+			node("worker-2", corev1.ConditionFalse),
+			operator("ingress", configv1.ConditionFalse),
+			operator("monitoring", configv1.ConditionTrue),
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		DeferCleanup(server.Close)
+
+		client, err := server.Client()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		report, err := healthcheck.VerifyClient(context.Background(), client, "my-cluster", &healthcheck.Policy{
+			Optional: []healthcheck.Check{healthcheck.ClusterOperatorsAvailable},
+			Timeout:  500 * time.Millisecond,
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(report.Results).To(HaveLen(2))
+		Expect(report.Passed()).To(BeFalse())
+		Expect(report.Failed()).To(HaveLen(1))
+		Expect(report.Failed()[0].Details).To(Equal("nodes not ready: worker-2"))
+		Expect(report.String()).To(ContainSubstring("[WARN] cluster-operators-available"))
+		Expect(report.String()).To(ContainSubstring("monitoring (degraded)"))
+	})
+
+	It("should reject unknown checks", func() {
+		_, err := healthcheck.VerifyClient(context.Background(), nil, "my-cluster", &healthcheck.Policy{Checks: []healthcheck.Check{"unknown"}})
+		Expect(err).Should(HaveOccurred())
+	})
+})
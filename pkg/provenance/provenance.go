@@ -0,0 +1,104 @@
+package provenance
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+)
+
+const (
+	frameworkModulePath = "github.com/openshift/osde2e-framework"
+
+	// FrameworkVersionProperty is the cluster property key holding the framework version
+	FrameworkVersionProperty = "osde2e_framework_version"
+	// GitSHAProperty is the cluster property key holding the git sha of the consuming test suite
+	GitSHAProperty = "osde2e_git_sha"
+	// JobURLProperty is the cluster property key holding the ci job url
+	JobURLProperty = "osde2e_job_url"
+)
+
+// Provenance represents where and by what a cluster was created
+type Provenance struct {
+	FrameworkVersion string
+	GitSHA           string
+	JobURL           string
+}
+
+// FromEnvironment gathers the provenance data from well known ci environment
+// variables (prow, jenkins, github actions) and the running binaries build info
+func FromEnvironment() Provenance {
+	return Provenance{
+		FrameworkVersion: frameworkVersion(),
+		GitSHA:           firstEnv("PULL_PULL_SHA", "PULL_BASE_SHA", "GIT_COMMIT", "GITHUB_SHA"),
+		JobURL:           jobURL(),
+	}
+}
+
+// FromProperties reads the provenance data from the clusters properties
+func FromProperties(properties map[string]string) Provenance {
+	return Provenance{
+		FrameworkVersion: properties[FrameworkVersionProperty],
+		GitSHA:           properties[GitSHAProperty],
+		JobURL:           properties[JobURLProperty],
+	}
+}
+
+// Properties returns the provenance data as cluster properties, omitting undefined values
+func (p Provenance) Properties() map[string]string {
+	properties := map[string]string{}
+
+	for key, value := range map[string]string{
+		FrameworkVersionProperty: p.FrameworkVersion,
+		GitSHAProperty:           p.GitSHA,
+		JobURLProperty:           p.JobURL,
+	} {
+		if value != "" {
+			properties[key] = value
+		}
+	}
+
+	return properties
+}
+
+// frameworkVersion returns the version of the framework module compiled into the running binary
+func frameworkVersion() string {
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+
+	if buildInfo.Main.Path == frameworkModulePath {
+		return buildInfo.Main.Version
+	}
+
+	for _, dependency := range buildInfo.Deps {
+		if dependency.Path == frameworkModulePath {
+			return dependency.Version
+		}
+	}
+
+	return ""
+}
+
+// jobURL returns the ci job url for the running job
+func jobURL() string {
+	if url := firstEnv("JOB_URL", "BUILD_URL"); url != "" {
+		return url
+	}
+
+	if os.Getenv("GITHUB_RUN_ID") != "" {
+		return fmt.Sprintf("%s/%s/actions/runs/%s", os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_RUN_ID"))
+	}
+
+	return ""
+}
+
+// firstEnv returns the value of the first environment variable set
+func firstEnv(keys ...string) string {
+	for _, key := range keys {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -2,8 +2,13 @@ package aws
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
+	"time"
+
+	"github.com/openshift/osde2e-framework/internal/cmd"
 )
 
 // AWSCredentials contains the data to be used to authenticate with aws
@@ -79,3 +84,43 @@ func (c *AWSCredentials) CallFuncWithCredentials(ctx context.Context, f func(ctx
 
 	return f(ctx)
 }
+
+// Expiration returns when the aws session credentials (e.g. sts assumed roles, sso) expire,
+// zero when the credentials are long lived access keys. AWS_CREDENTIAL_EXPIRATION is used
+// when set, otherwise the expiration is read from the aws cli (aws configure export-credentials)
+func (c *AWSCredentials) Expiration(ctx context.Context) (time.Time, error) {
+	if value := os.Getenv("AWS_CREDENTIAL_EXPIRATION"); value != "" {
+		expiration, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse AWS_CREDENTIAL_EXPIRATION %q: %v", value, err)
+		}
+		return expiration, nil
+	}
+
+	var credentials struct {
+		Expiration string `json:"Expiration"`
+	}
+
+	err := c.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
+		stdout, stderr, err := cmd.Run(exec.CommandContext(ctx, "aws", "configure", "export-credentials", "--format", "process"))
+		if err != nil {
+			return fmt.Errorf("aws configure export-credentials: %v: %s", err, stderr)
+		}
+
+		return json.Unmarshal([]byte(fmt.Sprint(stdout)), &credentials)
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get aws credentials expiration: %v", err)
+	}
+
+	if credentials.Expiration == "" {
+		return time.Time{}, nil
+	}
+
+	expiration, err := time.Parse(time.RFC3339, credentials.Expiration)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse aws credentials expiration %q: %v", credentials.Expiration, err)
+	}
+
+	return expiration, nil
+}
@@ -0,0 +1,86 @@
+package gcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// GCPCredentials contains the data to be used to authenticate with gcp, either a
+// service account key (e.g. osd-ccs-admin) or an ocm workload identity federation config
+type GCPCredentials struct {
+	// ServiceAccountFile is the path to the service account json key
+	ServiceAccountFile string
+	// WIFConfigID is the ocm workload identity federation config used instead of a service account key
+	WIFConfigID string
+	// ProjectID defaults to the service accounts project
+	ProjectID string
+	Region    string
+}
+
+// ServiceAccount represents a gcp service account json key
+type ServiceAccount struct {
+	Type                    string `json:"type"`
+	ProjectID               string `json:"project_id"`
+	PrivateKeyID            string `json:"private_key_id"`
+	PrivateKey              string `json:"private_key"`
+	ClientEmail             string `json:"client_email"`
+	ClientID                string `json:"client_id"`
+	AuthURI                 string `json:"auth_uri"`
+	TokenURI                string `json:"token_uri"`
+	AuthProviderX509CertURL string `json:"auth_provider_x509_cert_url"`
+	ClientX509CertURL       string `json:"client_x509_cert_url"`
+}
+
+// ValidateAndFetchCredentials validates the gcp credentials/ensures they are set
+// Data can be passed as a parameter or fetched from the environment
+// (GOOGLE_APPLICATION_CREDENTIALS, GCP_WIF_CONFIG_ID, GOOGLE_CLOUD_PROJECT, CLOUDSDK_COMPUTE_REGION)
+func (c *GCPCredentials) ValidateAndFetchCredentials() error {
+	if *c == (GCPCredentials{}) {
+		c.ServiceAccountFile = os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+		c.WIFConfigID = os.Getenv("GCP_WIF_CONFIG_ID")
+		c.ProjectID = os.Getenv("GOOGLE_CLOUD_PROJECT")
+		c.Region = os.Getenv("CLOUDSDK_COMPUTE_REGION")
+	}
+
+	if c.ServiceAccountFile == "" && c.WIFConfigID == "" {
+		return fmt.Errorf("credentials are not supplied")
+	}
+
+	if c.ServiceAccountFile != "" && c.WIFConfigID != "" {
+		return fmt.Errorf("only one of service account file or wif config id can be supplied")
+	}
+
+	if c.ProjectID == "" {
+		if c.WIFConfigID != "" {
+			return fmt.Errorf("project id is not supplied")
+		}
+
+		serviceAccount, err := c.ServiceAccount()
+		if err != nil {
+			return err
+		}
+		c.ProjectID = serviceAccount.ProjectID
+	}
+
+	if c.Region == "" {
+		return fmt.Errorf("region is not supplied")
+	}
+
+	return nil
+}
+
+// ServiceAccount returns the service account json key
+func (c *GCPCredentials) ServiceAccount() (*ServiceAccount, error) {
+	data, err := os.ReadFile(c.ServiceAccountFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account file: %v", err)
+	}
+
+	var serviceAccount ServiceAccount
+	if err = json.Unmarshal(data, &serviceAccount); err != nil {
+		return nil, fmt.Errorf("failed to parse service account file %q: %v", c.ServiceAccountFile, err)
+	}
+
+	return &serviceAccount, nil
+}
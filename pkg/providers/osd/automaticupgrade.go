@@ -0,0 +1,95 @@
+package osd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+)
+
+// upgradeAtTolerance is how far the upgrade config upgrade at time may differ from the upgrade policies next run
+const upgradeAtTolerance = time.Minute
+
+// VerifyAutomaticUpgradeSchedule verifies the managed upgrade operator picked up the clusters automatic
+// upgrade policy, waiting for the upgrade config to be scheduled at the policies next run (and to the
+// policies version when ocm resolved it)
+//
+//	policyID, err := provider.ScheduleUpgrade(ctx, clusterID, current, upgrade, &UpgradeOptions{ScheduleType: "automatic", Schedule: "0 2 * * *"})
+//	Expect(err).ShouldNot(HaveOccurred())
+//	Expect(provider.VerifyAutomaticUpgradeSchedule(ctx, client, clusterID, policyID, 10*time.Minute)).To(Succeed())
+func (o *Provider) VerifyAutomaticUpgradeSchedule(ctx context.Context, client *openshift.Client, clusterID, policyID string, timeout time.Duration) error {
+	response, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).
+		UpgradePolicies().UpgradePolicy(policyID).Get().SendContext(ctx)
+	if err != nil {
+		return &upgradeError{err: fmt.Errorf("failed to get cluster %q upgrade policy %q: %v", clusterID, policyID, err)}
+	}
+
+	upgradePolicy := response.Body()
+	if upgradePolicy.ScheduleType() != automaticScheduleType {
+		return &upgradeError{err: fmt.Errorf("upgrade policy %q schedule type is %q, not %s", policyID, upgradePolicy.ScheduleType(), automaticScheduleType)}
+	}
+
+	if upgradePolicy.NextRun().IsZero() {
+		return &upgradeError{err: fmt.Errorf("upgrade policy %q with schedule %q has no next run", policyID, upgradePolicy.Schedule())}
+	}
+
+	dynamicClient, err := getKubernetesDynamicClient(client)
+	if err != nil {
+		return &upgradeError{err: err}
+	}
+
+	log.Printf("Waiting for the managed upgrade operator to schedule the upgrade for %s (schedule %q)",
+		upgradePolicy.NextRun().UTC().Format(time.RFC3339), upgradePolicy.Schedule())
+
+	if err = waitForScheduledUpgradeConfig(ctx, dynamicClient, upgradePolicy.NextRun(), upgradePolicy.Version(), timeout); err != nil {
+		return &upgradeError{err: err}
+	}
+
+	return nil
+}
+
+// waitForScheduledUpgradeConfig waits for the upgrade config to be scheduled at the time and to the version when provided
+func waitForScheduledUpgradeConfig(ctx context.Context, dynamicClient dynamic.Interface, upgradeAt time.Time, version string, timeout time.Duration) error {
+	var lastErr error
+
+	err := wait.PollUntilContextTimeout(ctx, upgradeConfigPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		upgradeConfig, err := getManagedUpgradeOperatorConfig(ctx, dynamicClient)
+		if err != nil || upgradeConfig == nil {
+			log.Printf("Failed to get managed upgrade operator config: %v", err)
+			return false, nil
+		}
+
+		lastErr = upgradeConfigScheduled(upgradeConfig, upgradeAt, version)
+		return lastErr == nil, nil
+	})
+	if err != nil {
+		if lastErr != nil {
+			return fmt.Errorf("managed upgrade operator did not schedule the upgrade (%v): %v", lastErr, err)
+		}
+		return fmt.Errorf("managed upgrade operator did not schedule the upgrade: %v", err)
+	}
+
+	return nil
+}
+
+// upgradeConfigScheduled returns an error when the upgrade config is not scheduled at the time and to the version when provided
+func upgradeConfigScheduled(upgradeConfig *UpgradeConfig, upgradeAt time.Time, version string) error {
+	scheduled, err := time.Parse(time.RFC3339, upgradeConfig.Spec.UpgradeAt)
+	if err != nil {
+		return fmt.Errorf("upgrade config upgrade at %q is invalid: %v", upgradeConfig.Spec.UpgradeAt, err)
+	}
+
+	if difference := scheduled.Sub(upgradeAt); difference < -upgradeAtTolerance || difference > upgradeAtTolerance {
+		return fmt.Errorf("upgrade config is scheduled at %s, expected %s", scheduled.UTC().Format(time.RFC3339), upgradeAt.UTC().Format(time.RFC3339))
+	}
+
+	if version != "" && upgradeConfig.Spec.Desired.Version != version {
+		return fmt.Errorf("upgrade config desires version %q, expected %q", upgradeConfig.Spec.Desired.Version, version)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package osd
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var _ = Describe("Automatic upgrades", func() {
+	nextRun := time.Date(2023, 11, 2, 2, 0, 0, 0, time.UTC)
+
+	scheduledUpgradeConfig := func(upgradeAt, version string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "upgrade.managed.openshift.io/v1alpha1",
+			"kind":       "UpgradeConfig",
+			"metadata":   map[string]interface{}{"name": "managed-upgrade-config", "namespace": managedUpgradeOperatorNamespace},
+			"spec": map[string]interface{}{
+				"type":      "OSD",
+				"upgradeAt": upgradeAt,
+				"desired":   map[string]interface{}{"version": version, "channel": "stable-4.14"},
+			},
+		}}
+	}
+
+	It("should require a cron schedule", func() {
+		Expect((&UpgradeOptions{ScheduleType: "automatic", Schedule: "*/30 2-4 * * 1,3"}).validate()).To(Succeed())
+		Expect((&UpgradeOptions{ScheduleType: "automatic", Schedule: "0 2 * *"}).validate()).ToNot(Succeed())
+		Expect((&UpgradeOptions{ScheduleType: "automatic", Schedule: "daily"}).validate()).ToNot(Succeed())
+	})
+
+	It("should verify the upgrade config is scheduled at the next run", func() {
+		Expect(upgradeConfigScheduled(mustNewUpgradeConfig(scheduledUpgradeConfig("2023-11-02T02:00:00Z", "4.14.2")), nextRun, "4.14.2")).To(Succeed())
+		Expect(upgradeConfigScheduled(mustNewUpgradeConfig(scheduledUpgradeConfig("2023-11-02T02:00:30Z", "4.14.2")), nextRun, "")).To(Succeed())
+		Expect(upgradeConfigScheduled(mustNewUpgradeConfig(scheduledUpgradeConfig("2023-11-03T02:00:00Z", "4.14.2")), nextRun, "4.14.2")).
+			To(MatchError(ContainSubstring("expected 2023-11-02T02:00:00Z")))
+		Expect(upgradeConfigScheduled(mustNewUpgradeConfig(scheduledUpgradeConfig("2023-11-02T02:00:00Z", "4.14.1")), nextRun, "4.14.2")).
+			To(MatchError(ContainSubstring(`expected "4.14.2"`)))
+		Expect(upgradeConfigScheduled(mustNewUpgradeConfig(scheduledUpgradeConfig("", "4.14.2")), nextRun, "4.14.2")).ToNot(Succeed())
+	})
+
+	It("should wait for the managed upgrade operator to schedule the upgrade", func(ctx context.Context) {
+		dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			upgradeConfigResource: "UpgradeConfigList",
+		}, scheduledUpgradeConfig("2023-11-02T02:00:00Z", "4.14.2"))
+
+		Expect(waitForScheduledUpgradeConfig(ctx, dynamicClient, nextRun, "4.14.2", time.Second)).To(Succeed())
+		Expect(waitForScheduledUpgradeConfig(ctx, dynamicClient, nextRun.Add(24*time.Hour), "4.14.2", time.Second)).
+			To(MatchError(ContainSubstring("did not schedule the upgrade")))
+	})
+})
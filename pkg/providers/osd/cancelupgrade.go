@@ -0,0 +1,110 @@
+package osd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+)
+
+// CancelUpgrade cancels the clusters pending upgrade by deleting its upgrade policies that have not
+// started and waits for the managed upgrade operator to acknowledge the cancellation by no longer
+// scheduling the upgrade. Upgrades that already started cannot be cancelled and return an error
+func (o *Provider) CancelUpgrade(ctx context.Context, client *openshift.Client, clusterID string, timeout time.Duration) error {
+	cancelled, err := o.cancelUpgradePolicies(ctx, clusterID)
+	if err != nil {
+		return &upgradeError{err: err}
+	}
+
+	dynamicClient, err := getKubernetesDynamicClient(client)
+	if err != nil {
+		return &upgradeError{err: err}
+	}
+
+	if err = waitForUpgradeCancelled(ctx, dynamicClient, cancelled, timeout); err != nil {
+		return &upgradeError{err: err}
+	}
+
+	log.Printf("Cluster id %q upgrade has been cancelled", clusterID)
+
+	return nil
+}
+
+// cancelUpgradePolicies deletes the clusters pending and scheduled upgrade policies returning them
+func (o *Provider) cancelUpgradePolicies(ctx context.Context, clusterID string) ([]*clustersmgmtv1.UpgradePolicy, error) {
+	upgradePolicies := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).UpgradePolicies()
+
+	response, err := upgradePolicies.List().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster %q upgrade policies: %v", clusterID, err)
+	}
+
+	var cancelled []*clustersmgmtv1.UpgradePolicy
+
+	for _, upgradePolicy := range response.Items().Slice() {
+		status, err := o.UpgradePolicyStatus(ctx, clusterID, upgradePolicy.ID())
+		if err != nil {
+			return nil, err
+		}
+
+		switch status.State {
+		case UpgradePolicyPending, UpgradePolicyScheduled:
+		case UpgradePolicyStarted, UpgradePolicyDelayed:
+			return nil, fmt.Errorf("cluster %q upgrade to %q already started and cannot be cancelled", clusterID, upgradePolicy.Version())
+		default:
+			continue
+		}
+
+		if _, err = upgradePolicies.UpgradePolicy(upgradePolicy.ID()).Delete().SendContext(ctx); err != nil {
+			return nil, fmt.Errorf("failed to delete cluster %q upgrade policy %q: %v", clusterID, upgradePolicy.ID(), err)
+		}
+
+		log.Printf("Cluster id %q upgrade policy %q (version %q) deleted", clusterID, upgradePolicy.ID(), upgradePolicy.Version())
+		cancelled = append(cancelled, upgradePolicy)
+	}
+
+	if len(cancelled) == 0 {
+		return nil, fmt.Errorf("cluster %q has no pending upgrade to cancel", clusterID)
+	}
+
+	return cancelled, nil
+}
+
+// waitForUpgradeCancelled waits for the upgrade config to be removed or no longer scheduled for any of the upgrade policies
+func waitForUpgradeCancelled(ctx context.Context, dynamicClient dynamic.Interface, upgradePolicies []*clustersmgmtv1.UpgradePolicy, timeout time.Duration) error {
+	err := wait.PollUntilContextTimeout(ctx, upgradeConfigPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		upgradeConfig, err := getManagedUpgradeOperatorConfig(ctx, dynamicClient)
+		if err != nil {
+			log.Printf("Failed to get managed upgrade operator config: %v", err)
+			return false, nil
+		}
+
+		if upgradeConfig == nil {
+			return true, nil
+		}
+
+		for _, upgradePolicy := range upgradePolicies {
+			if upgradeConfigScheduled(upgradeConfig, upgradePolicy.NextRun(), upgradePolicy.Version()) != nil {
+				continue
+			}
+
+			if phase := upgradeConfigProgress(upgradeConfig, upgradePolicy.Version()).Phase; phase == "Upgrading" || phase == "Upgraded" {
+				return false, fmt.Errorf("upgrade to %q started before it was cancelled", upgradePolicy.Version())
+			}
+
+			return false, nil
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("managed upgrade operator did not acknowledge the cancellation: %v", err)
+	}
+
+	return nil
+}
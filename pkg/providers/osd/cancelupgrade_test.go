@@ -0,0 +1,107 @@
+package osd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osde2e-framework/pkg/clients/ocmfake"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var _ = Describe("Cancel upgrade", func() {
+	var (
+		provider *Provider
+		server   *ocmfake.Server
+		deleted  []string
+	)
+
+	handleUpgradePolicies := func(states map[string]string) {
+		server.Handle(http.MethodGet, "/api/clusters_mgmt/v1/clusters/abc/upgrade_policies", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"kind":"UpgradePolicyList","page":1,"size":2,"total":2,"items":[
+				{"id":"completed","version":"4.14.1","schedule_type":"manual","next_run":"2023-11-01T02:00:00Z"},
+				{"id":"pending","version":"4.14.2","schedule_type":"manual","next_run":"2023-11-02T02:00:00Z"}]}`)
+		})
+		for id, state := range states {
+			state := state
+			server.Handle(http.MethodGet, "/api/clusters_mgmt/v1/clusters/abc/upgrade_policies/"+id+"/state", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"value":%q}`, state)
+			})
+		}
+		server.Handle(http.MethodDelete, "/api/clusters_mgmt/v1/clusters/abc/upgrade_policies/pending", func(w http.ResponseWriter, r *http.Request) {
+			deleted = append(deleted, "pending")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+
+	BeforeEach(func(ctx context.Context) {
+		deleted = nil
+
+		server = ocmfake.NewServer()
+		DeferCleanup(server.Close)
+
+		client, err := server.Client(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		provider = &Provider{Client: client}
+	})
+
+	It("should delete the pending upgrade policies", func(ctx context.Context) {
+		handleUpgradePolicies(map[string]string{"completed": "completed", "pending": "scheduled"})
+
+		cancelled, err := provider.cancelUpgradePolicies(ctx, "abc")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cancelled).To(HaveLen(1))
+		Expect(cancelled[0].Version()).To(Equal("4.14.2"))
+		Expect(deleted).To(Equal([]string{"pending"}))
+	})
+
+	It("should fail when the upgrade already started", func(ctx context.Context) {
+		handleUpgradePolicies(map[string]string{"completed": "completed", "pending": "started"})
+
+		_, err := provider.cancelUpgradePolicies(ctx, "abc")
+		Expect(err).To(MatchError(ContainSubstring("already started")))
+		Expect(deleted).To(BeEmpty())
+	})
+
+	It("should fail when there is no pending upgrade", func(ctx context.Context) {
+		handleUpgradePolicies(map[string]string{"completed": "completed", "pending": "cancelled"})
+
+		_, err := provider.cancelUpgradePolicies(ctx, "abc")
+		Expect(err).To(MatchError(ContainSubstring("no pending upgrade")))
+	})
+
+	It("should wait for the managed upgrade operator to unschedule the upgrade", func(ctx context.Context) {
+		upgradePolicy, err := clustersmgmtv1.NewUpgradePolicy().ID("pending").Version("4.14.2").
+			NextRun(time.Date(2023, 11, 2, 2, 0, 0, 0, time.UTC)).Build()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		newDynamicClient := func(upgradeAt string) *dynamicfake.FakeDynamicClient {
+			return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				upgradeConfigResource: "UpgradeConfigList",
+			}, &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "upgrade.managed.openshift.io/v1alpha1",
+				"kind":       "UpgradeConfig",
+				"metadata":   map[string]interface{}{"name": "managed-upgrade-config", "namespace": managedUpgradeOperatorNamespace},
+				"spec": map[string]interface{}{
+					"upgradeAt": upgradeAt,
+					"desired":   map[string]interface{}{"version": "4.14.2"},
+				},
+			}})
+		}
+
+		Expect(waitForUpgradeCancelled(ctx, newDynamicClient("2023-11-09T02:00:00Z"), []*clustersmgmtv1.UpgradePolicy{upgradePolicy}, time.Second)).To(Succeed())
+		Expect(waitForUpgradeCancelled(ctx, newDynamicClient("2023-11-02T02:00:00Z"), []*clustersmgmtv1.UpgradePolicy{upgradePolicy}, time.Second)).
+			To(MatchError(ContainSubstring("did not acknowledge the cancellation")))
+	})
+})
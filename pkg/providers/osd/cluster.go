@@ -1,9 +1,477 @@
 package osd
 
-func (r *Provider) CreateCluster() error {
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmclient "github.com/openshift/osde2e-framework/pkg/clients/ocm"
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+	"github.com/openshift/osde2e-framework/pkg/healthcheck"
+	awscloud "github.com/openshift/osde2e-framework/pkg/providers/clouds/aws"
+	gcpcloud "github.com/openshift/osde2e-framework/pkg/providers/clouds/gcp"
+	"github.com/openshift/osde2e-framework/pkg/summary"
+)
+
+const (
+	defaultComputeMachineType    = "m5.xlarge"
+	defaultGCPComputeMachineType = "custom-4-16384"
+	clusterReadyPollInterval     = time.Minute
+	clusterReadyTimeout          = 2 * time.Hour
+	clusterDeletedPollInterval   = time.Minute
+	clusterDeletedTimeout        = time.Hour
+)
+
+// CreateClusterOptions represents data used to create customer cloud subscription "ccs"
+// osd clusters on aws, or on gcp when GCPCredentials are set
+type CreateClusterOptions struct {
+	ClusterName string
+	// ChannelGroup is the channel group the version is resolved from, defaults to stable
+	ChannelGroup string
+	// Version defaults to the channel groups default version
+	Version string
+	// Region defaults to the aws or gcp credentials region
+	Region string
+	// MultiAZ spreads the control plane and compute nodes across three availability zones
+	MultiAZ bool
+	// ComputeMachineType defaults to m5.xlarge (aws) or custom-4-16384 (gcp)
+	ComputeMachineType string
+	// ComputeNodes defaults to 2, or 3 for multi-az clusters
+	ComputeNodes int
+
+	// AWSAccountID is the aws account the cluster is installed into
+	AWSAccountID string
+	// AWSCredentials are the access keys of the accounts osdCcsAdmin iam user ocm installs with
+	AWSCredentials *awscloud.AWSCredentials
+
+	// GCPCredentials are the service account key or workload identity federation config
+	// ocm installs gcp clusters with, the aws options are ignored when set
+	GCPCredentials *gcpcloud.GCPCredentials
+
+	// SkipHealthChecks returns once the cluster is ready without running the osd-cluster-ready health check
+	SkipHealthChecks bool
+}
+
+// DeleteClusterOptions represents data used to delete osd clusters
+type DeleteClusterOptions struct {
+	ClusterID string
+	// Timeout is how long to wait for the cluster to be removed from ocm, defaults to 1 hour
+	Timeout time.Duration
+	// SkipWait returns once the delete request is accepted without waiting for the cluster to be removed
+	SkipWait bool
+	// DeprovisionLogs writes the clusters uninstall log to the artifact directory once
+	// the cluster is removed or fails to be removed
+	DeprovisionLogs bool
+}
+
+// clusterError represents the custom error
+type clusterError struct {
+	action string
+	err    error
+}
+
+// Error returns the formatted error message when clusterError is invoked
+func (c *clusterError) Error() string {
+	return fmt.Sprintf("%s cluster failed: %v", c.action, c.err)
+}
+
+// setDefaults sets the cluster option defaults for undefined fields
+func (c *CreateClusterOptions) setDefaults() {
+	if c.ChannelGroup == "" {
+		c.ChannelGroup = "stable"
+	}
+
+	if c.Region == "" {
+		switch {
+		case c.GCPCredentials != nil:
+			c.Region = c.GCPCredentials.Region
+		case c.AWSCredentials != nil:
+			c.Region = c.AWSCredentials.Region
+		}
+	}
+
+	if c.ComputeMachineType == "" {
+		c.ComputeMachineType = defaultComputeMachineType
+		if c.GCPCredentials != nil {
+			c.ComputeMachineType = defaultGCPComputeMachineType
+		}
+	}
+
+	if c.ComputeNodes == 0 {
+		c.ComputeNodes = 2
+		if c.MultiAZ {
+			c.ComputeNodes = 3
+		}
+	}
+}
+
+// validate verifies the cluster options required for ccs clusters are set
+func (c *CreateClusterOptions) validate() error {
+	if c.GCPCredentials != nil {
+		switch {
+		case c.ClusterName == "":
+			return fmt.Errorf("cluster name is required")
+		case c.MultiAZ && c.ComputeNodes%3 != 0:
+			return fmt.Errorf("compute nodes must be a multiple of 3 for multi az clusters")
+		}
+
+		if err := c.GCPCredentials.ValidateAndFetchCredentials(); err != nil {
+			return fmt.Errorf("gcp authentication data check failed: %v", err)
+		}
+
+		if c.Region == "" {
+			c.Region = c.GCPCredentials.Region
+		}
+
+		return nil
+	}
+
+	switch {
+	case c.ClusterName == "":
+		return fmt.Errorf("cluster name is required")
+	case c.AWSAccountID == "":
+		return fmt.Errorf("aws account id is required")
+	case c.AWSCredentials == nil || c.AWSCredentials.AccessKeyID == "" || c.AWSCredentials.SecretAccessKey == "":
+		return fmt.Errorf("aws access key id and secret access key are required")
+	case c.Region == "":
+		return fmt.Errorf("region is required")
+	case c.MultiAZ && c.ComputeNodes%3 != 0:
+		return fmt.Errorf("compute nodes must be a multiple of 3 for multi az clusters")
+	}
+
+	return nil
+}
+
+// CreateCluster creates an osd ccs cluster on aws or gcp using the provided inputs and waits
+// for it to be ready and healthy, returning the cluster id
+func (o *Provider) CreateCluster(ctx context.Context, options *CreateClusterOptions) (string, error) {
+	const action = "create"
+
+	var clusterID string
+	err := o.runPhase("create", "", options.ClusterName, func() (err error) {
+		clusterID, err = o.CreateClusterAsync(ctx, options)
+		return err
+	})
+	if err != nil {
+		return clusterID, err
+	}
+
+	summary.Global().ClusterCreated(clusterID, options.ClusterName)
+
+	var kubeConfigFile string
+	err = o.runPhase("install", clusterID, options.ClusterName, func() (err error) {
+		kubeConfigFile, err = o.WaitForClusterReady(ctx, clusterID, clusterReadyTimeout)
+		return err
+	})
+	if err != nil || options.SkipHealthChecks {
+		return clusterID, err
+	}
+
+	err = o.runPhase("health checks", clusterID, options.ClusterName, func() error {
+		client, err := openshift.NewFromKubeconfig(kubeConfigFile)
+		if err != nil {
+			return fmt.Errorf("failed to construct openshift client: %v", err)
+		}
+		return healthcheck.OSDClusterReady(ctx, client, o.ArtifactDir, 45*time.Minute)
+	})
+	if err != nil {
+		return clusterID, &clusterError{action: action, err: err}
+	}
+
+	return clusterID, nil
+}
+
+// CreateClusterAsync sends the request to create the osd ccs cluster on aws or gcp and returns
+// the cluster id once accepted without waiting for the cluster to be ready
+func (o *Provider) CreateClusterAsync(ctx context.Context, options *CreateClusterOptions) (string, error) {
+	const action = "create"
+
+	options.setDefaults()
+	if err := options.validate(); err != nil {
+		return "", &clusterError{action: action, err: fmt.Errorf("cluster options validation failed: %v", err)}
+	}
+
+	if options.Version == "" {
+		version, err := o.defaultVersion(ctx, options.ChannelGroup)
+		if err != nil {
+			return "", &clusterError{action: action, err: err}
+		}
+		options.Version = version
+	}
+
+	if err := o.CheckQuota(ctx, clusterQuotaResource(options), 1); err != nil {
+		return "", &clusterError{action: action, err: fmt.Errorf("quota pre-flight check failed: %v", err)}
+	}
+
+	body, err := buildClusterBody(options)
+	if err != nil {
+		return "", &clusterError{action: action, err: err}
+	}
+
+	log.Printf("Creating osd cluster %q (version=%s, region=%s)", options.ClusterName, options.Version, options.Region)
+
+	response, err := o.Connection.Post().Path("/api/clusters_mgmt/v1/clusters").Bytes(body).SendContext(ctx)
+	if err != nil {
+		return "", &clusterError{action: action, err: fmt.Errorf("failed to send create cluster request: %v", err)}
+	}
+
+	if response.Status() != http.StatusCreated {
+		return "", &clusterError{action: action, err: fmt.Errorf("create cluster request failed with status %d: %s", response.Status(), response.String())}
+	}
+
+	cluster, err := clustersmgmtv1.UnmarshalCluster(response.Bytes())
+	if err != nil {
+		return "", &clusterError{action: action, err: fmt.Errorf("failed to parse create cluster response: %v", err)}
+	}
+
+	log.Printf("Cluster %q created (id=%s)", options.ClusterName, cluster.ID())
+
+	return cluster.ID(), nil
+}
+
+// clusterQuotaResource returns the quota resource the ccs cluster consumes
+func clusterQuotaResource(options *CreateClusterOptions) ocmclient.QuotaResource {
+	resourceType := "cluster.aws"
+	if options.GCPCredentials != nil {
+		resourceType = "cluster.gcp"
+	}
+	return ocmclient.QuotaResource{Product: "OSD", ResourceType: resourceType, BYOC: "byoc"}
+}
+
+// buildClusterBody builds the ocm cluster request body from the cluster options
+func buildClusterBody(options *CreateClusterOptions) ([]byte, error) {
+	versionID := options.Version
+	if !strings.HasPrefix(versionID, "openshift-v") {
+		versionID = fmt.Sprintf("openshift-v%s", versionID)
+		if options.ChannelGroup != "stable" {
+			versionID = fmt.Sprintf("%s-%s", versionID, options.ChannelGroup)
+		}
+	}
+
+	clusterBuilder := clustersmgmtv1.NewCluster().
+		Name(options.ClusterName).
+		Product(clustersmgmtv1.NewProduct().ID("osd")).
+		Region(clustersmgmtv1.NewCloudRegion().ID(options.Region)).
+		Version(clustersmgmtv1.NewVersion().ID(versionID).ChannelGroup(options.ChannelGroup)).
+		CCS(clustersmgmtv1.NewCCS().Enabled(true)).
+		MultiAZ(options.MultiAZ).
+		Nodes(clustersmgmtv1.NewClusterNodes().
+			ComputeMachineType(clustersmgmtv1.NewMachineType().ID(options.ComputeMachineType)).
+			Compute(options.ComputeNodes))
+
+	gcp := options.GCPCredentials
+	switch {
+	case gcp != nil && gcp.WIFConfigID != "":
+		clusterBuilder = clusterBuilder.
+			CloudProvider(clustersmgmtv1.NewCloudProvider().ID("gcp")).
+			GCP(clustersmgmtv1.NewGCP().ProjectID(gcp.ProjectID))
+	case gcp != nil:
+		serviceAccount, err := gcp.ServiceAccount()
+		if err != nil {
+			return nil, err
+		}
+
+		clusterBuilder = clusterBuilder.
+			CloudProvider(clustersmgmtv1.NewCloudProvider().ID("gcp")).
+			GCP(clustersmgmtv1.NewGCP().
+				Type(serviceAccount.Type).
+				ProjectID(gcp.ProjectID).
+				PrivateKeyID(serviceAccount.PrivateKeyID).
+				PrivateKey(serviceAccount.PrivateKey).
+				ClientEmail(serviceAccount.ClientEmail).
+				ClientID(serviceAccount.ClientID).
+				AuthURI(serviceAccount.AuthURI).
+				TokenURI(serviceAccount.TokenURI).
+				AuthProviderX509CertURL(serviceAccount.AuthProviderX509CertURL).
+				ClientX509CertURL(serviceAccount.ClientX509CertURL))
+	default:
+		clusterBuilder = clusterBuilder.
+			CloudProvider(clustersmgmtv1.NewCloudProvider().ID("aws")).
+			AWS(clustersmgmtv1.NewAWS().
+				AccountID(options.AWSAccountID).
+				AccessKeyID(options.AWSCredentials.AccessKeyID).
+				SecretAccessKey(options.AWSCredentials.SecretAccessKey))
+	}
+
+	cluster, err := clusterBuilder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build cluster: %v", err)
+	}
+
+	var buffer bytes.Buffer
+	if err = clustersmgmtv1.MarshalCluster(cluster, &buffer); err != nil {
+		return nil, fmt.Errorf("failed to marshal cluster: %v", err)
+	}
+
+	if gcp == nil || gcp.WIFConfigID == "" {
+		return buffer.Bytes(), nil
+	}
+
+	// The workload identity federation authentication is not available in the ocm sdk cluster type
+	body := map[string]any{}
+	if err = json.Unmarshal(buffer.Bytes(), &body); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cluster: %v", err)
+	}
+
+	gcpBody, _ := body["gcp"].(map[string]any)
+	gcpBody["authentication"] = map[string]any{"kind": "WifConfig", "id": gcp.WIFConfigID}
+
+	return json.Marshal(body)
+}
+
+// defaultVersion returns the channel groups default openshift version
+func (o *Provider) defaultVersion(ctx context.Context, channelGroup string) (string, error) {
+	versions, err := o.Versions(ctx, channelGroup)
+	if err != nil {
+		return "", err
+	}
+
+	version := versions.Default()
+	if version == nil {
+		return "", fmt.Errorf("channel group %q has no default version", versions.ChannelGroup)
+	}
+
+	log.Printf("Resolved cluster version to %q from channel group %q", version.ID, versions.ChannelGroup)
+
+	return version.ID, nil
+}
+
+// WaitForClusterReady waits for the cluster to be in a ready state and returns its kubeconfig file
+func (o *Provider) WaitForClusterReady(ctx context.Context, clusterID string, timeout time.Duration) (string, error) {
+	const action = "create"
+
+	err := o.WaitForClusterStateWithOptions(ctx, clusterID, clustersmgmtv1.ClusterStateReady, &ocmclient.ClusterStateOptions{
+		Timeout:       timeout,
+		PollInterval:  clusterReadyPollInterval,
+		OnStateChange: o.stateChangeHandler(clusterID, ""),
+	})
+	if err != nil {
+		return "", &clusterError{action: action, err: err}
+	}
+
+	kubeConfigFile, err := o.KubeConfigFile(ctx, clusterID)
+	if err != nil {
+		return "", &clusterError{action: action, err: err}
+	}
+
+	return kubeConfigFile, nil
+}
+
+// DeleteCluster deletes the osd cluster and waits for it to be removed from ocm
+func (o *Provider) DeleteCluster(ctx context.Context, options *DeleteClusterOptions) error {
+	const action = "delete"
+
+	if options.ClusterID == "" {
+		return &clusterError{action: action, err: fmt.Errorf("cluster id is required")}
+	}
+
+	if options.Timeout == 0 {
+		options.Timeout = clusterDeletedTimeout
+	}
+
+	clusterID := options.ClusterID
+
+	response, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().SendContext(ctx)
+	if err != nil {
+		return &clusterError{action: action, err: fmt.Errorf("failed to get cluster %q: %v", clusterID, err)}
+	}
+	clusterName := response.Body().Name()
+
+	err = o.runPhase("delete", clusterID, clusterName, func() error {
+		_, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).Delete().SendContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to delete cluster %q: %v", clusterID, err)
+		}
+
+		log.Printf("Cluster %q deletion requested", clusterID)
+
+		if options.SkipWait {
+			return nil
+		}
+
+		return o.waitForClusterDeleted(ctx, clusterID, clusterName, options)
+	})
+	if err != nil {
+		return &clusterError{action: action, err: err}
+	}
+
+	summary.Global().ClusterDeleted(clusterID, clusterName)
+
 	return nil
 }
 
-func (r *Provider) DeleteCluster() error {
+// waitForClusterDeleted waits for the cluster to be removed from ocm, capturing its uninstall log when requested
+func (o *Provider) waitForClusterDeleted(ctx context.Context, clusterID, clusterName string, options *DeleteClusterOptions) error {
+	var uninstallLog string
+
+	if options.DeprovisionLogs {
+		defer func() {
+			if uninstallLog == "" {
+				return
+			}
+			if filename, err := o.writeUninstallLog(clusterID, uninstallLog); err != nil {
+				log.Println(err)
+			} else {
+				log.Printf("Cluster %q uninstall log written to %s", clusterID, filename)
+			}
+		}()
+	}
+
+	err := o.WaitForClusterStateWithOptions(ctx, clusterID, ocmclient.ClusterStateDeleted, &ocmclient.ClusterStateOptions{
+		Timeout:       options.Timeout,
+		PollInterval:  clusterDeletedPollInterval,
+		OnStateChange: o.stateChangeHandler(clusterID, clusterName),
+		OnPoll: func(ctx context.Context, state clustersmgmtv1.ClusterState) error {
+			if !options.DeprovisionLogs {
+				return nil
+			}
+			response, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).Logs().Uninstall().Get().SendContext(ctx)
+			// Logs are unavailable until the uninstaller starts and once the cluster is removed
+			if err == nil && response.Body().Content() != "" {
+				uninstallLog = response.Body().Content()
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("cluster %q failed to finish uninstalling: %v", clusterID, err)
+	}
+
 	return nil
 }
+
+// writeUninstallLog writes the clusters uninstall log to the artifact directory and returns the file name
+func (o *Provider) writeUninstallLog(clusterID, content string) (string, error) {
+	filename := filepath.Join(o.ArtifactDir, fmt.Sprintf("%s-uninstall.log", clusterID))
+
+	if o.ArtifactDir != "" {
+		if err := os.MkdirAll(o.ArtifactDir, 0o755); err != nil {
+			return filename, fmt.Errorf("failed to create artifact directory: %v", err)
+		}
+	}
+
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		return filename, fmt.Errorf("failed to write uninstall log file: %v", err)
+	}
+
+	summary.Global().Artifact(filename)
+
+	return filename, nil
+}
+
+// runPhase runs the provisioning phase, recording it in the run summary and notifying the event hooks
+func (o *Provider) runPhase(name, clusterID, clusterName string, phase func() error) error {
+	start := o.events.PhaseStart(name, clusterID, clusterName)
+	err := phase()
+	summary.Global().Phase(name, clusterName, start, err)
+	o.events.PhaseEnd(name, clusterID, clusterName, start, err)
+	return err
+}
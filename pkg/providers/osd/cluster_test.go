@@ -0,0 +1,152 @@
+package osd
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osde2e-framework/pkg/clients/ocmfake"
+	"github.com/openshift/osde2e-framework/pkg/events"
+	"github.com/openshift/osde2e-framework/pkg/featureflags"
+	awscloud "github.com/openshift/osde2e-framework/pkg/providers/clouds/aws"
+	gcpcloud "github.com/openshift/osde2e-framework/pkg/providers/clouds/gcp"
+)
+
+var _ = Describe("Cluster", func() {
+	var (
+		server    *ocmfake.Server
+		provider  *Provider
+		quotaCost string
+	)
+
+	BeforeEach(func(ctx context.Context) {
+		server = ocmfake.NewServer()
+		DeferCleanup(server.Close)
+
+		client, err := server.Client(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		client.ArtifactDir = GinkgoT().TempDir()
+		provider = &Provider{Client: client}
+
+		quotaCost = `{"kind":"QuotaCostList","page":1,"size":1,"total":1,"items":[{"quota_id":"cluster|byoc|osd","allowed":0,"consumed":0,
+			"related_resources":[{"product":"OSD","resource_type":"cluster.aws","byoc":"byoc","cost":0}]}]}`
+
+		server.Handle(http.MethodGet, "/api/accounts_mgmt/v1/current_account", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"kind":"Account","id":"abc","organization":{"id":"org"}}`))
+		})
+		server.Handle(http.MethodGet, "/api/accounts_mgmt/v1/organizations/org/quota_cost", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(quotaCost))
+		})
+	})
+
+	It("should create a ccs cluster using the channel groups default version", func(ctx context.Context) {
+		server.Handle(http.MethodGet, "/api/clusters_mgmt/v1/versions", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"kind":"VersionList","page":1,"size":2,"total":2,"items":[
+				{"kind":"Version","id":"openshift-v4.13.4","raw_id":"4.13.4","channel_group":"stable","default":true},
+				{"kind":"Version","id":"openshift-v4.14.1","raw_id":"4.14.1","channel_group":"stable"}]}`))
+		})
+
+		clusterID, err := provider.CreateClusterAsync(ctx, &CreateClusterOptions{
+			ClusterName:    "my-cluster",
+			MultiAZ:        true,
+			AWSAccountID:   "123456789012",
+			AWSCredentials: &awscloud.AWSCredentials{AccessKeyID: "key", SecretAccessKey: "secret", Region: "us-east-1"},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		response, err := provider.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().SendContext(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		cluster := response.Body()
+		Expect(cluster.Version().ID()).To(Equal("openshift-v4.13.4"))
+		Expect(cluster.Region().ID()).To(Equal("us-east-1"))
+		Expect(cluster.AWS().AccountID()).To(Equal("123456789012"))
+		Expect(cluster.Nodes().Compute()).To(Equal(3))
+	})
+
+	It("should create a gcp ccs cluster using a wif config", func(ctx context.Context) {
+		clusterID, err := provider.CreateClusterAsync(ctx, &CreateClusterOptions{
+			ClusterName:    "my-cluster",
+			Version:        "4.13.4",
+			GCPCredentials: &gcpcloud.GCPCredentials{WIFConfigID: "wif", ProjectID: "my-project", Region: "us-east1"},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		response, err := provider.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().SendContext(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		cluster := response.Body()
+		Expect(cluster.CloudProvider().ID()).To(Equal("gcp"))
+		Expect(cluster.GCP().ProjectID()).To(Equal("my-project"))
+		Expect(cluster.Region().ID()).To(Equal("us-east1"))
+		Expect(cluster.Nodes().ComputeMachineType().ID()).To(Equal("custom-4-16384"))
+	})
+
+	It("should reject gcp clusters with a service account file and wif config", func(ctx context.Context) {
+		_, err := provider.CreateClusterAsync(ctx, &CreateClusterOptions{
+			ClusterName:    "my-cluster",
+			GCPCredentials: &gcpcloud.GCPCredentials{ServiceAccountFile: "key.json", WIFConfigID: "wif", Region: "us-east1"},
+		})
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should reject clusters without aws credentials", func(ctx context.Context) {
+		_, err := provider.CreateClusterAsync(ctx, &CreateClusterOptions{ClusterName: "my-cluster", AWSAccountID: "123456789012"})
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should wait for the cluster to be ready and delete it", func(ctx context.Context) {
+		cluster, err := clustersmgmtv1.NewCluster().ID("abc").Name("my-cluster").State(clustersmgmtv1.ClusterStateReady).Build()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(server.AddCluster(cluster)).To(Succeed())
+		server.SetKubeConfig("abc", "apiVersion: v1\nkind: Config\n")
+
+		kubeConfigFile, err := provider.WaitForClusterReady(ctx, "abc", time.Minute)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(kubeConfigFile).To(HaveSuffix("abc-kubeconfig"))
+
+		server.Handle(http.MethodGet, "/api/clusters_mgmt/v1/clusters/abc/logs/uninstall", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"kind":"Log","id":"uninstall","content":"level=info msg=Uninstalling cluster\n"}`))
+		})
+
+		Expect(featureflags.Global().Set(featureflags.ProvisioningEvents, true)).To(Succeed())
+		DeferCleanup(featureflags.Global().Set, featureflags.ProvisioningEvents, false)
+
+		var phases []events.PhaseEvent
+		provider.events.Add(events.Funcs{PhaseEnd: func(event events.PhaseEvent) { phases = append(phases, event) }})
+
+		Expect(provider.DeleteCluster(ctx, &DeleteClusterOptions{ClusterID: "abc", DeprovisionLogs: true})).To(Succeed())
+		Expect(phases).To(ConsistOf(HaveField("ClusterName", "my-cluster")))
+		_, err = provider.ClustersMgmt().V1().Clusters().Cluster("abc").Get().SendContext(ctx)
+		Expect(err).Should(HaveOccurred())
+
+		uninstallLog, err := os.ReadFile(filepath.Join(provider.ArtifactDir, "abc-uninstall.log"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(uninstallLog)).To(ContainSubstring("Uninstalling cluster"))
+	})
+
+	It("should require the cluster id to delete a cluster", func(ctx context.Context) {
+		Expect(provider.DeleteCluster(ctx, &DeleteClusterOptions{})).ToNot(Succeed())
+	})
+
+	It("should not create a cluster when the organization has insufficient quota", func(ctx context.Context) {
+		quotaCost = `{"kind":"QuotaCostList","page":1,"size":1,"total":1,"items":[{"quota_id":"cluster|byoc|osd","allowed":2,"consumed":2,
+			"related_resources":[{"product":"OSD","resource_type":"cluster.aws","byoc":"byoc","cost":1}]}]}`
+
+		_, err := provider.CreateClusterAsync(ctx, &CreateClusterOptions{
+			ClusterName:    "my-cluster",
+			Version:        "4.13.4",
+			AWSAccountID:   "123456789012",
+			AWSCredentials: &awscloud.AWSCredentials{AccessKeyID: "key", SecretAccessKey: "secret", Region: "us-east-1"},
+		})
+		Expect(err).To(MatchError(ContainSubstring("insufficient quota for 1 OSD/cluster.aws/byoc: cluster|byoc|osd (allowed=2, consumed=2, cost=1)")))
+	})
+})
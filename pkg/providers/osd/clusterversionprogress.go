@@ -0,0 +1,70 @@
+package osd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+)
+
+// clusterVersionPercentPattern matches the percentage of the cluster version progressing message
+// (e.g. Working towards 4.14.1: 584 of 845 done (69% complete))
+var clusterVersionPercentPattern = regexp.MustCompile(`\((\d+)% complete`)
+
+// clusterVersionProgress sets the progress of the cluster version operator applying the version
+// and the number of cluster operators at the version, it is left unset until the cluster desires the version
+func clusterVersionProgress(ctx context.Context, client *openshift.Client, version string, progress *UpgradeProgress) error {
+	var clusterVersion configv1.ClusterVersion
+	if err := client.Get(ctx, "version", "", &clusterVersion); err != nil {
+		return fmt.Errorf("failed to get cluster version: %v", err)
+	}
+
+	var clusterOperators configv1.ClusterOperatorList
+	if err := client.List(ctx, &clusterOperators); err != nil {
+		return fmt.Errorf("failed to list cluster operators: %v", err)
+	}
+
+	setClusterVersionProgress(&clusterVersion, clusterOperators.Items, version, progress)
+
+	return nil
+}
+
+// setClusterVersionProgress sets the progress from the cluster version progressing condition and
+// the versions the cluster operators report
+func setClusterVersionProgress(clusterVersion *configv1.ClusterVersion, clusterOperators []configv1.ClusterOperator, version string, progress *UpgradeProgress) {
+	if clusterVersion.Status.Desired.Version != version {
+		return
+	}
+
+	for _, condition := range clusterVersion.Status.Conditions {
+		if condition.Type != configv1.OperatorProgressing {
+			continue
+		}
+
+		progress.ClusterVersionMessage = condition.Message
+
+		if matches := clusterVersionPercentPattern.FindStringSubmatch(condition.Message); matches != nil {
+			progress.ClusterVersionPercent, _ = strconv.Atoi(matches[1])
+		}
+	}
+
+	if len(clusterVersion.Status.History) > 0 {
+		latest := clusterVersion.Status.History[0]
+		if latest.Version == version && latest.State == configv1.CompletedUpdate {
+			progress.ClusterVersionPercent = 100
+		}
+	}
+
+	progress.Operators = len(clusterOperators)
+	progress.OperatorsUpdated = 0
+	for _, clusterOperator := range clusterOperators {
+		for _, operandVersion := range clusterOperator.Status.Versions {
+			if operandVersion.Name == "operator" && operandVersion.Version == version {
+				progress.OperatorsUpdated++
+			}
+		}
+	}
+}
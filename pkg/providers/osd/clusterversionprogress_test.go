@@ -0,0 +1,54 @@
+package osd
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+var _ = Describe("Cluster version progress", func() {
+	clusterOperator := func(version string) configv1.ClusterOperator {
+		return configv1.ClusterOperator{Status: configv1.ClusterOperatorStatus{
+			Versions: []configv1.OperandVersion{{Name: "operator", Version: version}, {Name: "operand", Version: "4.14.1"}},
+		}}
+	}
+
+	clusterVersion := func(desired string, state configv1.UpdateState) *configv1.ClusterVersion {
+		return &configv1.ClusterVersion{Status: configv1.ClusterVersionStatus{
+			Desired: configv1.Release{Version: desired},
+			History: []configv1.UpdateHistory{{Version: desired, State: state}},
+			Conditions: []configv1.ClusterOperatorStatusCondition{{
+				Type:    configv1.OperatorProgressing,
+				Status:  configv1.ConditionTrue,
+				Message: "Working towards 4.14.1: 584 of 845 done (69% complete), waiting on kube-apiserver",
+			}},
+		}}
+	}
+
+	operators := []configv1.ClusterOperator{clusterOperator("4.14.1"), clusterOperator("4.13.10"), clusterOperator("4.14.1")}
+
+	It("should report the cluster version operators progress", func() {
+		var progress UpgradeProgress
+		setClusterVersionProgress(clusterVersion("4.14.1", configv1.PartialUpdate), operators, "4.14.1", &progress)
+
+		Expect(progress.ClusterVersionPercent).To(Equal(69))
+		Expect(progress.ClusterVersionMessage).To(ContainSubstring("waiting on kube-apiserver"))
+		Expect(progress.OperatorsUpdated).To(Equal(2))
+		Expect(progress.Operators).To(Equal(3))
+		Expect(progress.String()).To(HaveSuffix("cluster version 69% (2/3 operators updated)"))
+	})
+
+	It("should report the completed update", func() {
+		var progress UpgradeProgress
+		setClusterVersionProgress(clusterVersion("4.14.1", configv1.CompletedUpdate), operators, "4.14.1", &progress)
+		Expect(progress.ClusterVersionPercent).To(Equal(100))
+	})
+
+	It("should not report progress until the cluster desires the version", func() {
+		var progress UpgradeProgress
+		setClusterVersionProgress(clusterVersion("4.13.10", configv1.CompletedUpdate), operators, "4.14.1", &progress)
+		Expect(progress.Operators).To(BeZero())
+		Expect(progress.ClusterVersionPercent).To(BeZero())
+	})
+})
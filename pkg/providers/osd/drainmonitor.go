@@ -0,0 +1,188 @@
+package osd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+)
+
+// defaultStuckDrainThreshold is how long a node may be cordoned before it is reported as stuck draining
+const defaultStuckDrainThreshold = 30 * time.Minute
+
+// StuckDrain represents a node cordoned for longer than the stuck drain threshold
+type StuckDrain struct {
+	Node string
+	// Since is when the node was first observed cordoned
+	Since time.Time
+	// Pods are the pods (namespace/name) still running on the node, excluding daemon set pods
+	Pods []string
+	// PodDisruptionBudgets are the pod disruption budgets (namespace/name) allowing no
+	// disruptions for the pods, they are usually the reason the drain is stuck
+	PodDisruptionBudgets []string
+}
+
+// String returns a human readable description of the stuck drain
+func (s StuckDrain) String() string {
+	description := fmt.Sprintf("node %s has been draining since %s", s.Node, s.Since.UTC().Format(time.RFC3339))
+	if len(s.PodDisruptionBudgets) > 0 {
+		description = fmt.Sprintf("%s, blocked by pod disruption budgets %s", description, strings.Join(s.PodDisruptionBudgets, ", "))
+	}
+	if len(s.Pods) > 0 {
+		description = fmt.Sprintf("%s, remaining pods %s", description, strings.Join(s.Pods, ", "))
+	}
+	return description
+}
+
+// drainMonitor tracks when nodes are cordoned during an upgrade and reports the nodes
+// cordoned for longer than the threshold once
+type drainMonitor struct {
+	threshold time.Duration
+	cordoned  map[string]time.Time
+	reported  map[string]bool
+}
+
+// newDrainMonitor returns a drain monitor for the threshold, defaults to 30 minutes
+func newDrainMonitor(threshold time.Duration) *drainMonitor {
+	if threshold == 0 {
+		threshold = defaultStuckDrainThreshold
+	}
+
+	return &drainMonitor{
+		threshold: threshold,
+		cordoned:  map[string]time.Time{},
+		reported:  map[string]bool{},
+	}
+}
+
+// check records the cordoned nodes and returns the nodes newly found stuck draining
+func (d *drainMonitor) check(ctx context.Context, client *openshift.Client, now time.Time) ([]StuckDrain, error) {
+	var nodes corev1.NodeList
+	if err := client.List(ctx, &nodes); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %v", err)
+	}
+
+	var stuck []StuckDrain
+
+	for _, node := range nodes.Items {
+		if !node.Spec.Unschedulable {
+			delete(d.cordoned, node.Name)
+			delete(d.reported, node.Name)
+			continue
+		}
+
+		since, ok := d.cordoned[node.Name]
+		if !ok {
+			log.Printf("Node %q cordoned for draining", node.Name)
+			d.cordoned[node.Name] = now
+			continue
+		}
+
+		if d.reported[node.Name] || now.Sub(since) < d.threshold {
+			continue
+		}
+
+		drain, err := stuckDrain(ctx, client, node.Name, since)
+		if err != nil {
+			return nil, err
+		}
+
+		d.reported[node.Name] = true
+		stuck = append(stuck, *drain)
+	}
+
+	return stuck, nil
+}
+
+// reportStuckDrains logs and sends a warning event for the nodes newly found stuck draining,
+// recording them to the result when provided
+func (o *Provider) reportStuckDrains(ctx context.Context, client *openshift.Client, clusterID string, monitor *drainMonitor, now time.Time, result *UpgradeResult) {
+	stuck, err := monitor.check(ctx, client, now)
+	if err != nil {
+		log.Printf("Failed to check for nodes stuck draining: %v", err)
+		return
+	}
+
+	source := fmt.Sprintf("cluster %s upgrade", clusterID)
+	for _, drain := range stuck {
+		log.Printf("WARNING: cluster %q %s", clusterID, drain)
+		o.events.Warning(source, drain.String(), time.Time{})
+		if result != nil {
+			result.StuckDrains = append(result.StuckDrains, drain)
+		}
+	}
+}
+
+// stuckDrain returns the pods remaining on the node and the pod disruption budgets blocking their eviction
+func stuckDrain(ctx context.Context, client *openshift.Client, nodeName string, since time.Time) (*StuckDrain, error) {
+	drain := &StuckDrain{Node: nodeName, Since: since}
+
+	// the resources client is namespaced in place, restore it to all namespaces once finished
+	defer client.WithNamespace("")
+
+	var pods corev1.PodList
+	if err := client.WithNamespace("").List(ctx, &pods, resources.WithFieldSelector("spec.nodeName="+nodeName)); err != nil {
+		return nil, fmt.Errorf("failed to list node %q pods: %v", nodeName, err)
+	}
+
+	budgets := map[string][]policyv1.PodDisruptionBudget{}
+	blocking := map[string]bool{}
+
+	for _, pod := range pods.Items {
+		if daemonSetPod(&pod) || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		drain.Pods = append(drain.Pods, pod.Namespace+"/"+pod.Name)
+
+		namespaceBudgets, ok := budgets[pod.Namespace]
+		if !ok {
+			var list policyv1.PodDisruptionBudgetList
+			if err := client.WithNamespace(pod.Namespace).List(ctx, &list); err != nil {
+				return nil, fmt.Errorf("failed to list %s pod disruption budgets: %v", pod.Namespace, err)
+			}
+			namespaceBudgets = list.Items
+			budgets[pod.Namespace] = namespaceBudgets
+		}
+
+		for _, budget := range namespaceBudgets {
+			if budget.Status.DisruptionsAllowed > 0 {
+				continue
+			}
+
+			selector, err := metav1.LabelSelectorAsSelector(budget.Spec.Selector)
+			if err != nil || selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+
+			blocking[budget.Namespace+"/"+budget.Name] = true
+		}
+	}
+
+	for budget := range blocking {
+		drain.PodDisruptionBudgets = append(drain.PodDisruptionBudgets, budget)
+	}
+	sort.Strings(drain.PodDisruptionBudgets)
+	sort.Strings(drain.Pods)
+
+	return drain, nil
+}
+
+// daemonSetPod returns true when the pod is owned by a daemon set, drains do not evict them
+func daemonSetPod(pod *corev1.Pod) bool {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "DaemonSet" {
+			return true
+		}
+	}
+	return false
+}
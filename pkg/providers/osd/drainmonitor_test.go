@@ -0,0 +1,70 @@
+package osd
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/osde2e-framework/pkg/clients/kubernetesfake"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Drain monitor", func() {
+	pod := func(name, nodeName string, owner string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "my-app", Labels: map[string]string{"app": name}},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if owner != "" {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: owner, Name: name, APIVersion: "apps/v1", UID: "abc"}}
+		}
+		return pod
+	}
+
+	It("should report the nodes cordoned longer than the threshold with the blocking pod disruption budgets", func(ctx context.Context) {
+		server, err := kubernetesfake.NewServer(
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-0"}, Spec: corev1.NodeSpec{Unschedulable: true}},
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}},
+			pod("database", "worker-0", ""),
+			pod("node-exporter", "worker-0", "DaemonSet"),
+			pod("web", "worker-1", ""),
+			&policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Name: "database", Namespace: "my-app"},
+				Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "database"}}},
+			},
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		DeferCleanup(server.Close)
+
+		client, err := server.Client()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		monitor := newDrainMonitor(0)
+		start := time.Now()
+
+		stuck, err := monitor.check(ctx, client, start)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(stuck).To(BeEmpty())
+
+		stuck, err = monitor.check(ctx, client, start.Add(10*time.Minute))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(stuck).To(BeEmpty())
+
+		stuck, err = monitor.check(ctx, client, start.Add(31*time.Minute))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(stuck).To(HaveLen(1))
+		Expect(stuck[0].Node).To(Equal("worker-0"))
+		Expect(stuck[0].Since).To(Equal(start))
+		Expect(stuck[0].Pods).To(Equal([]string{"my-app/database"}))
+		Expect(stuck[0].PodDisruptionBudgets).To(Equal([]string{"my-app/database"}))
+
+		stuck, err = monitor.check(ctx, client, start.Add(40*time.Minute))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(stuck).To(BeEmpty())
+	})
+})
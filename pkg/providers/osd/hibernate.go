@@ -0,0 +1,78 @@
+package osd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmclient "github.com/openshift/osde2e-framework/pkg/clients/ocm"
+)
+
+// defaultHibernationTimeout is how long to wait for the cluster to hibernate or resume
+const defaultHibernationTimeout = 30 * time.Minute
+
+// HibernateCluster hibernates the cluster, shutting down its nodes, and waits for it to be
+// hibernating. The timeout defaults to 30 minutes
+func (o *Provider) HibernateCluster(ctx context.Context, clusterID string, timeout time.Duration) error {
+	const action = "hibernate"
+
+	err := o.runPhase(action, clusterID, "", func() error {
+		_, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).Hibernate().SendContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to hibernate cluster %q: %v", clusterID, err)
+		}
+
+		log.Printf("Cluster %q hibernation requested", clusterID)
+
+		return o.waitForClusterState(ctx, clusterID, clustersmgmtv1.ClusterStateHibernating, timeout)
+	})
+	if err != nil {
+		return &clusterError{action: action, err: err}
+	}
+
+	return nil
+}
+
+// ResumeCluster resumes the hibernating cluster and waits for it to be ready. The timeout
+// defaults to 30 minutes
+func (o *Provider) ResumeCluster(ctx context.Context, clusterID string, timeout time.Duration) error {
+	const action = "resume"
+
+	err := o.runPhase(action, clusterID, "", func() error {
+		_, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).Resume().SendContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to resume cluster %q: %v", clusterID, err)
+		}
+
+		log.Printf("Cluster %q resume requested", clusterID)
+
+		return o.waitForClusterState(ctx, clusterID, clustersmgmtv1.ClusterStateReady, timeout)
+	})
+	if err != nil {
+		return &clusterError{action: action, err: err}
+	}
+
+	return nil
+}
+
+// waitForClusterState waits for the cluster to reach the state, failing once it is in error state
+func (o *Provider) waitForClusterState(ctx context.Context, clusterID string, state clustersmgmtv1.ClusterState, timeout time.Duration) error {
+	if timeout == 0 {
+		timeout = defaultHibernationTimeout
+	}
+
+	return o.WaitForClusterStateWithOptions(ctx, clusterID, state, &ocmclient.ClusterStateOptions{
+		Timeout:       timeout,
+		PollInterval:  clusterReadyPollInterval,
+		OnStateChange: o.stateChangeHandler(clusterID, ""),
+	})
+}
+
+// stateChangeHandler returns the handler notifying the event hooks of the clusters state changes
+func (o *Provider) stateChangeHandler(clusterID, clusterName string) func(previous, current clustersmgmtv1.ClusterState) {
+	return func(previous, current clustersmgmtv1.ClusterState) {
+		o.events.StateChange(clusterID, clusterName, string(previous), string(current))
+	}
+}
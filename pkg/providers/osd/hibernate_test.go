@@ -0,0 +1,62 @@
+package osd
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osde2e-framework/pkg/clients/ocmfake"
+)
+
+var _ = Describe("Hibernation", func() {
+	var (
+		server   *ocmfake.Server
+		provider *Provider
+	)
+
+	setState := func(state clustersmgmtv1.ClusterState) {
+		cluster, err := clustersmgmtv1.NewCluster().ID("abc").Name("my-cluster").State(state).Build()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(server.AddCluster(cluster)).To(Succeed())
+	}
+
+	BeforeEach(func(ctx context.Context) {
+		server = ocmfake.NewServer()
+		DeferCleanup(server.Close)
+
+		client, err := server.Client(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		provider = &Provider{Client: client}
+
+		setState(clustersmgmtv1.ClusterStateReady)
+
+		transition := func(state clustersmgmtv1.ClusterState) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				setState(state)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusNoContent)
+			}
+		}
+		server.Handle(http.MethodPost, "/api/clusters_mgmt/v1/clusters/abc/hibernate", transition(clustersmgmtv1.ClusterStateHibernating))
+		server.Handle(http.MethodPost, "/api/clusters_mgmt/v1/clusters/abc/resume", transition(clustersmgmtv1.ClusterStateReady))
+	})
+
+	It("should hibernate and resume the cluster", func(ctx context.Context) {
+		Expect(provider.HibernateCluster(ctx, "abc", time.Minute)).To(Succeed())
+		Expect(provider.ResumeCluster(ctx, "abc", time.Minute)).To(Succeed())
+	})
+
+	It("should fail when the cluster enters error state", func(ctx context.Context) {
+		server.Handle(http.MethodPost, "/api/clusters_mgmt/v1/clusters/abc/resume", func(w http.ResponseWriter, r *http.Request) {
+			setState(clustersmgmtv1.ClusterStateError)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		Expect(provider.ResumeCluster(ctx, "abc", time.Minute)).To(MatchError(ContainSubstring("is in error state")))
+	})
+})
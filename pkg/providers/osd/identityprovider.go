@@ -0,0 +1,219 @@
+package osd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+const (
+	// ClusterAdminsGroup is the group granting its users cluster-admin
+	ClusterAdminsGroup = "cluster-admins"
+	// DedicatedAdminsGroup is the group granting its users dedicated-admin
+	DedicatedAdminsGroup = "dedicated-admins"
+)
+
+// IdentityProviderOptions represents data used to create an identity provider, exactly one of
+// HTPasswdUsers or OpenID must be defined
+type IdentityProviderOptions struct {
+	Name string
+	// MappingMethod maps identities to users, defaults to claim
+	MappingMethod clustersmgmtv1.IdentityProviderMappingMethod
+	// HTPasswdUsers are the htpasswd users passwords by username
+	HTPasswdUsers map[string]string
+	OpenID        *OpenIDOptions
+}
+
+// OpenIDOptions represents data used to create an openid identity provider
+type OpenIDOptions struct {
+	ClientID     string
+	ClientSecret string
+	Issuer       string
+	// EmailClaims, NameClaims and PreferredUsernameClaims default to email, name and preferred_username
+	EmailClaims             []string
+	NameClaims              []string
+	PreferredUsernameClaims []string
+	ExtraScopes             []string
+}
+
+// identityProviderError represents the custom error
+type identityProviderError struct {
+	action string
+	err    error
+}
+
+// Error returns the formatted error message when identityProviderError is invoked
+func (i *identityProviderError) Error() string {
+	return fmt.Sprintf("%s identity provider failed: %v", i.action, i.err)
+}
+
+// validate verifies the identity provider options are set and consistent
+func (i *IdentityProviderOptions) validate() error {
+	switch {
+	case i.Name == "":
+		return fmt.Errorf("identity provider name is required")
+	case len(i.HTPasswdUsers) > 0 && i.OpenID != nil:
+		return fmt.Errorf("htpasswd users and openid are mutually exclusive")
+	case len(i.HTPasswdUsers) == 0 && i.OpenID == nil:
+		return fmt.Errorf("htpasswd users or openid is required")
+	case i.OpenID != nil && (i.OpenID.ClientID == "" || i.OpenID.ClientSecret == "" || i.OpenID.Issuer == ""):
+		return fmt.Errorf("openid client id, client secret and issuer are required")
+	}
+
+	return nil
+}
+
+// buildIdentityProvider builds the ocm identity provider from the options
+func buildIdentityProvider(options *IdentityProviderOptions) (*clustersmgmtv1.IdentityProvider, error) {
+	mappingMethod := options.MappingMethod
+	if mappingMethod == "" {
+		mappingMethod = clustersmgmtv1.IdentityProviderMappingMethodClaim
+	}
+
+	identityProviderBuilder := clustersmgmtv1.NewIdentityProvider().
+		Name(options.Name).
+		MappingMethod(mappingMethod)
+
+	if options.OpenID != nil {
+		claims := func(values []string, defaultValue string) []string {
+			if len(values) == 0 {
+				return []string{defaultValue}
+			}
+			return values
+		}
+
+		identityProviderBuilder = identityProviderBuilder.
+			Type(clustersmgmtv1.IdentityProviderTypeOpenID).
+			OpenID(clustersmgmtv1.NewOpenIDIdentityProvider().
+				ClientID(options.OpenID.ClientID).
+				ClientSecret(options.OpenID.ClientSecret).
+				Issuer(options.OpenID.Issuer).
+				ExtraScopes(options.OpenID.ExtraScopes...).
+				Claims(clustersmgmtv1.NewOpenIDClaims().
+					Email(claims(options.OpenID.EmailClaims, "email")...).
+					Name(claims(options.OpenID.NameClaims, "name")...).
+					PreferredUsername(claims(options.OpenID.PreferredUsernameClaims, "preferred_username")...)))
+	} else {
+		usernames := make([]string, 0, len(options.HTPasswdUsers))
+		for username := range options.HTPasswdUsers {
+			usernames = append(usernames, username)
+		}
+		sort.Strings(usernames)
+
+		users := make([]*clustersmgmtv1.HTPasswdUserBuilder, 0, len(usernames))
+		for _, username := range usernames {
+			users = append(users, clustersmgmtv1.NewHTPasswdUser().Username(username).Password(options.HTPasswdUsers[username]))
+		}
+
+		identityProviderBuilder = identityProviderBuilder.
+			Type(clustersmgmtv1.IdentityProviderTypeHtpasswd).
+			Htpasswd(clustersmgmtv1.NewHTPasswdIdentityProvider().
+				Users(clustersmgmtv1.NewHTPasswdUserList().Items(users...)))
+	}
+
+	identityProvider, err := identityProviderBuilder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build identity provider: %v", err)
+	}
+
+	return identityProvider, nil
+}
+
+// CreateIdentityProvider creates the identity provider on the cluster
+//
+//	idp, err := provider.CreateIdentityProvider(ctx, clusterID, &osd.IdentityProviderOptions{
+//		Name:          "test-htpasswd",
+//		HTPasswdUsers: map[string]string{"test-user": password},
+//	})
+//	Expect(err).ShouldNot(HaveOccurred())
+//	Expect(provider.AddGroupUser(ctx, clusterID, osd.DedicatedAdminsGroup, "test-user")).To(Succeed())
+func (o *Provider) CreateIdentityProvider(ctx context.Context, clusterID string, options *IdentityProviderOptions) (*clustersmgmtv1.IdentityProvider, error) {
+	const action = "create"
+
+	if err := options.validate(); err != nil {
+		return nil, &identityProviderError{action: action, err: err}
+	}
+
+	identityProvider, err := buildIdentityProvider(options)
+	if err != nil {
+		return nil, &identityProviderError{action: action, err: err}
+	}
+
+	response, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).IdentityProviders().Add().Body(identityProvider).SendContext(ctx)
+	if err != nil {
+		return nil, &identityProviderError{action: action, err: fmt.Errorf("failed to add cluster %q identity provider %q: %v", clusterID, options.Name, err)}
+	}
+
+	log.Printf("Cluster %q identity provider %q created (id=%s)", clusterID, options.Name, response.Body().ID())
+
+	return response.Body(), nil
+}
+
+// DeleteIdentityProvider deletes the clusters identity provider
+func (o *Provider) DeleteIdentityProvider(ctx context.Context, clusterID, identityProviderID string) error {
+	_, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).IdentityProviders().IdentityProvider(identityProviderID).Delete().SendContext(ctx)
+	if err != nil {
+		return &identityProviderError{action: "delete", err: fmt.Errorf("failed to delete cluster %q identity provider %q: %v", clusterID, identityProviderID, err)}
+	}
+
+	log.Printf("Cluster %q identity provider %q deleted", clusterID, identityProviderID)
+
+	return nil
+}
+
+// IdentityProviders returns the clusters identity providers
+func (o *Provider) IdentityProviders(ctx context.Context, clusterID string) ([]*clustersmgmtv1.IdentityProvider, error) {
+	response, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).IdentityProviders().List().SendContext(ctx)
+	if err != nil {
+		return nil, &identityProviderError{action: "list", err: fmt.Errorf("failed to list cluster %q identity providers: %v", clusterID, err)}
+	}
+
+	return response.Items().Slice(), nil
+}
+
+// AddGroupUser adds the user to the clusters group (e.g. cluster-admins, dedicated-admins)
+func (o *Provider) AddGroupUser(ctx context.Context, clusterID, group, username string) error {
+	user, err := clustersmgmtv1.NewUser().ID(username).Build()
+	if err != nil {
+		return &identityProviderError{action: "add user", err: fmt.Errorf("failed to build user: %v", err)}
+	}
+
+	_, err = o.ClustersMgmt().V1().Clusters().Cluster(clusterID).Groups().Group(group).Users().Add().Body(user).SendContext(ctx)
+	if err != nil {
+		return &identityProviderError{action: "add user", err: fmt.Errorf("failed to add user %q to cluster %q group %q: %v", username, clusterID, group, err)}
+	}
+
+	log.Printf("User %q added to cluster %q group %q", username, clusterID, group)
+
+	return nil
+}
+
+// RemoveGroupUser removes the user from the clusters group
+func (o *Provider) RemoveGroupUser(ctx context.Context, clusterID, group, username string) error {
+	_, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).Groups().Group(group).Users().User(username).Delete().SendContext(ctx)
+	if err != nil {
+		return &identityProviderError{action: "remove user", err: fmt.Errorf("failed to remove user %q from cluster %q group %q: %v", username, clusterID, group, err)}
+	}
+
+	log.Printf("User %q removed from cluster %q group %q", username, clusterID, group)
+
+	return nil
+}
+
+// GroupUsers returns the usernames of the clusters group users
+func (o *Provider) GroupUsers(ctx context.Context, clusterID, group string) ([]string, error) {
+	response, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).Groups().Group(group).Users().List().SendContext(ctx)
+	if err != nil {
+		return nil, &identityProviderError{action: "list users", err: fmt.Errorf("failed to list cluster %q group %q users: %v", clusterID, group, err)}
+	}
+
+	usernames := make([]string, 0, response.Items().Len())
+	for _, user := range response.Items().Slice() {
+		usernames = append(usernames, user.ID())
+	}
+
+	return usernames, nil
+}
@@ -0,0 +1,52 @@
+package osd
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Identity Providers", func() {
+	It("should validate the identity provider options", func() {
+		Expect((&IdentityProviderOptions{Name: "htpasswd", HTPasswdUsers: map[string]string{"user": "password"}}).validate()).To(Succeed())
+		Expect((&IdentityProviderOptions{HTPasswdUsers: map[string]string{"user": "password"}}).validate()).ToNot(Succeed())
+		Expect((&IdentityProviderOptions{Name: "none"}).validate()).ToNot(Succeed())
+		Expect((&IdentityProviderOptions{Name: "openid", OpenID: &OpenIDOptions{ClientID: "id"}}).validate()).ToNot(Succeed())
+		Expect((&IdentityProviderOptions{
+			Name:          "both",
+			HTPasswdUsers: map[string]string{"user": "password"},
+			OpenID:        &OpenIDOptions{ClientID: "id", ClientSecret: "secret", Issuer: "https://issuer"},
+		}).validate()).ToNot(Succeed())
+	})
+
+	It("should build an htpasswd identity provider", func() {
+		identityProvider, err := buildIdentityProvider(&IdentityProviderOptions{
+			Name:          "htpasswd",
+			HTPasswdUsers: map[string]string{"user-b": "password-b", "user-a": "password-a"},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(identityProvider.Type()).To(Equal(clustersmgmtv1.IdentityProviderTypeHtpasswd))
+		Expect(identityProvider.MappingMethod()).To(Equal(clustersmgmtv1.IdentityProviderMappingMethodClaim))
+
+		users := identityProvider.Htpasswd().Users().Slice()
+		Expect(users).To(HaveLen(2))
+		Expect(users[0].Username()).To(Equal("user-a"))
+		Expect(users[0].Password()).To(Equal("password-a"))
+	})
+
+	It("should build an openid identity provider with default claims", func() {
+		identityProvider, err := buildIdentityProvider(&IdentityProviderOptions{
+			Name:          "openid",
+			MappingMethod: clustersmgmtv1.IdentityProviderMappingMethodAdd,
+			OpenID:        &OpenIDOptions{ClientID: "id", ClientSecret: "secret", Issuer: "https://issuer", NameClaims: []string{"given_name"}},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(identityProvider.Type()).To(Equal(clustersmgmtv1.IdentityProviderTypeOpenID))
+		Expect(identityProvider.MappingMethod()).To(Equal(clustersmgmtv1.IdentityProviderMappingMethodAdd))
+		Expect(identityProvider.OpenID().Issuer()).To(Equal("https://issuer"))
+		Expect(identityProvider.OpenID().Claims().Email()).To(Equal([]string{"email"}))
+		Expect(identityProvider.OpenID().Claims().Name()).To(Equal([]string{"given_name"}))
+		Expect(identityProvider.OpenID().Claims().PreferredUsername()).To(Equal([]string{"preferred_username"}))
+	})
+})
@@ -0,0 +1,186 @@
+package osd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// MachinePoolOptions represents data used to create or update osd machine pools
+type MachinePoolOptions struct {
+	ID string
+	// InstanceType defaults to m5.xlarge, it cannot be changed once the machine pool is created
+	InstanceType string
+	// Replicas is the number of nodes, for multi az clusters it must be a multiple of 3
+	// unless the machine pool is placed in a single availability zone
+	Replicas int
+	// MinReplicas and MaxReplicas enable autoscaling instead of a fixed number of replicas
+	MinReplicas int
+	MaxReplicas int
+	// AvailabilityZones places the machine pool in the zones instead of all the clusters
+	// zones, it cannot be changed once the machine pool is created
+	AvailabilityZones []string
+	Labels            map[string]string
+	Taints            []corev1.Taint
+}
+
+// machinePoolError represents the custom error
+type machinePoolError struct {
+	action string
+	err    error
+}
+
+// Error returns the formatted error message when machinePoolError is invoked
+func (m *machinePoolError) Error() string {
+	return fmt.Sprintf("%s machine pool failed: %v", m.action, m.err)
+}
+
+// autoscaling returns true when the machine pool autoscales
+func (m *MachinePoolOptions) autoscaling() bool {
+	return m.MinReplicas > 0 || m.MaxReplicas > 0
+}
+
+// validate verifies the machine pool options are set and consistent
+func (m *MachinePoolOptions) validate() error {
+	switch {
+	case m.ID == "":
+		return fmt.Errorf("machine pool id is required")
+	case m.autoscaling() && m.Replicas > 0:
+		return fmt.Errorf("replicas and min/max replicas are mutually exclusive")
+	case m.autoscaling() && (m.MinReplicas < 1 || m.MaxReplicas < m.MinReplicas):
+		return fmt.Errorf("min replicas must be at least 1 and not greater than max replicas")
+	case m.Replicas < 0:
+		return fmt.Errorf("replicas must not be negative")
+	}
+
+	for _, taint := range m.Taints {
+		switch taint.Effect {
+		case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			return fmt.Errorf("taint %q effect %q is invalid", taint.Key, taint.Effect)
+		}
+
+		if taint.Key == "" {
+			return fmt.Errorf("taint key is required")
+		}
+	}
+
+	return nil
+}
+
+// buildMachinePool builds the ocm machine pool from the options, the instance type and
+// availability zones are only set when creating the machine pool
+func buildMachinePool(options *MachinePoolOptions, create bool) (*clustersmgmtv1.MachinePool, error) {
+	machinePoolBuilder := clustersmgmtv1.NewMachinePool().ID(options.ID)
+
+	if create {
+		instanceType := options.InstanceType
+		if instanceType == "" {
+			instanceType = defaultComputeMachineType
+		}
+		machinePoolBuilder = machinePoolBuilder.InstanceType(instanceType)
+
+		if len(options.AvailabilityZones) > 0 {
+			machinePoolBuilder = machinePoolBuilder.AvailabilityZones(options.AvailabilityZones...)
+		}
+	}
+
+	if options.autoscaling() {
+		machinePoolBuilder = machinePoolBuilder.Autoscaling(clustersmgmtv1.NewMachinePoolAutoscaling().
+			MinReplicas(options.MinReplicas).
+			MaxReplicas(options.MaxReplicas))
+	} else {
+		machinePoolBuilder = machinePoolBuilder.Replicas(options.Replicas)
+	}
+
+	if options.Labels != nil {
+		machinePoolBuilder = machinePoolBuilder.Labels(options.Labels)
+	}
+
+	if options.Taints != nil {
+		taints := make([]*clustersmgmtv1.TaintBuilder, 0, len(options.Taints))
+		for _, taint := range options.Taints {
+			taints = append(taints, clustersmgmtv1.NewTaint().Key(taint.Key).Value(taint.Value).Effect(string(taint.Effect)))
+		}
+		machinePoolBuilder = machinePoolBuilder.Taints(taints...)
+	}
+
+	machinePool, err := machinePoolBuilder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build machine pool: %v", err)
+	}
+
+	return machinePool, nil
+}
+
+// CreateMachinePool creates the machine pool on the cluster
+func (o *Provider) CreateMachinePool(ctx context.Context, clusterID string, options *MachinePoolOptions) (*clustersmgmtv1.MachinePool, error) {
+	const action = "create"
+
+	if err := options.validate(); err != nil {
+		return nil, &machinePoolError{action: action, err: err}
+	}
+
+	machinePool, err := buildMachinePool(options, true)
+	if err != nil {
+		return nil, &machinePoolError{action: action, err: err}
+	}
+
+	response, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).MachinePools().Add().Body(machinePool).SendContext(ctx)
+	if err != nil {
+		return nil, &machinePoolError{action: action, err: fmt.Errorf("failed to add cluster %q machine pool %q: %v", clusterID, options.ID, err)}
+	}
+
+	log.Printf("Cluster %q machine pool %q created", clusterID, options.ID)
+
+	return response.Body(), nil
+}
+
+// UpdateMachinePool updates the replicas, autoscaling, labels and taints of the clusters machine pool,
+// undefined labels and taints are left unchanged
+func (o *Provider) UpdateMachinePool(ctx context.Context, clusterID string, options *MachinePoolOptions) (*clustersmgmtv1.MachinePool, error) {
+	const action = "update"
+
+	if err := options.validate(); err != nil {
+		return nil, &machinePoolError{action: action, err: err}
+	}
+
+	machinePool, err := buildMachinePool(options, false)
+	if err != nil {
+		return nil, &machinePoolError{action: action, err: err}
+	}
+
+	response, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).MachinePools().MachinePool(options.ID).Update().Body(machinePool).SendContext(ctx)
+	if err != nil {
+		return nil, &machinePoolError{action: action, err: fmt.Errorf("failed to update cluster %q machine pool %q: %v", clusterID, options.ID, err)}
+	}
+
+	log.Printf("Cluster %q machine pool %q updated", clusterID, options.ID)
+
+	return response.Body(), nil
+}
+
+// DeleteMachinePool deletes the clusters machine pool
+func (o *Provider) DeleteMachinePool(ctx context.Context, clusterID, machinePoolID string) error {
+	_, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).MachinePools().MachinePool(machinePoolID).Delete().SendContext(ctx)
+	if err != nil {
+		return &machinePoolError{action: "delete", err: fmt.Errorf("failed to delete cluster %q machine pool %q: %v", clusterID, machinePoolID, err)}
+	}
+
+	log.Printf("Cluster %q machine pool %q deleted", clusterID, machinePoolID)
+
+	return nil
+}
+
+// MachinePools returns the clusters machine pools
+func (o *Provider) MachinePools(ctx context.Context, clusterID string) ([]*clustersmgmtv1.MachinePool, error) {
+	response, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).MachinePools().List().SendContext(ctx)
+	if err != nil {
+		return nil, &machinePoolError{action: "list", err: fmt.Errorf("failed to list cluster %q machine pools: %v", clusterID, err)}
+	}
+
+	return response.Items().Slice(), nil
+}
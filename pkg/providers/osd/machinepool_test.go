@@ -0,0 +1,60 @@
+package osd
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osde2e-framework/pkg/clients/ocmfake"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Machine Pools", func() {
+	It("should validate the machine pool options", func() {
+		Expect((&MachinePoolOptions{ID: "workers", Replicas: 3}).validate()).To(Succeed())
+		Expect((&MachinePoolOptions{Replicas: 3}).validate()).ToNot(Succeed())
+		Expect((&MachinePoolOptions{ID: "workers", Replicas: 3, MaxReplicas: 6}).validate()).ToNot(Succeed())
+		Expect((&MachinePoolOptions{ID: "workers", MinReplicas: 6, MaxReplicas: 3}).validate()).ToNot(Succeed())
+		Expect((&MachinePoolOptions{ID: "workers", Taints: []corev1.Taint{{Key: "key", Effect: "Invalid"}}}).validate()).ToNot(Succeed())
+	})
+
+	It("should create an autoscaling machine pool with labels and taints", func(ctx context.Context) {
+		server := ocmfake.NewServer()
+		DeferCleanup(server.Close)
+
+		var body []byte
+		server.Handle(http.MethodPost, "/api/clusters_mgmt/v1/clusters/abc/machine_pools", func(w http.ResponseWriter, r *http.Request) {
+			body, _ = io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write(body)
+		})
+
+		client, err := server.Client(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		provider := &Provider{Client: client}
+
+		_, err = provider.CreateMachinePool(ctx, "abc", &MachinePoolOptions{
+			ID:                "workers",
+			MinReplicas:       1,
+			MaxReplicas:       3,
+			AvailabilityZones: []string{"us-east-1a"},
+			Labels:            map[string]string{"pool": "workers"},
+			Taints:            []corev1.Taint{{Key: "dedicated", Value: "workers", Effect: corev1.TaintEffectNoSchedule}},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		machinePool, err := clustersmgmtv1.UnmarshalMachinePool(body)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(machinePool.InstanceType()).To(Equal("m5.xlarge"))
+		Expect(machinePool.Autoscaling().MaxReplicas()).To(Equal(3))
+		Expect(machinePool.AvailabilityZones()).To(Equal([]string{"us-east-1a"}))
+		Expect(machinePool.Labels()).To(HaveKeyWithValue("pool", "workers"))
+		Expect(machinePool.Taints()).To(HaveLen(1))
+		Expect(machinePool.Taints()[0].Effect()).To(Equal("NoSchedule"))
+	})
+})
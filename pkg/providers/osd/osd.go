@@ -5,11 +5,51 @@ import (
 	"fmt"
 
 	ocmclient "github.com/openshift/osde2e-framework/pkg/clients/ocm"
+	"github.com/openshift/osde2e-framework/pkg/events"
 )
 
 // Provider is a openshift dedicated "osd" provider
 type Provider struct {
 	*ocmclient.Client
+
+	// events notifies the hooks of the clusters provisioning progress
+	events events.Dispatcher
+
+	// upgradeProgress is invoked when the progress of an upgrade changes
+	upgradeProgress func(progress UpgradeProgress)
+}
+
+// Option configures optional settings for the osd provider
+type Option func(*Provider)
+
+// WithArtifactDir writes the clusters kubeconfigs to the directory
+func WithArtifactDir(dir string) Option {
+	return func(p *Provider) {
+		p.Client.ArtifactDir = dir
+	}
+}
+
+// WithKubeConfigDir writes the clusters kubeconfigs to the directory instead of the artifact directory
+func WithKubeConfigDir(dir string) Option {
+	return func(p *Provider) {
+		p.Client.KubeConfigDir = dir
+	}
+}
+
+// WithEventHooks adds hooks notified when provisioning phases start and end and when
+// the cluster state changes, requires the provisioning-events feature flag
+func WithEventHooks(hooks ...events.Hook) Option {
+	return func(p *Provider) {
+		p.events.Add(hooks...)
+	}
+}
+
+// WithUpgradeProgressHandler invokes the handler each time the progress of an upgrade
+// changes (phase, message or percent complete), e.g. to report it to a dashboard
+func WithUpgradeProgressHandler(handler func(progress UpgradeProgress)) Option {
+	return func(p *Provider) {
+		p.upgradeProgress = handler
+	}
 }
 
 // providerError represents the provider custom error
@@ -25,15 +65,31 @@ func (o *providerError) Error() string {
 // New handles constructing the osd provider which creates a connection
 // to openshift cluster manager "ocm". It is the callers responsibility
 // to close the ocm connection when they are finished (defer provider.Connection.Close())
-func New(ctx context.Context, token string, environment ocmclient.Environment) (*Provider, error) {
+func New(ctx context.Context, token string, environment ocmclient.Environment, options ...Option) (*Provider, error) {
 	if environment == "" || token == "" {
 		return nil, &providerError{err: fmt.Errorf("some parameters are undefined, unable to construct osd provider")}
 	}
 
-	ocmClient, err := ocmclient.New(ctx, token, environment)
+	return NewWithCredentials(ctx, ocmclient.Credentials{Token: token}, environment, options...)
+}
+
+// NewWithCredentials handles constructing the osd provider authenticating to ocm using the
+// credentials (e.g. service account client credentials). It is the callers responsibility
+// to close the ocm connection when they are finished (defer provider.Connection.Close())
+func NewWithCredentials(ctx context.Context, credentials ocmclient.Credentials, environment ocmclient.Environment, options ...Option) (*Provider, error) {
+	if environment == "" {
+		return nil, &providerError{err: fmt.Errorf("some parameters are undefined, unable to construct osd provider")}
+	}
+
+	ocmClient, err := ocmclient.NewWithCredentials(ctx, credentials, environment)
 	if err != nil {
 		return nil, &providerError{err: err}
 	}
 
-	return &Provider{ocmClient}, nil
+	provider := &Provider{Client: ocmClient}
+	for _, option := range options {
+		option(provider)
+	}
+
+	return provider, nil
 }
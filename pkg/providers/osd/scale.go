@@ -0,0 +1,62 @@
+package osd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+)
+
+const (
+	// defaultMachinePoolID is the id of the machine pool created with the cluster
+	defaultMachinePoolID = "worker"
+	// defaultScaleTimeout is how long to wait for the nodes to join or drain
+	defaultScaleTimeout = 45 * time.Minute
+)
+
+// ScaleCluster sets the replicas of the clusters default machine pool and waits for the nodes
+// to join or drain. The timeout defaults to 45 minutes
+//
+//	client, err := openshift.NewFromKubeconfig(kubeConfigFile)
+//	Expect(err).ShouldNot(HaveOccurred())
+//	Expect(provider.ScaleCluster(ctx, client, clusterID, 6, 0)).To(Succeed())
+func (o *Provider) ScaleCluster(ctx context.Context, client *openshift.Client, clusterID string, replicas int, timeout time.Duration) error {
+	const action = "scale"
+
+	if timeout == 0 {
+		timeout = defaultScaleTimeout
+	}
+
+	err := o.runPhase(action, clusterID, "", func() error {
+		response, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).MachinePools().MachinePool(defaultMachinePoolID).Get().SendContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get cluster %q machine pool %q: %v", clusterID, defaultMachinePoolID, err)
+		}
+
+		machinePool := response.Body()
+		if machinePool.Autoscaling() != nil {
+			return fmt.Errorf("cluster %q machine pool %q is autoscaling", clusterID, defaultMachinePoolID)
+		}
+
+		_, workers, err := client.WorkerNodes(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = o.UpdateMachinePool(ctx, clusterID, &MachinePoolOptions{ID: defaultMachinePoolID, Replicas: replicas})
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Cluster %q scaling from %d to %d replicas", clusterID, machinePool.Replicas(), replicas)
+
+		return client.WaitForWorkerNodes(ctx, workers-machinePool.Replicas()+replicas, timeout)
+	})
+	if err != nil {
+		return &clusterError{action: action, err: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package osd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osde2e-framework/pkg/clients/kubernetesfake"
+	"github.com/openshift/osde2e-framework/pkg/clients/ocmfake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Scale", func() {
+	workerNode := func(name string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"node-role.kubernetes.io/worker": ""}},
+			Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}},
+		}
+	}
+
+	It("should scale the default machine pool and wait for the nodes to join", func(ctx context.Context) {
+		kubeServer, err := kubernetesfake.NewServer(
+			workerNode("worker-0"),
+			workerNode("worker-1"),
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "infra-0", Labels: map[string]string{
+				"node-role.kubernetes.io/worker": "",
+				"node-role.kubernetes.io/infra":  "",
+			}}},
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		DeferCleanup(kubeServer.Close)
+
+		kubeClient, err := kubeServer.Client()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		server := ocmfake.NewServer()
+		DeferCleanup(server.Close)
+
+		server.Handle(http.MethodGet, "/api/clusters_mgmt/v1/clusters/abc/machine_pools/worker", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"kind":"MachinePool","id":"worker","replicas":2}`)
+		})
+
+		var replicas int
+		server.Handle(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/abc/machine_pools/worker", func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			machinePool, err := clustersmgmtv1.UnmarshalMachinePool(body)
+			Expect(err).ShouldNot(HaveOccurred())
+			replicas = machinePool.Replicas()
+
+			for i := 2; i < replicas; i++ {
+				Expect(kubeServer.Add(workerNode(fmt.Sprintf("worker-%d", i)))).To(Succeed())
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(body)
+		})
+
+		client, err := server.Client(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		provider := &Provider{Client: client}
+
+		Expect(provider.ScaleCluster(ctx, kubeClient, "abc", 4, time.Minute)).To(Succeed())
+		Expect(replicas).To(Equal(4))
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/Masterminds/semver"
@@ -14,30 +15,95 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/dynamic"
 	"sigs.k8s.io/e2e-framework/klient/k8s"
-	"sigs.k8s.io/e2e-framework/klient/wait"
-	"sigs.k8s.io/e2e-framework/klient/wait/conditions"
 )
 
 const (
-	managedUpgradeOperatorDeploymentName = "managed-upgrade-operator"
-	managedUpgradeOperatorNamespace      = "openshift-managed-upgrade-operator"
-	versionGateLabel                     = "api.openshift.com/gate-ocp"
-	upgradeMaxAttempts                   = 1080
-	upgradeDelay                         = 10
+	managedUpgradeOperatorDeploymentName   = "managed-upgrade-operator"
+	managedUpgradeOperatorNamespace        = "openshift-managed-upgrade-operator"
+	versionGateLabel                       = "api.openshift.com/gate-ocp"
+	upgradePollInterval                    = 10 * time.Second
+	upgradeTimeout                         = 3 * time.Hour
+	upgradeConfigPollInterval              = 30 * time.Second
+	upgradeConfigTimeout                   = 3 * time.Minute
+	managedUpgradeOperatorAvailableTimeout = 5 * time.Minute
 )
 
+// upgradeSteps are the managed upgrade operator upgrade steps, recorded as conditions
+// of the upgrade config history in the order they are performed
+var upgradeSteps = []string{
+	"SendStartedNotification",
+	"UpgradeDelayedCheck",
+	"UpgradePreHealthCheck",
+	"ExtDepAvailabilityCheck",
+	"UpgradeScaleUpExtraNodes",
+	"ControlPlaneMaintWindow",
+	"CommenceUpgrade",
+	"ControlPlaneUpgraded",
+	"RemoveControlPlaneMaintWindow",
+	"WorkersMaintWindow",
+	"AllWorkerNodesUpgraded",
+	"RemoveExtraScaledNodes",
+	"RemoveMaintWindow",
+	"PostClusterHealthCheck",
+	"PostUpgradeProcedures",
+	"SendCompletedNotification",
+}
+
+// UpgradeProgress represents the progress of the managed upgrade operator upgrading the cluster
+type UpgradeProgress struct {
+	// Phase is the upgrade config phase (Pending, Upgrading, Upgraded, Failed), empty until the upgrade starts
+	Phase string
+	// Message is the message of the most recent upgrade step condition
+	Message string
+	// Percent is the share of the upgrade steps completed
+	Percent int
+	// ClusterVersionPercent is the share of the release the cluster version operator has applied
+	ClusterVersionPercent int
+	// ClusterVersionMessage is the message of the cluster version progressing condition
+	ClusterVersionMessage string
+	// OperatorsUpdated is the number of the Operators cluster operators at the version
+	OperatorsUpdated int
+	Operators        int
+	Time             time.Time
+}
+
+// String returns a human readable description of the progress
+func (p UpgradeProgress) String() string {
+	description := fmt.Sprintf("%s (%d%%)", p.Phase, p.Percent)
+	if p.Message != "" {
+		description = fmt.Sprintf("%s: %s", description, p.Message)
+	}
+	if p.Operators > 0 {
+		description = fmt.Sprintf("%s, cluster version %d%% (%d/%d operators updated)",
+			description, p.ClusterVersionPercent, p.OperatorsUpdated, p.Operators)
+	}
+	return description
+}
+
+// changed returns true when the progress differs from the previous progress
+func (p UpgradeProgress) changed(previous UpgradeProgress) bool {
+	p.Time, previous.Time = time.Time{}, time.Time{}
+	return p != previous
+}
+
 // upgradeError represents the cluster upgrade custom error
 type upgradeError struct {
 	err error
+	// diagnostics are collected when the upgrade fails once started
+	diagnostics *UpgradeDiagnostics
+	// checks are the results of the pre and post upgrade checks run
+	checks []UpgradeCheckResult
 }
 
 // Error returns the formatted error message when upgradeError is invoked
 func (e *upgradeError) Error() string {
+	if e.diagnostics != nil {
+		return fmt.Sprintf("osd upgrade failed: %v (%s)", e.err, e.diagnostics)
+	}
 	return fmt.Sprintf("osd upgrade failed: %v", e.err)
 }
 
@@ -51,52 +117,25 @@ func (o *Provider) versionGates(ctx context.Context) (*clustersmgmtv1.VersionGat
 	return response.Items(), nil
 }
 
-// getVersionGateID returns the version gate agreement id
-func (o *Provider) getVersionGateID(ctx context.Context, version string) (string, error) {
-	versionGates, err := o.versionGates(ctx)
-	if err != nil {
-		return "", fmt.Errorf("failed to get version gate id for version %q, %v", version, err)
-	}
-
-	for _, versionGate := range versionGates.Slice() {
-		if versionGate.VersionRawIDPrefix() == version && versionGate.Label() == versionGateLabel {
-			return versionGate.ID(), nil
-		}
-	}
-
-	return "", fmt.Errorf("no version gate exists for %q", version)
-}
-
-// getVersionGateAgreement returns the gate agreement ocm resource
-func (o *Provider) getVersionGateAgreement(ctx context.Context, versionGateID string) (*clustersmgmtv1.VersionGate, error) {
-	response, err := o.ClustersMgmt().V1().VersionGates().VersionGate(versionGateID).Get().SendContext(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed  to get version gate agreement %q, %v", versionGateID, err)
-	}
-
-	return response.Body(), nil
-}
-
-// gateAgreementExistForCluster checks to see if the version gate agreement id provided for the cluster already exists
-func (o *Provider) gateAgreementExistForCluster(ctx context.Context, clusterID, gateAgreementID string) (bool, error) {
+// gateAgreementsForCluster returns the ids of the version gates the cluster has acknowledged
+func (o *Provider) gateAgreementsForCluster(ctx context.Context, clusterID string) (map[string]bool, error) {
 	response, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).GateAgreements().List().SendContext(ctx)
 	if err != nil {
-		return false, fmt.Errorf("failed to get cluster %q version gate agreement: %v", clusterID, err)
+		return nil, fmt.Errorf("failed to get cluster %q version gate agreement: %v", clusterID, err)
 	}
 
+	acknowledged := map[string]bool{}
 	for _, gateAgreement := range response.Items().Slice() {
-		if gateAgreement.VersionGate().ID() == gateAgreementID {
-			log.Printf("Cluster gate agreement id: %s already exists", gateAgreementID)
-			return true, nil
-		}
+		acknowledged[gateAgreement.VersionGate().ID()] = true
 	}
 
-	return false, nil
+	return acknowledged, nil
 }
 
-// addGateAgreement adds a version gate agreement to the cluster ocm resource.
+// addGateAgreements adds a version gate agreement to the cluster ocm resource for each
+// unacknowledged version gate of the upgrade version the options allow.
 // Version gate agreement are used to acknowledge the cluster can be upgraded between versions
-func (o *Provider) addGateAgreement(ctx context.Context, clusterID string, currentVersion, upgradeVersion semver.Version) error {
+func (o *Provider) addGateAgreements(ctx context.Context, clusterID string, currentVersion, upgradeVersion semver.Version, options *UpgradeOptions) error {
 	if !(currentVersion.Minor() < upgradeVersion.Minor()) {
 		log.Println("No gate agreement is required for z-stream upgrade.")
 		return nil
@@ -104,56 +143,113 @@ func (o *Provider) addGateAgreement(ctx context.Context, clusterID string, curre
 
 	majorMinor := fmt.Sprintf("%d.%d", upgradeVersion.Major(), upgradeVersion.Minor())
 
-	versionGateID, err := o.getVersionGateID(ctx, majorMinor)
+	versionGates, err := o.versionGates(ctx)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to get version gates for version %q, %v", majorMinor, err)
 	}
 
-	exist, err := o.gateAgreementExistForCluster(ctx, clusterID, versionGateID)
+	response, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().SendContext(ctx)
 	if err != nil {
-		return err
-	}
-	if exist {
-		return nil
+		return fmt.Errorf("failed to get cluster %q: %v", clusterID, err)
 	}
+	sts := response.Body().AWS().STS().Enabled()
 
-	gateAgreement, err := o.getVersionGateAgreement(ctx, versionGateID)
+	acknowledged, err := o.gateAgreementsForCluster(ctx, clusterID)
 	if err != nil {
 		return err
 	}
 
-	versionGateAgreement, err := clustersmgmtv1.NewVersionGateAgreement().
-		VersionGate(clustersmgmtv1.NewVersionGate().Copy(gateAgreement)).
-		Build()
-	if err != nil {
-		return fmt.Errorf("failed to build version gate agreement for cluster %q, %v", clusterID, err)
+	var blocked []string
+
+	for _, versionGate := range versionGates.Slice() {
+		if versionGate.VersionRawIDPrefix() != majorMinor || (versionGate.STSOnly() && !sts) {
+			continue
+		}
+
+		if acknowledged[versionGate.ID()] {
+			log.Printf("Cluster gate agreement id: %s already exists", versionGate.ID())
+			continue
+		}
+
+		if !options.versionGateAllowed(versionGate.Label()) {
+			blocked = append(blocked, fmt.Sprintf("%s (%s)", versionGate.ID(), versionGate.Label()))
+			continue
+		}
+
+		versionGateAgreement, err := clustersmgmtv1.NewVersionGateAgreement().
+			VersionGate(clustersmgmtv1.NewVersionGate().Copy(versionGate)).
+			Build()
+		if err != nil {
+			return fmt.Errorf("failed to build version gate agreement for cluster %q, %v", clusterID, err)
+		}
+
+		_, err = o.ClustersMgmt().V1().Clusters().Cluster(clusterID).GateAgreements().Add().Body(versionGateAgreement).SendContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to apply version gate agreement %q to cluster %q, %v", versionGate.ID(), clusterID, err)
+		}
+
+		log.Printf("Cluster %q version gate %q (%s) acknowledged", clusterID, versionGate.ID(), versionGate.Label())
 	}
 
-	_, err = o.ClustersMgmt().V1().Clusters().Cluster(clusterID).GateAgreements().Add().Body(versionGateAgreement).SendContext(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to apply version gate agreement to cluster %q, %v", clusterID, err)
+	if len(blocked) > 0 {
+		return fmt.Errorf("cluster %q version gates for %q are not acknowledged and not allowed by the upgrade options: %s",
+			clusterID, majorMinor, strings.Join(blocked, ", "))
 	}
 
 	return nil
 }
 
 // initiateUpgrade initiates the upgrade for the cluster with ocm by applying a upgrade policy to the cluster
-func (o *Provider) initiateUpgrade(ctx context.Context, clusterID, version string) error {
-	upgradePolicy, err := clustersmgmtv1.NewUpgradePolicy().Version(version).
-		NextRun(time.Now().UTC().Add(7 * time.Minute)).
-		ScheduleType("manual").Build()
+// and returns the upgrade policy id
+func (o *Provider) initiateUpgrade(ctx context.Context, clusterID, version string, options *UpgradeOptions) (string, error) {
+	upgradePolicy, err := buildUpgradePolicy(version, options)
 	if err != nil {
-		return fmt.Errorf("failed to build upgrade policy for cluster %q, %v", clusterID, err)
+		return "", fmt.Errorf("failed to build upgrade policy for cluster %q, %v", clusterID, err)
 	}
 
 	response, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).UpgradePolicies().Add().Body(upgradePolicy).SendContext(ctx)
 	if err != nil || response.Status() != http.StatusCreated {
-		return fmt.Errorf("failed to apply upgrade policy to cluster %q, %v", clusterID, err)
+		return "", fmt.Errorf("failed to apply upgrade policy to cluster %q, %v", clusterID, err)
 	}
 
-	log.Printf("Cluster id %q upgrade to version %q has been scheduled for %s\n", clusterID, response.Body().Version(), response.Body().NextRun().Format(time.RFC3339))
+	if options.ScheduleType == automaticScheduleType {
+		log.Printf("Cluster id %q automatic upgrades have been scheduled (schedule=%q)\n", clusterID, options.Schedule)
+	} else {
+		log.Printf("Cluster id %q upgrade to version %q has been scheduled for %s\n", clusterID, response.Body().Version(), response.Body().NextRun().Format(time.RFC3339))
+	}
 
-	return nil
+	return response.Body().ID(), nil
+}
+
+// ScheduleUpgrade acknowledges the version gates the options allow and schedules the cluster upgrade with ocm
+// returning the upgrade policy id, use WaitForUpgradePolicy to wait for it to complete.
+// Undefined options (or nil) use the defaults, a manual upgrade starting in 7 minutes
+func (o *Provider) ScheduleUpgrade(ctx context.Context, clusterID string, currentVersion, upgradeVersion semver.Version, options *UpgradeOptions) (string, error) {
+	if options == nil {
+		options = &UpgradeOptions{}
+	}
+	options.setDefaults()
+
+	if err := options.validate(); err != nil {
+		return "", &upgradeError{err: fmt.Errorf("upgrade options validation failed: %v", err)}
+	}
+
+	if err := o.addGateAgreements(ctx, clusterID, currentVersion, upgradeVersion, options); err != nil {
+		return "", &upgradeError{err: err}
+	}
+
+	if options.NodeDrainGracePeriod > 0 {
+		if err := o.setNodeDrainGracePeriod(ctx, clusterID, options.NodeDrainGracePeriod); err != nil {
+			return "", &upgradeError{err: err}
+		}
+	}
+
+	policyID, err := o.initiateUpgrade(ctx, clusterID, upgradeVersion.String(), options)
+	if err != nil {
+		return "", &upgradeError{err: err}
+	}
+
+	return policyID, nil
 }
 
 // restartManagedUpgradeOperator scales down/up the muo operator to speed up the cluster upgrade start time
@@ -175,7 +271,17 @@ func (o *Provider) restartManagedUpgradeOperator(ctx context.Context, client *op
 	}
 
 	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: managedUpgradeOperatorDeploymentName, Namespace: managedUpgradeOperatorNamespace}}
-	err := wait.For(conditions.New(client.Resources).DeploymentConditionMatch(deployment, appsv1.DeploymentAvailable, corev1.ConditionTrue))
+	err := wait.PollUntilContextTimeout(ctx, upgradePollInterval, managedUpgradeOperatorAvailableTimeout, true, func(ctx context.Context) (bool, error) {
+		if err := client.Get(ctx, deployment.Name, deployment.Namespace, deployment); err != nil {
+			return false, nil
+		}
+		for _, condition := range deployment.Status.Conditions {
+			if condition.Type == appsv1.DeploymentAvailable && condition.Status == corev1.ConditionTrue {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
 	if err != nil {
 		return fmt.Errorf("failed to get managed upgrade operator deployment: %v", err)
 	}
@@ -207,37 +313,61 @@ func (o *Provider) restartManagedUpgradeOperator(ctx context.Context, client *op
 
 // managedUpgradeConfigExist waits/checks for the muo upgrade config to exist on the cluster
 func (o *Provider) managedUpgradeConfigExist(ctx context.Context, dynamicClient *dynamic.DynamicClient) error {
-	for i := 1; i <= 6; i++ {
+	err := wait.PollUntilContextTimeout(ctx, upgradeConfigPollInterval, upgradeConfigTimeout, true, func(ctx context.Context) (bool, error) {
 		upgradeConfig, err := getManagedUpgradeOperatorConfig(ctx, dynamicClient)
-		if err != nil || upgradeConfig == nil {
-			time.Sleep(30 * time.Second)
-			continue
-		}
-		return nil
+		return err == nil && upgradeConfig != nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("managed upgrade config does not exist the cluster: %v", err)
 	}
 
-	return fmt.Errorf("managed upgrade config does not exist the cluster")
+	return nil
 }
 
-// OCMUpgrade handles the end to end process to upgrade an openshift dedicated cluster
-func (o *Provider) OCMUpgrade(ctx context.Context, client *openshift.Client, clusterID string, currentVersion, upgradeVersion semver.Version) error {
-	var (
-		conditionMessage string
-		dynamicClient    *dynamic.DynamicClient
-		err              error
-		upgradeStatus    string
-	)
+// OCMUpgrade handles the end to end process to upgrade an openshift dedicated cluster, the
+// upgrade progress is reported to the upgrade progress handler (see WithUpgradeProgressHandler).
+// The upgrade result (timings, phases, final cluster version) is returned whether or not the upgrade succeeded.
+// Cancelling the context stops waiting for the upgrade, it does not cancel the upgrade itself.
+// Undefined options (or nil) use the defaults, see UpgradeOptions
+func (o *Provider) OCMUpgrade(ctx context.Context, client *openshift.Client, clusterID string, currentVersion, upgradeVersion semver.Version, options *UpgradeOptions) (*UpgradeResult, error) {
+	result := &UpgradeResult{
+		ClusterID:   clusterID,
+		FromVersion: currentVersion.String(),
+		ToVersion:   upgradeVersion.String(),
+	}
+
+	result.Start = o.events.PhaseStart("upgrade", clusterID, "")
+	err := o.ocmUpgrade(ctx, client, clusterID, currentVersion, upgradeVersion, options, result)
+	result.finish(ctx, client)
+	o.events.PhaseEnd("upgrade", clusterID, "", result.Start, err)
 
-	if dynamicClient, err = getKubernetesDynamicClient(client); err != nil {
-		return &upgradeError{err: err}
+	return result, err
+}
+
+// ocmUpgrade schedules the upgrade and waits for the managed upgrade operator to complete it,
+// recording the upgrade phases and checks to the result
+func (o *Provider) ocmUpgrade(ctx context.Context, client *openshift.Client, clusterID string, currentVersion, upgradeVersion semver.Version, options *UpgradeOptions, result *UpgradeResult) error {
+	if options == nil {
+		options = &UpgradeOptions{}
 	}
 
-	if err = o.addGateAgreement(ctx, clusterID, currentVersion, upgradeVersion); err != nil {
+	dynamicClient, err := getKubernetesDynamicClient(client)
+	if err != nil {
 		return &upgradeError{err: err}
 	}
 
-	if err = o.initiateUpgrade(ctx, clusterID, upgradeVersion.String()); err != nil {
-		return &upgradeError{err: err}
+	if len(options.PreUpgradeChecks) > 0 {
+		result.startPhase("PreUpgradeChecks", time.Now())
+		result.Checks = runUpgradeChecks(ctx, client, "pre-upgrade", options.PreUpgradeChecks)
+		if err = upgradeChecksError(result.Checks); err != nil {
+			return &upgradeError{err: err, checks: result.Checks}
+		}
+	}
+
+	result.startPhase("Schedule", time.Now())
+
+	if _, err = o.ScheduleUpgrade(ctx, clusterID, currentVersion, upgradeVersion, options); err != nil {
+		return err
 	}
 
 	if err = o.restartManagedUpgradeOperator(ctx, client); err != nil {
@@ -248,82 +378,133 @@ func (o *Provider) OCMUpgrade(ctx context.Context, client *openshift.Client, clu
 		return &upgradeError{err: err}
 	}
 
-	errorHandler := func(key string, found bool, err error) error {
-		if !found || err != nil {
-			log.Printf("Failed to retrieve %q from managed upgrade operator config: %v\n", key, err)
-			time.Sleep(10 * time.Second)
-			return err
+	if options.CapacityReservation != nil {
+		if err = setCapacityReservation(ctx, dynamicClient, *options.CapacityReservation); err != nil {
+			return &upgradeError{err: err}
 		}
-		return nil
 	}
 
-	for i := 1; i <= upgradeMaxAttempts; i++ {
-		upgradeConfig, err := getManagedUpgradeOperatorConfig(ctx, dynamicClient)
-		if err != nil {
-			log.Printf("Failed to get managed upgrade operator config: %v\n", err)
-			time.Sleep(upgradeDelay * time.Second)
-			continue
-		}
+	monitor := newDrainMonitor(options.StuckDrainThreshold)
 
-		status, found, err := unstructured.NestedMap(upgradeConfig.Object, "status")
-		if errorHandler("status", found, err) != nil {
-			continue
+	if err = o.waitForUpgrade(ctx, client, dynamicClient, clusterID, upgradeVersion.String(), monitor, result); err != nil {
+		diagnostics, diagnosticsErr := o.collectUpgradeDiagnostics(ctx, client, dynamicClient, clusterID, currentVersion.String(), upgradeVersion.String())
+		if diagnosticsErr != nil {
+			log.Printf("Failed to collect upgrade diagnostics: %v", diagnosticsErr)
 		}
+		return &upgradeError{err: err, diagnostics: diagnostics, checks: result.Checks}
+	}
 
-		histories, found, err := unstructured.NestedSlice(status, "history")
-		if errorHandler("status.history", found, err) != nil {
-			continue
+	if len(options.PostUpgradeChecks) > 0 {
+		result.startPhase("PostUpgradeChecks", time.Now())
+		result.Checks = append(result.Checks, runUpgradeChecks(ctx, client, "post-upgrade", options.PostUpgradeChecks)...)
+		if err = upgradeChecksError(result.Checks); err != nil {
+			return &upgradeError{err: err, checks: result.Checks}
 		}
+	}
 
-		for _, h := range histories {
-			version, found, err := unstructured.NestedString(h.(map[string]interface{}), "version")
-			if errorHandler("status.history.[].version", found, err) != nil {
-				continue
-			}
+	return nil
+}
 
-			if version == upgradeVersion.String() {
-				upgradeStatus, found, err = unstructured.NestedString(h.(map[string]interface{}), "phase")
-				if errorHandler("status.history.[].version.phase", found, err) != nil {
-					continue
-				}
+// waitForUpgrade waits for the managed upgrade operator to finish upgrading the cluster to the version,
+// the cluster version operators progress and the nodes stuck draining are included when the client and
+// drain monitor are provided and the managed upgrade operator phases are recorded when the result is provided
+func (o *Provider) waitForUpgrade(ctx context.Context, client *openshift.Client, dynamicClient dynamic.Interface, clusterID, version string, monitor *drainMonitor, result *UpgradeResult) error {
+	var previous UpgradeProgress
 
-				conditions, found, err := unstructured.NestedSlice(h.(map[string]interface{}), "conditions")
-				if (upgradeStatus == "Pending" && len(conditions) < 1) || len(conditions) < 1 {
-					break
-				}
-				if errorHandler("status.history.[].version.conditions", found, err) != nil {
-					continue
-				}
+	err := wait.PollUntilContextTimeout(ctx, upgradePollInterval, upgradeTimeout, true, func(ctx context.Context) (bool, error) {
+		upgradeConfig, err := getManagedUpgradeOperatorConfig(ctx, dynamicClient)
+		if err != nil || upgradeConfig == nil {
+			log.Printf("Failed to get managed upgrade operator config: %v", err)
+			return false, nil
+		}
 
-				conditionMessage, found, err = unstructured.NestedString(conditions[0].(map[string]interface{}), "message")
-				if errorHandler("status.history.[].version.message", found, err) != nil {
-					continue
-				}
+		progress := upgradeConfigProgress(upgradeConfig, version)
 
-				break
+		if client != nil && progress.Phase != "" {
+			if err = clusterVersionProgress(ctx, client, version, &progress); err != nil {
+				log.Printf("Failed to get cluster version progress: %v", err)
 			}
 		}
 
-		switch upgradeStatus {
-		case "":
-			log.Println("Upgrade has not started yet..")
-			time.Sleep(upgradeDelay * time.Second)
+		if client != nil && monitor != nil && progress.Phase == "Upgrading" {
+			o.reportStuckDrains(ctx, client, clusterID, monitor, progress.Time, result)
+		}
+
+		o.events.StateChange(clusterID, "", previous.Phase, progress.Phase)
+		if result != nil && progress.Phase != previous.Phase {
+			switch progress.Phase {
+			case "", "Upgraded", "Failed":
+				result.endPhase(progress.Time)
+			default:
+				result.startPhase(progress.Phase, progress.Time)
+			}
+		}
+		if progress.changed(previous) {
+			log.Printf("Upgrade progress: %s", progress)
+			if o.upgradeProgress != nil {
+				o.upgradeProgress(progress)
+			}
+		}
+		previous = progress
+
+		switch progress.Phase {
 		case "Failed":
-			log.Printf("Upgrade failed, %s\n", conditionMessage)
-			return &upgradeError{err: fmt.Errorf("upgrade failed")}
+			return false, fmt.Errorf("upgrade failed: %s", progress.Message)
 		case "Upgraded":
 			log.Printf("Upgrade complete!")
-			return nil
-		case "Pending":
-			log.Printf("Upgrade is pending")
-			time.Sleep(upgradeDelay * time.Second)
-		case "Upgrading":
-			log.Printf("Upgrade is in progress, %s\n", conditionMessage)
-			time.Sleep(upgradeDelay * time.Second)
+			return true, nil
 		}
+
+		return false, nil
+	})
+	if err != nil {
+		if previous.Phase != "Failed" {
+			err = fmt.Errorf("upgrade did not finish (last progress: %s): %v", previous, err)
+		}
+		return err
 	}
 
-	return fmt.Errorf("upgrade is still in progress, failed to finish within max wait attempts")
+	return nil
+}
+
+// upgradeConfigProgress returns the progress of the upgrade config history entry for the version
+func upgradeConfigProgress(upgradeConfig *UpgradeConfig, version string) UpgradeProgress {
+	progress := UpgradeProgress{Time: time.Now()}
+
+	if history := upgradeConfig.history(version); history != nil {
+		progress.Phase = history.Phase
+
+		completed := map[string]bool{}
+		var latest time.Time
+
+		for _, condition := range history.Conditions {
+			if condition.Status == corev1.ConditionTrue {
+				completed[condition.Type] = true
+			}
+
+			// conditions are ordered newest first, fall back to it when transition times are missing
+			if progress.Message == "" || condition.LastTransitionTime.After(latest) {
+				progress.Message = condition.Message
+				if !condition.LastTransitionTime.IsZero() {
+					latest = condition.LastTransitionTime.Time
+				}
+			}
+		}
+
+		done := 0
+		for _, step := range upgradeSteps {
+			if completed[step] {
+				done++
+			}
+		}
+		progress.Percent = 100 * done / len(upgradeSteps)
+	}
+
+	if progress.Phase == "Upgraded" {
+		progress.Percent = 100
+	}
+
+	return progress
 }
 
 // getKubernetesDynamicClient returns the kubernetes dynamic client
@@ -334,19 +515,3 @@ func getKubernetesDynamicClient(client *openshift.Client) (*dynamic.DynamicClien
 	}
 	return dynamicClient, nil
 }
-
-// getManagedUpgradeOperatorConfig returns the upgrade config object
-func getManagedUpgradeOperatorConfig(ctx context.Context, dynamicClient *dynamic.DynamicClient) (*unstructured.Unstructured, error) {
-	upgradeConfigs, err := dynamicClient.Resource(
-		schema.GroupVersionResource{
-			Group:    "upgrade.managed.openshift.io",
-			Version:  "v1alpha1",
-			Resource: "upgradeconfigs",
-		},
-	).Namespace(managedUpgradeOperatorNamespace).List(ctx, metav1.ListOptions{})
-	if err != nil || len(upgradeConfigs.Items) < 1 {
-		return nil, err
-	}
-
-	return &upgradeConfigs.Items[0], nil
-}
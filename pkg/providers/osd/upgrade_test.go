@@ -0,0 +1,154 @@
+package osd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/Masterminds/semver"
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osde2e-framework/pkg/clients/ocmfake"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var _ = Describe("Upgrade", func() {
+	upgradeConfig := func(phase string, conditions ...interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "upgrade.managed.openshift.io/v1alpha1",
+			"kind":       "UpgradeConfig",
+			"metadata":   map[string]interface{}{"name": "managed-upgrade-config", "namespace": managedUpgradeOperatorNamespace},
+			"status": map[string]interface{}{
+				"history": []interface{}{
+					map[string]interface{}{"version": "4.13.5", "phase": "Upgraded"},
+					map[string]interface{}{"version": "4.14.1", "phase": phase, "conditions": conditions},
+				},
+			},
+		}}
+	}
+
+	condition := func(conditionType, status, message, lastTransitionTime string) interface{} {
+		condition := map[string]interface{}{"type": conditionType, "status": status, "message": message}
+		if lastTransitionTime != "" {
+			condition["lastTransitionTime"] = lastTransitionTime
+		}
+		return condition
+	}
+
+	It("should report the upgrade progress of the version", func() {
+		progress := upgradeConfigProgress(mustNewUpgradeConfig(upgradeConfig("Upgrading",
+			condition("ControlPlaneUpgraded", "False", "control plane is upgrading", "2023-08-01T10:10:00Z"),
+			condition("CommenceUpgrade", "True", "upgrade commenced", "2023-08-01T10:05:00Z"),
+			condition("SendStartedNotification", "True", "started notification sent", "2023-08-01T10:00:00Z"),
+		)), "4.14.1")
+
+		Expect(progress.Phase).To(Equal("Upgrading"))
+		Expect(progress.Message).To(Equal("control plane is upgrading"))
+		Expect(progress.Percent).To(Equal(12))
+
+		Expect(upgradeConfigProgress(mustNewUpgradeConfig(upgradeConfig("Upgraded")), "4.13.5").Percent).To(Equal(100))
+		Expect(upgradeConfigProgress(mustNewUpgradeConfig(upgradeConfig("Upgrading")), "4.15.0").Phase).To(BeEmpty())
+	})
+
+	newDynamicClient := func(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+		return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			{Group: "upgrade.managed.openshift.io", Version: "v1alpha1", Resource: "upgradeconfigs"}: "UpgradeConfigList",
+		}, objects...)
+	}
+
+	It("should fail when the upgrade fails", func(ctx context.Context) {
+		var reported []UpgradeProgress
+		provider := &Provider{upgradeProgress: func(progress UpgradeProgress) { reported = append(reported, progress) }}
+
+		dynamicClient := newDynamicClient(upgradeConfig("Failed", condition("UpgradePreHealthCheck", "False", "cluster operators degraded", "")))
+
+		err := provider.waitForUpgrade(ctx, nil, dynamicClient, "abc", "4.14.1", nil, nil)
+		Expect(err).To(MatchError(ContainSubstring("cluster operators degraded")))
+		Expect(reported).To(HaveLen(1))
+		Expect(reported[0].Phase).To(Equal("Failed"))
+	})
+
+	It("should stop waiting when the context is cancelled", func(ctx context.Context) {
+		provider := &Provider{}
+		dynamicClient := newDynamicClient(upgradeConfig("Pending"))
+
+		ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err := provider.waitForUpgrade(ctx, nil, dynamicClient, "abc", "4.14.1", nil, nil)
+		Expect(err).To(MatchError(ContainSubstring("upgrade did not finish")))
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+	})
+})
+
+var _ = Describe("Version gates", func() {
+	var (
+		provider     *Provider
+		acknowledged []string
+	)
+
+	BeforeEach(func(ctx context.Context) {
+		acknowledged = nil
+
+		server := ocmfake.NewServer()
+		DeferCleanup(server.Close)
+
+		client, err := server.Client(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		provider = &Provider{Client: client}
+
+		cluster, err := clustersmgmtv1.NewCluster().ID("abc").Name("my-cluster").
+			AWS(clustersmgmtv1.NewAWS().STS(clustersmgmtv1.NewSTS().Enabled(true))).
+			Build()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(server.AddCluster(cluster)).To(Succeed())
+
+		server.Handle(http.MethodGet, "/api/clusters_mgmt/v1/version_gates", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"kind":"VersionGateList","page":1,"size":4,"total":4,"items":[
+				{"id":"ocp-4.14","version_raw_id_prefix":"4.14","label":"api.openshift.com/gate-ocp"},
+				{"id":"sts-4.14","version_raw_id_prefix":"4.14","label":"api.openshift.com/gate-sts","sts_only":true},
+				{"id":"acked-4.14","version_raw_id_prefix":"4.14","label":"api.openshift.com/gate-ocp"},
+				{"id":"ocp-4.15","version_raw_id_prefix":"4.15","label":"api.openshift.com/gate-ocp"}]}`)
+		})
+		server.Handle(http.MethodGet, "/api/clusters_mgmt/v1/clusters/abc/gate_agreements", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"kind":"VersionGateAgreementList","page":1,"size":1,"total":1,"items":[{"version_gate":{"id":"acked-4.14"}}]}`)
+		})
+		server.Handle(http.MethodPost, "/api/clusters_mgmt/v1/clusters/abc/gate_agreements", func(w http.ResponseWriter, r *http.Request) {
+			agreement, err := clustersmgmtv1.UnmarshalVersionGateAgreement(r.Body)
+			Expect(err).ShouldNot(HaveOccurred())
+			acknowledged = append(acknowledged, agreement.VersionGate().ID())
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{}`)
+		})
+	})
+
+	current, upgrade := semver.MustParse("4.13.10"), semver.MustParse("4.14.2")
+
+	It("should fail when the version has gates the options do not allow", func(ctx context.Context) {
+		err := provider.addGateAgreements(ctx, "abc", *current, *upgrade, &UpgradeOptions{})
+		Expect(err).To(MatchError(ContainSubstring("sts-4.14 (api.openshift.com/gate-sts)")))
+		Expect(acknowledged).To(Equal([]string{"ocp-4.14"}))
+	})
+
+	It("should acknowledge the unacknowledged gates the options allow", func(ctx context.Context) {
+		err := provider.addGateAgreements(ctx, "abc", *current, *upgrade, &UpgradeOptions{VersionGateLabels: []string{"api.openshift.com/gate-sts"}})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(acknowledged).To(Equal([]string{"ocp-4.14", "sts-4.14"}))
+	})
+
+	It("should not acknowledge gates for z-stream upgrades", func(ctx context.Context) {
+		err := provider.addGateAgreements(ctx, "abc", *current, *semver.MustParse("4.13.11"), &UpgradeOptions{AcknowledgeAllVersionGates: true})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(acknowledged).To(BeEmpty())
+	})
+})
@@ -0,0 +1,98 @@
+package osd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+	"github.com/openshift/osde2e-framework/pkg/healthcheck"
+)
+
+// UpgradeCheck is a health check run before the upgrade is scheduled or after it completes
+type UpgradeCheck struct {
+	Name  string
+	Check func(ctx context.Context, client *openshift.Client) error
+}
+
+// UpgradeCheckResult represents the outcome of an upgrade health check
+type UpgradeCheckResult struct {
+	Name string
+	// Stage is pre-upgrade or post-upgrade
+	Stage    string
+	Err      error
+	Duration time.Duration
+}
+
+// HealthCheck returns an upgrade check running the health check, e.g. to verify the cluster
+// operators are available before and after the upgrade
+//
+//	options := &osd.UpgradeOptions{
+//		PreUpgradeChecks:  []osd.UpgradeCheck{osd.HealthCheck(healthcheck.ClusterOperatorsAvailable, 10*time.Minute)},
+//		PostUpgradeChecks: []osd.UpgradeCheck{osd.HealthCheck(healthcheck.NodesReady, 30*time.Minute)},
+//	}
+func HealthCheck(check healthcheck.Check, timeout time.Duration) UpgradeCheck {
+	return UpgradeCheck{
+		Name: string(check),
+		Check: func(ctx context.Context, client *openshift.Client) error {
+			report, err := healthcheck.VerifyClient(ctx, client, "", &healthcheck.Policy{Checks: []healthcheck.Check{check}, Timeout: timeout})
+			if err != nil {
+				return err
+			}
+
+			if failed := report.Failed(); len(failed) > 0 {
+				return fmt.Errorf("%s", failed[0].Details)
+			}
+
+			return nil
+		},
+	}
+}
+
+// UpgradeCheckResults returns the results of the pre and post upgrade checks run by the failed upgrade
+func UpgradeCheckResults(err error) []UpgradeCheckResult {
+	var upgradeErr *upgradeError
+	if errors.As(err, &upgradeErr) {
+		return upgradeErr.checks
+	}
+	return nil
+}
+
+// runUpgradeChecks runs all the checks of the stage and returns their results, checks
+// are run even when previous checks failed so all failures are reported together
+func runUpgradeChecks(ctx context.Context, client *openshift.Client, stage string, checks []UpgradeCheck) []UpgradeCheckResult {
+	results := make([]UpgradeCheckResult, 0, len(checks))
+
+	for _, check := range checks {
+		log.Printf("Running %s check %q", stage, check.Name)
+
+		start := time.Now()
+		err := check.Check(ctx, client)
+		if err != nil {
+			log.Printf("%s check %q failed: %v", stage, check.Name, err)
+		}
+
+		results = append(results, UpgradeCheckResult{Name: check.Name, Stage: stage, Err: err, Duration: time.Since(start)})
+	}
+
+	return results
+}
+
+// upgradeChecksError returns an error describing the failed checks, nil when all checks passed
+func upgradeChecksError(results []UpgradeCheckResult) error {
+	var failures []string
+	for _, result := range results {
+		if result.Err != nil {
+			failures = append(failures, fmt.Sprintf("%s check %q: %v", result.Stage, result.Name, result.Err))
+		}
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%d upgrade check(s) failed: %s", len(failures), strings.Join(failures, "; "))
+}
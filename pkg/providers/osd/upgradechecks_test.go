@@ -0,0 +1,32 @@
+package osd
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+)
+
+var _ = Describe("Upgrade Checks", func() {
+	It("should run all checks and aggregate the failures", func(ctx context.Context) {
+		passing := UpgradeCheck{Name: "passing", Check: func(ctx context.Context, client *openshift.Client) error { return nil }}
+		failing := func(name string) UpgradeCheck {
+			return UpgradeCheck{Name: name, Check: func(ctx context.Context, client *openshift.Client) error {
+				return fmt.Errorf("%s is unhealthy", name)
+			}}
+		}
+
+		results := runUpgradeChecks(ctx, nil, "pre-upgrade", []UpgradeCheck{failing("dns"), passing, failing("console")})
+		Expect(results).To(HaveLen(3))
+		Expect(results[1].Err).ShouldNot(HaveOccurred())
+
+		err := &upgradeError{err: upgradeChecksError(results), checks: results}
+		Expect(err).To(MatchError(`osd upgrade failed: 2 upgrade check(s) failed: pre-upgrade check "dns": dns is unhealthy; pre-upgrade check "console": console is unhealthy`))
+		Expect(UpgradeCheckResults(fmt.Errorf("wrapped: %w", err))).To(Equal(results))
+
+		Expect(upgradeChecksError(results[1:2])).To(Succeed())
+	})
+})
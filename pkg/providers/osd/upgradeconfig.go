@@ -0,0 +1,108 @@
+package osd
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// upgradeConfigResource is the managed upgrade operator upgrade config resource
+var upgradeConfigResource = schema.GroupVersionResource{
+	Group:    "upgrade.managed.openshift.io",
+	Version:  "v1alpha1",
+	Resource: "upgradeconfigs",
+}
+
+// UpgradeConfig is the managed upgrade operator upgrade.managed.openshift.io/v1alpha1 UpgradeConfig,
+// only the fields used by the framework are included
+type UpgradeConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   UpgradeConfigSpec   `json:"spec"`
+	Status UpgradeConfigStatus `json:"status,omitempty"`
+}
+
+// UpgradeConfigSpec is the upgrade the managed upgrade operator performs and when
+type UpgradeConfigSpec struct {
+	Desired Update `json:"desired"`
+	// UpgradeAt is when the upgrade starts (RFC3339)
+	UpgradeAt string `json:"upgradeAt"`
+	// PDBForceDrainTimeout is how many minutes nodes respect pod disruption budgets before they are force drained
+	PDBForceDrainTimeout int32  `json:"PDBForceDrainTimeout"`
+	Type                 string `json:"type"`
+	CapacityReservation  bool   `json:"capacityReservation,omitempty"`
+}
+
+// Update is the version the cluster is upgraded to
+type Update struct {
+	Version string `json:"version,omitempty"`
+	Channel string `json:"channel,omitempty"`
+	Image   string `json:"image,omitempty"`
+}
+
+// UpgradeConfigStatus is the history of the upgrades the managed upgrade operator performed
+type UpgradeConfigStatus struct {
+	History []UpgradeHistory `json:"history,omitempty"`
+}
+
+// UpgradeHistory is the state of the upgrade to a version
+type UpgradeHistory struct {
+	Version string `json:"version,omitempty"`
+	// Phase is New, Pending, Upgrading, Upgraded or Failed
+	Phase string `json:"phase"`
+	// Conditions are the upgrade steps performed, ordered newest first
+	Conditions         []UpgradeCondition `json:"conditions,omitempty"`
+	StartTime          *metav1.Time       `json:"startTime,omitempty"`
+	CompleteTime       *metav1.Time       `json:"completeTime,omitempty"`
+	WorkerStartTime    *metav1.Time       `json:"workerStartTime,omitempty"`
+	WorkerCompleteTime *metav1.Time       `json:"workerCompleteTime,omitempty"`
+}
+
+// UpgradeCondition is the state of an upgrade step
+type UpgradeCondition struct {
+	Type               string                 `json:"type"`
+	Status             corev1.ConditionStatus `json:"status"`
+	LastProbeTime      metav1.Time            `json:"lastProbeTime,omitempty"`
+	LastTransitionTime metav1.Time            `json:"lastTransitionTime,omitempty"`
+	StartTime          *metav1.Time           `json:"startTime,omitempty"`
+	CompleteTime       *metav1.Time           `json:"completeTime,omitempty"`
+	Reason             string                 `json:"reason,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+}
+
+// history returns the history of the upgrade to the version, nil when it has not been attempted
+func (u *UpgradeConfig) history(version string) *UpgradeHistory {
+	for i := range u.Status.History {
+		if u.Status.History[i].Version == version {
+			return &u.Status.History[i]
+		}
+	}
+	return nil
+}
+
+// newUpgradeConfig converts the unstructured upgrade config, returning an error when a field
+// does not match the upgrade config type
+func newUpgradeConfig(object *unstructured.Unstructured) (*UpgradeConfig, error) {
+	var upgradeConfig UpgradeConfig
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, &upgradeConfig); err != nil {
+		return nil, fmt.Errorf("failed to convert upgrade config %q: %v", object.GetName(), err)
+	}
+	return &upgradeConfig, nil
+}
+
+// getManagedUpgradeOperatorConfig returns the upgrade config, nil when it does not exist
+func getManagedUpgradeOperatorConfig(ctx context.Context, dynamicClient dynamic.Interface) (*UpgradeConfig, error) {
+	upgradeConfigs, err := dynamicClient.Resource(upgradeConfigResource).Namespace(managedUpgradeOperatorNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil || len(upgradeConfigs.Items) < 1 {
+		return nil, err
+	}
+
+	return newUpgradeConfig(&upgradeConfigs.Items[0])
+}
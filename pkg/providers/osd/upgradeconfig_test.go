@@ -0,0 +1,80 @@
+package osd
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+// mustNewUpgradeConfig converts the unstructured upgrade config failing the spec when it is invalid
+func mustNewUpgradeConfig(object *unstructured.Unstructured) *UpgradeConfig {
+	upgradeConfig, err := newUpgradeConfig(object)
+	Expect(err).ShouldNot(HaveOccurred())
+	return upgradeConfig
+}
+
+var _ = Describe("Upgrade config", func() {
+	newUnstructuredUpgradeConfig := func(status map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "upgrade.managed.openshift.io/v1alpha1",
+			"kind":       "UpgradeConfig",
+			"metadata":   map[string]interface{}{"name": "managed-upgrade-config", "namespace": managedUpgradeOperatorNamespace},
+			"spec": map[string]interface{}{
+				"type":                 "OSD",
+				"upgradeAt":            "2023-11-02T02:00:00Z",
+				"PDBForceDrainTimeout": int64(60),
+				"desired":              map[string]interface{}{"version": "4.14.1", "channel": "stable-4.14"},
+			},
+			"status": status,
+		}}
+	}
+
+	newDynamicClient := func(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+		return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			upgradeConfigResource: "UpgradeConfigList",
+		}, objects...)
+	}
+
+	It("should get the typed upgrade config", func(ctx context.Context) {
+		dynamicClient := newDynamicClient(newUnstructuredUpgradeConfig(map[string]interface{}{
+			"history": []interface{}{
+				map[string]interface{}{"version": "4.14.1", "phase": "Upgrading", "startTime": "2023-11-02T02:00:10Z", "conditions": []interface{}{
+					map[string]interface{}{"type": "CommenceUpgrade", "status": "True", "message": "upgrade commenced", "lastTransitionTime": "2023-11-02T02:05:00Z"},
+				}},
+			},
+		}))
+
+		upgradeConfig, err := getManagedUpgradeOperatorConfig(ctx, dynamicClient)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(upgradeConfig.Name).To(Equal("managed-upgrade-config"))
+		Expect(upgradeConfig.Spec.Desired.Version).To(Equal("4.14.1"))
+		Expect(upgradeConfig.Spec.PDBForceDrainTimeout).To(BeEquivalentTo(60))
+
+		history := upgradeConfig.history("4.14.1")
+		Expect(history).ToNot(BeNil())
+		Expect(history.StartTime.UTC().Hour()).To(Equal(2))
+		Expect(history.Conditions).To(HaveLen(1))
+		Expect(history.Conditions[0].Status).To(Equal(corev1.ConditionTrue))
+		Expect(upgradeConfig.history("4.15.0")).To(BeNil())
+	})
+
+	It("should return nil when the upgrade config does not exist", func(ctx context.Context) {
+		upgradeConfig, err := getManagedUpgradeOperatorConfig(ctx, newDynamicClient())
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(upgradeConfig).To(BeNil())
+	})
+
+	It("should fail when the upgrade config does not match the type", func(ctx context.Context) {
+		dynamicClient := newDynamicClient(newUnstructuredUpgradeConfig(map[string]interface{}{"history": "Upgrading"}))
+
+		_, err := getManagedUpgradeOperatorConfig(ctx, dynamicClient)
+		Expect(err).To(MatchError(ContainSubstring("failed to convert upgrade config")))
+	})
+})
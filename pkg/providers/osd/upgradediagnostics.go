@@ -0,0 +1,199 @@
+package osd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+	"github.com/openshift/osde2e-framework/pkg/summary"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+	"sigs.k8s.io/yaml"
+)
+
+// upgradeEventNamespaces are the namespaces whose warning events are collected when an upgrade fails
+var upgradeEventNamespaces = []string{managedUpgradeOperatorNamespace, "openshift-cluster-version"}
+
+// UpgradeDiagnostics represents the state of the cluster collected when an upgrade fails
+type UpgradeDiagnostics struct {
+	// Dir is the directory the diagnostics bundle was written to
+	Dir string
+	// RolledBack is true when the cluster version was rolled back to the version upgraded from
+	RolledBack bool
+	// Version is the version the cluster reports once the upgrade failed
+	Version string
+	// Conditions are the upgrade config conditions of the upgrade (type=status: message)
+	Conditions []string
+}
+
+// String returns a human readable description of the diagnostics
+func (d *UpgradeDiagnostics) String() string {
+	description := fmt.Sprintf("diagnostics: %s", d.Dir)
+	if d.RolledBack {
+		description = fmt.Sprintf("cluster rolled back to %s, %s", d.Version, description)
+	}
+	return description
+}
+
+// UpgradeFailureDiagnostics returns the diagnostics collected for the failed upgrade, nil when
+// the error is not an upgrade failure or no diagnostics were collected
+func UpgradeFailureDiagnostics(err error) *UpgradeDiagnostics {
+	var upgradeErr *upgradeError
+	if errors.As(err, &upgradeErr) {
+		return upgradeErr.diagnostics
+	}
+	return nil
+}
+
+// collectUpgradeDiagnostics writes the upgrade config, cluster version, managed upgrade operator
+// logs and warning events to the diagnostics bundle directory, detecting whether the cluster
+// rolled back to the version upgraded from. Collection is best effort, failures are recorded in the bundle
+func (o *Provider) collectUpgradeDiagnostics(ctx context.Context, client *openshift.Client, dynamicClient dynamic.Interface, clusterID, fromVersion, toVersion string) (*UpgradeDiagnostics, error) {
+	diagnostics := &UpgradeDiagnostics{Dir: filepath.Join(o.ArtifactDir, fmt.Sprintf("%s-upgrade-diagnostics", clusterID))}
+
+	if err := os.MkdirAll(diagnostics.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create upgrade diagnostics directory: %v", err)
+	}
+
+	write := func(name string, content func() ([]byte, error)) {
+		data, err := content()
+		if err != nil {
+			data = []byte(fmt.Sprintf("failed to collect %s: %v\n", name, err))
+		}
+		_ = os.WriteFile(filepath.Join(diagnostics.Dir, name), data, 0o600)
+	}
+
+	write("upgradeconfig.yaml", func() ([]byte, error) {
+		upgradeConfig, err := getManagedUpgradeOperatorConfig(ctx, dynamicClient)
+		if err != nil || upgradeConfig == nil {
+			return nil, fmt.Errorf("upgrade config not found: %v", err)
+		}
+		diagnostics.Conditions = upgradeConfigConditions(upgradeConfig, toVersion)
+		return yaml.Marshal(upgradeConfig)
+	})
+
+	write("clusterversion.yaml", func() ([]byte, error) {
+		var clusterVersion configv1.ClusterVersion
+		if err := client.Get(ctx, "version", "", &clusterVersion); err != nil {
+			return nil, err
+		}
+
+		diagnostics.Version = clusterVersion.Status.Desired.Version
+		diagnostics.RolledBack = rolledBack(&clusterVersion, fromVersion, toVersion)
+
+		return yaml.Marshal(clusterVersion.Status)
+	})
+
+	write("managed-upgrade-operator.log", func() ([]byte, error) {
+		return managedUpgradeOperatorLogs(ctx, client)
+	})
+
+	write("events.log", func() ([]byte, error) {
+		return warningEvents(ctx, client.Resources)
+	})
+
+	summary.Global().Artifact(diagnostics.Dir)
+
+	return diagnostics, nil
+}
+
+// rolledBack returns true when the cluster version attempted the upgrade but now desires
+// the version it was upgraded from
+func rolledBack(clusterVersion *configv1.ClusterVersion, fromVersion, toVersion string) bool {
+	if clusterVersion.Status.Desired.Version != fromVersion {
+		return false
+	}
+
+	for _, history := range clusterVersion.Status.History {
+		if history.Version == toVersion {
+			return true
+		}
+	}
+
+	return false
+}
+
+// upgradeConfigConditions returns the conditions of the upgrade config history entry for the version
+func upgradeConfigConditions(upgradeConfig *UpgradeConfig, version string) []string {
+	history := upgradeConfig.history(version)
+	if history == nil {
+		return nil
+	}
+
+	conditions := make([]string, 0, len(history.Conditions))
+	for _, condition := range history.Conditions {
+		conditions = append(conditions, fmt.Sprintf("%s=%s: %s", condition.Type, condition.Status, condition.Message))
+	}
+
+	return conditions
+}
+
+// managedUpgradeOperatorLogs returns the logs of the managed upgrade operator pods
+func managedUpgradeOperatorLogs(ctx context.Context, client *openshift.Client) ([]byte, error) {
+	clientset, err := kubernetes.NewForConfig(client.GetConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct kubernetes client: %v", err)
+	}
+
+	pods, err := clientset.CoreV1().Pods(managedUpgradeOperatorNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("name=%s", managedUpgradeOperatorDeploymentName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s pods: %v", managedUpgradeOperatorDeploymentName, err)
+	}
+
+	var content strings.Builder
+	for _, pod := range pods.Items {
+		fmt.Fprintf(&content, "==> pod %s (%s) <==\n", pod.Name, pod.Status.Phase)
+
+		tailLines := int64(1000)
+		logs, err := clientset.CoreV1().Pods(managedUpgradeOperatorNamespace).GetLogs(pod.Name, &corev1.PodLogOptions{TailLines: &tailLines}).DoRaw(ctx)
+		if err != nil {
+			fmt.Fprintf(&content, "failed to get logs: %v\n", err)
+			continue
+		}
+
+		content.Write(logs)
+	}
+
+	return []byte(content.String()), nil
+}
+
+// warningEvents returns the warning events of the upgrade namespaces ordered by time
+func warningEvents(ctx context.Context, client *resources.Resources) ([]byte, error) {
+	var events []corev1.Event
+
+	for _, namespace := range upgradeEventNamespaces {
+		var list corev1.EventList
+		if err := client.WithNamespace(namespace).List(ctx, &list); err != nil {
+			return nil, fmt.Errorf("failed to list %s events: %v", namespace, err)
+		}
+
+		for _, event := range list.Items {
+			if event.Type == corev1.EventTypeWarning {
+				events = append(events, event)
+			}
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].LastTimestamp.Before(&events[j].LastTimestamp)
+	})
+
+	var content strings.Builder
+	for _, event := range events {
+		fmt.Fprintf(&content, "%s %s/%s %s: %s\n", event.LastTimestamp.UTC().Format("2006-01-02T15:04:05Z"),
+			event.Namespace, event.InvolvedObject.Name, event.Reason, event.Message)
+	}
+
+	return []byte(content.String()), nil
+}
@@ -0,0 +1,76 @@
+package osd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/osde2e-framework/pkg/clients/kubernetesfake"
+	ocmclient "github.com/openshift/osde2e-framework/pkg/clients/ocm"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var _ = Describe("Upgrade Diagnostics", func() {
+	clusterVersion := func(desired string, history ...string) *configv1.ClusterVersion {
+		clusterVersion := &configv1.ClusterVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: "version"},
+			Status:     configv1.ClusterVersionStatus{Desired: configv1.Release{Version: desired}},
+		}
+		for _, version := range history {
+			completed := metav1.Now()
+			clusterVersion.Status.History = append(clusterVersion.Status.History, configv1.UpdateHistory{Version: version, CompletionTime: &completed})
+		}
+		return clusterVersion
+	}
+
+	It("should detect the cluster rolled back to the version upgraded from", func() {
+		Expect(rolledBack(clusterVersion("4.13.5", "4.14.1", "4.13.5"), "4.13.5", "4.14.1")).To(BeTrue())
+		Expect(rolledBack(clusterVersion("4.14.1", "4.14.1", "4.13.5"), "4.13.5", "4.14.1")).To(BeFalse())
+		Expect(rolledBack(clusterVersion("4.13.5", "4.13.5"), "4.13.5", "4.14.1")).To(BeFalse())
+	})
+
+	It("should write the diagnostics bundle", func(ctx context.Context) {
+		server, err := kubernetesfake.NewServer(
+			clusterVersion("4.13.5", "4.14.1", "4.13.5"),
+			&corev1.Event{
+				ObjectMeta:     metav1.ObjectMeta{Name: "muo", Namespace: managedUpgradeOperatorNamespace},
+				InvolvedObject: corev1.ObjectReference{Name: "managed-upgrade-config"},
+				Type:           corev1.EventTypeWarning,
+				Reason:         "UpgradeFailed",
+				Message:        "cluster operators degraded",
+			},
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		DeferCleanup(server.Close)
+
+		client, err := server.Client()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			{Group: "upgrade.managed.openshift.io", Version: "v1alpha1", Resource: "upgradeconfigs"}: "UpgradeConfigList",
+		})
+
+		provider := &Provider{Client: &ocmclient.Client{ArtifactDir: GinkgoT().TempDir()}}
+
+		diagnostics, err := provider.collectUpgradeDiagnostics(ctx, client, dynamicClient, "abc", "4.13.5", "4.14.1")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(diagnostics.RolledBack).To(BeTrue())
+		Expect(diagnostics.Version).To(Equal("4.13.5"))
+
+		events, err := os.ReadFile(filepath.Join(diagnostics.Dir, "events.log"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(events)).To(ContainSubstring("UpgradeFailed: cluster operators degraded"))
+
+		Expect(filepath.Join(diagnostics.Dir, "clusterversion.yaml")).To(BeAnExistingFile())
+		Expect(UpgradeFailureDiagnostics(fmt.Errorf("wrapped: %w", &upgradeError{err: fmt.Errorf("failed"), diagnostics: diagnostics}))).To(Equal(diagnostics))
+	})
+})
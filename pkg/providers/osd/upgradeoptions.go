@@ -0,0 +1,163 @@
+package osd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	manualScheduleType    = "manual"
+	automaticScheduleType = "automatic"
+	defaultNextRunOffset  = 7 * time.Minute
+)
+
+// cronSchedulePattern matches cron expressions with five fields
+var cronSchedulePattern = regexp.MustCompile(`^[0-9*,/-]+( [0-9*,/-]+){4}$`)
+
+// UpgradeOptions represents data used to schedule and perform osd upgrades
+type UpgradeOptions struct {
+	// NextRunOffset is how long after scheduling the upgrade starts, defaults to 7 minutes
+	NextRunOffset time.Duration
+	// ScheduleType is manual (default), upgrading once at the next run, or automatic,
+	// upgrading to the latest version on the Schedule
+	ScheduleType string
+	// Schedule is the cron expression (minute hour day-of-month month day-of-week) automatic
+	// upgrades run on, use VerifyAutomaticUpgradeSchedule to verify the managed upgrade operator
+	// picked up the next scheduled upgrade
+	Schedule string
+	// NodeDrainGracePeriod is how long nodes are given to drain respecting pod disruption
+	// budgets before they are force drained, the cluster default is used when undefined
+	NodeDrainGracePeriod time.Duration
+	// CapacityReservation scales up extra worker nodes during the upgrade to preserve the
+	// clusters capacity, the managed upgrade operator default is used when undefined
+	CapacityReservation *bool
+	// StuckDrainThreshold is how long a node may be cordoned during the upgrade before it is
+	// reported as stuck draining (see UpgradeResult.StuckDrains), defaults to 30 minutes
+	StuckDrainThreshold time.Duration
+	// VersionGateLabels are the labels of the version gates (e.g. api.openshift.com/gate-sts)
+	// acknowledged for y-stream upgrades in addition to api.openshift.com/gate-ocp gates
+	VersionGateLabels []string
+	// AcknowledgeAllVersionGates acknowledges every version gate of the upgrade version
+	AcknowledgeAllVersionGates bool
+
+	// PreUpgradeChecks run before the upgrade is scheduled, the upgrade is not scheduled when any fail
+	PreUpgradeChecks []UpgradeCheck
+	// PostUpgradeChecks run once the upgrade completes, the upgrade fails when any fail
+	PostUpgradeChecks []UpgradeCheck
+}
+
+// setDefaults sets the upgrade option defaults for undefined fields
+func (u *UpgradeOptions) setDefaults() {
+	if u.NextRunOffset == 0 {
+		u.NextRunOffset = defaultNextRunOffset
+	}
+
+	if u.ScheduleType == "" {
+		u.ScheduleType = manualScheduleType
+	}
+}
+
+// validate verifies the upgrade options are consistent
+func (u *UpgradeOptions) validate() error {
+	switch {
+	case u.ScheduleType != manualScheduleType && u.ScheduleType != automaticScheduleType:
+		return fmt.Errorf("schedule type %q is invalid, must be %s or %s", u.ScheduleType, manualScheduleType, automaticScheduleType)
+	case u.ScheduleType == automaticScheduleType && u.Schedule == "":
+		return fmt.Errorf("schedule is required for automatic upgrades")
+	case u.ScheduleType == manualScheduleType && u.Schedule != "":
+		return fmt.Errorf("schedule is only supported for automatic upgrades")
+	case u.ScheduleType == automaticScheduleType && !cronSchedulePattern.MatchString(u.Schedule):
+		return fmt.Errorf("schedule %q is not a cron expression (minute hour day-of-month month day-of-week)", u.Schedule)
+	case u.NodeDrainGracePeriod < 0 || u.NodeDrainGracePeriod%time.Minute != 0:
+		return fmt.Errorf("node drain grace period must be a positive number of minutes")
+	case u.NextRunOffset < 0:
+		return fmt.Errorf("next run offset must not be negative")
+	case u.StuckDrainThreshold < 0:
+		return fmt.Errorf("stuck drain threshold must not be negative")
+	}
+
+	return nil
+}
+
+// versionGateAllowed returns true when the options allow acknowledging version gates with the label
+func (u *UpgradeOptions) versionGateAllowed(label string) bool {
+	if u.AcknowledgeAllVersionGates || label == versionGateLabel {
+		return true
+	}
+
+	for _, allowed := range u.VersionGateLabels {
+		if allowed == label {
+			return true
+		}
+	}
+
+	return false
+}
+
+// buildUpgradePolicy builds the ocm upgrade policy for the version from the options
+func buildUpgradePolicy(version string, options *UpgradeOptions) (*clustersmgmtv1.UpgradePolicy, error) {
+	upgradePolicyBuilder := clustersmgmtv1.NewUpgradePolicy().
+		Version(version).
+		ScheduleType(options.ScheduleType)
+
+	if options.ScheduleType == automaticScheduleType {
+		upgradePolicyBuilder = upgradePolicyBuilder.Schedule(options.Schedule)
+	} else {
+		upgradePolicyBuilder = upgradePolicyBuilder.NextRun(time.Now().UTC().Add(options.NextRunOffset))
+	}
+
+	upgradePolicy, err := upgradePolicyBuilder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build upgrade policy: %v", err)
+	}
+
+	return upgradePolicy, nil
+}
+
+// setNodeDrainGracePeriod sets how long the clusters nodes are given to drain during upgrades
+func (o *Provider) setNodeDrainGracePeriod(ctx context.Context, clusterID string, gracePeriod time.Duration) error {
+	cluster, err := clustersmgmtv1.NewCluster().
+		NodeDrainGracePeriod(clustersmgmtv1.NewValue().Unit("minutes").Value(gracePeriod.Minutes())).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to build cluster node drain grace period: %v", err)
+	}
+
+	_, err = o.ClustersMgmt().V1().Clusters().Cluster(clusterID).Update().Body(cluster).SendContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to set cluster %q node drain grace period: %v", clusterID, err)
+	}
+
+	return nil
+}
+
+// setCapacityReservation sets whether the managed upgrade operator reserves capacity during the upgrade
+func setCapacityReservation(ctx context.Context, dynamicClient dynamic.Interface, capacityReservation bool) error {
+	upgradeConfig, err := getManagedUpgradeOperatorConfig(ctx, dynamicClient)
+	if err != nil || upgradeConfig == nil {
+		return fmt.Errorf("failed to get managed upgrade operator config: %v", err)
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{"capacityReservation": capacityReservation},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build capacity reservation patch: %v", err)
+	}
+
+	_, err = dynamicClient.Resource(upgradeConfigResource).Namespace(managedUpgradeOperatorNamespace).
+		Patch(ctx, upgradeConfig.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to set upgrade config capacity reservation: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package osd
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var _ = Describe("Upgrade Options", func() {
+	It("should default to a manual upgrade starting in 7 minutes", func() {
+		options := &UpgradeOptions{}
+		options.setDefaults()
+		Expect(options.validate()).To(Succeed())
+
+		upgradePolicy, err := buildUpgradePolicy("4.14.1", options)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(upgradePolicy.ScheduleType()).To(Equal("manual"))
+		Expect(upgradePolicy.NextRun()).To(BeTemporally("~", time.Now().Add(7*time.Minute), time.Minute))
+	})
+
+	It("should schedule automatic upgrades using the schedule", func() {
+		options := &UpgradeOptions{ScheduleType: "automatic", Schedule: "0 2 * * *"}
+		options.setDefaults()
+		Expect(options.validate()).To(Succeed())
+
+		upgradePolicy, err := buildUpgradePolicy("4.14.1", options)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(upgradePolicy.Schedule()).To(Equal("0 2 * * *"))
+	})
+
+	It("should reject invalid options", func() {
+		Expect((&UpgradeOptions{ScheduleType: "weekly"}).validate()).ToNot(Succeed())
+		Expect((&UpgradeOptions{ScheduleType: "automatic"}).validate()).ToNot(Succeed())
+		Expect((&UpgradeOptions{ScheduleType: "manual", Schedule: "0 2 * * *"}).validate()).ToNot(Succeed())
+		Expect((&UpgradeOptions{ScheduleType: "manual", NodeDrainGracePeriod: 90 * time.Second}).validate()).ToNot(Succeed())
+	})
+
+	It("should set the upgrade config capacity reservation", func(ctx context.Context) {
+		upgradeConfig := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "upgrade.managed.openshift.io/v1alpha1",
+			"kind":       "UpgradeConfig",
+			"metadata":   map[string]interface{}{"name": "managed-upgrade-config", "namespace": managedUpgradeOperatorNamespace},
+			"spec":       map[string]interface{}{"capacityReservation": true},
+		}}
+		dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), upgradeConfig)
+
+		Expect(setCapacityReservation(ctx, dynamicClient, false)).To(Succeed())
+
+		updated, err := dynamicClient.Resource(upgradeConfigResource).Namespace(managedUpgradeOperatorNamespace).
+			Get(ctx, "managed-upgrade-config", metav1.GetOptions{})
+		Expect(err).ShouldNot(HaveOccurred())
+		capacityReservation, _, _ := unstructured.NestedBool(updated.Object, "spec", "capacityReservation")
+		Expect(capacityReservation).To(BeFalse())
+	})
+})
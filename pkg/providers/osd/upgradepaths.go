@@ -0,0 +1,85 @@
+package osd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver"
+)
+
+// UpgradeStream filters the upgrade paths returned by AvailableUpgrades
+type UpgradeStream string
+
+const (
+	// AllStreams returns every available upgrade
+	AllStreams UpgradeStream = ""
+	// YStream returns upgrades to a newer minor release (e.g. 4.13.x -> 4.14.x)
+	YStream UpgradeStream = "y"
+	// ZStream returns upgrades within the clusters minor release (e.g. 4.13.4 -> 4.13.10)
+	ZStream UpgradeStream = "z"
+)
+
+// versionsError represents the custom error
+type versionsError struct {
+	err error
+}
+
+// Error returns the formatted error message when versionsError is invoked
+func (v *versionsError) Error() string {
+	return fmt.Sprintf("get versions failed: %v", v.err)
+}
+
+// AvailableUpgrades returns the clusters current version and the versions ocm reports it can
+// be upgraded to sorted from oldest to newest, optionally filtered to y-stream or z-stream upgrades
+//
+//	current, upgrades, err := provider.AvailableUpgrades(ctx, clusterID, osd.ZStream)
+//	Expect(err).ShouldNot(HaveOccurred())
+//	Expect(upgrades).ShouldNot(BeEmpty())
+//	result, err := provider.OCMUpgrade(ctx, client, clusterID, *current, *upgrades[len(upgrades)-1], nil)
+func (o *Provider) AvailableUpgrades(ctx context.Context, clusterID string, stream UpgradeStream) (*semver.Version, []*semver.Version, error) {
+	switch stream {
+	case AllStreams, YStream, ZStream:
+	default:
+		return nil, nil, &versionsError{err: fmt.Errorf("upgrade stream %q is invalid, must be %q or %q", stream, YStream, ZStream)}
+	}
+
+	response, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().SendContext(ctx)
+	if err != nil {
+		return nil, nil, &versionsError{err: fmt.Errorf("failed to get cluster %q: %v", clusterID, err)}
+	}
+
+	cluster := response.Body()
+
+	rawVersion := cluster.Version().RawID()
+	if rawVersion == "" {
+		rawVersion = strings.TrimPrefix(cluster.Version().ID(), "openshift-v")
+	}
+
+	current, err := semver.NewVersion(rawVersion)
+	if err != nil {
+		return nil, nil, &versionsError{err: fmt.Errorf("failed to parse cluster %q version %q: %v", clusterID, rawVersion, err)}
+	}
+
+	var upgrades []*semver.Version
+	for _, availableUpgrade := range cluster.Version().AvailableUpgrades() {
+		upgrade, err := semver.NewVersion(availableUpgrade)
+		if err != nil {
+			return nil, nil, &versionsError{err: fmt.Errorf("failed to parse cluster %q available upgrade %q: %v", clusterID, availableUpgrade, err)}
+		}
+
+		sameMinor := upgrade.Major() == current.Major() && upgrade.Minor() == current.Minor()
+		if (stream == YStream && sameMinor) || (stream == ZStream && !sameMinor) {
+			continue
+		}
+
+		upgrades = append(upgrades, upgrade)
+	}
+
+	sort.Slice(upgrades, func(i, j int) bool {
+		return upgrades[i].LessThan(upgrades[j])
+	})
+
+	return current, upgrades, nil
+}
@@ -0,0 +1,57 @@
+package osd
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/Masterminds/semver"
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osde2e-framework/pkg/clients/ocmfake"
+)
+
+var _ = Describe("AvailableUpgrades", func() {
+	var provider *Provider
+
+	BeforeEach(func(ctx context.Context) {
+		server := ocmfake.NewServer()
+		DeferCleanup(server.Close)
+
+		client, err := server.Client(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		provider = &Provider{Client: client}
+
+		cluster, err := clustersmgmtv1.NewCluster().ID("abc").Name("my-cluster").
+			Version(clustersmgmtv1.NewVersion().ID("openshift-v4.13.4").RawID("4.13.4").
+				AvailableUpgrades("4.14.2", "4.13.10", "4.14.1", "4.13.5")).
+			Build()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(server.AddCluster(cluster)).To(Succeed())
+	})
+
+	versions := func(upgrades []*semver.Version) []string {
+		var ids []string
+		for _, upgrade := range upgrades {
+			ids = append(ids, upgrade.String())
+		}
+		return ids
+	}
+
+	DescribeTable("should return the upgrades for the stream",
+		func(ctx context.Context, stream UpgradeStream, expected []string) {
+			current, upgrades, err := provider.AvailableUpgrades(ctx, "abc", stream)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(current.String()).To(Equal("4.13.4"))
+			Expect(versions(upgrades)).To(Equal(expected))
+		},
+		Entry("all", AllStreams, []string{"4.13.5", "4.13.10", "4.14.1", "4.14.2"}),
+		Entry("y-stream", YStream, []string{"4.14.1", "4.14.2"}),
+		Entry("z-stream", ZStream, []string{"4.13.5", "4.13.10"}),
+	)
+
+	It("should reject an invalid stream", func(ctx context.Context) {
+		_, _, err := provider.AvailableUpgrades(ctx, "abc", "x")
+		Expect(err).To(MatchError(ContainSubstring("is invalid")))
+	})
+})
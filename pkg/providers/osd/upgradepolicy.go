@@ -0,0 +1,201 @@
+package osd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const defaultUpgradePolicyPollInterval = 30 * time.Second
+
+// UpgradePolicyState represents the state of an ocm upgrade policy
+type UpgradePolicyState string
+
+const (
+	UpgradePolicyPending   UpgradePolicyState = "pending"
+	UpgradePolicyScheduled UpgradePolicyState = "scheduled"
+	UpgradePolicyStarted   UpgradePolicyState = "started"
+	UpgradePolicyDelayed   UpgradePolicyState = "delayed"
+	UpgradePolicyFailed    UpgradePolicyState = "failed"
+	UpgradePolicyCompleted UpgradePolicyState = "completed"
+	UpgradePolicyCancelled UpgradePolicyState = "cancelled"
+)
+
+// Terminal returns true when the upgrade policy will no longer change state
+func (s UpgradePolicyState) Terminal() bool {
+	switch s {
+	case UpgradePolicyFailed, UpgradePolicyCompleted, UpgradePolicyCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// UpgradePolicyStatus represents the current state of an upgrade policy and the reason for it
+type UpgradePolicyStatus struct {
+	PolicyID    string
+	State       UpgradePolicyState
+	Description string
+}
+
+// UpgradePolicyTransition represents an upgrade policy moving from one state to another
+type UpgradePolicyTransition struct {
+	From   UpgradePolicyState
+	To     UpgradePolicyState
+	Reason string
+	Time   time.Time
+}
+
+// String returns a human readable description of the transition
+func (t UpgradePolicyTransition) String() string {
+	if t.Reason == "" {
+		return fmt.Sprintf("%s -> %s", t.From, t.To)
+	}
+	return fmt.Sprintf("%s -> %s: %s", t.From, t.To, t.Reason)
+}
+
+// UpgradePolicyStatus returns the current state of the clusters upgrade policy
+func (o *Provider) UpgradePolicyStatus(ctx context.Context, clusterID, policyID string) (*UpgradePolicyStatus, error) {
+	response, err := o.ClustersMgmt().V1().Clusters().Cluster(clusterID).
+		UpgradePolicies().UpgradePolicy(policyID).State().Get().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster %q upgrade policy %q state: %v", clusterID, policyID, err)
+	}
+
+	return &UpgradePolicyStatus{
+		PolicyID:    policyID,
+		State:       UpgradePolicyState(response.Body().Value()),
+		Description: response.Body().Description(),
+	}, nil
+}
+
+// UpgradePolicyWatcher polls an upgrade policy and records each state transition
+type UpgradePolicyWatcher struct {
+	// Interval is the time to wait between polling the upgrade policy state
+	Interval time.Duration
+
+	status func(ctx context.Context) (*UpgradePolicyStatus, error)
+
+	mu          sync.Mutex
+	current     *UpgradePolicyStatus
+	transitions []UpgradePolicyTransition
+}
+
+// NewUpgradePolicyWatcher returns a watcher for the clusters upgrade policy
+func (o *Provider) NewUpgradePolicyWatcher(clusterID, policyID string) *UpgradePolicyWatcher {
+	return &UpgradePolicyWatcher{
+		Interval: defaultUpgradePolicyPollInterval,
+		status: func(ctx context.Context) (*UpgradePolicyStatus, error) {
+			return o.UpgradePolicyStatus(ctx, clusterID, policyID)
+		},
+	}
+}
+
+// Watch polls the upgrade policy and emits each state transition on the returned
+// channel. The channel is closed once the policy reaches a terminal state or the
+// context is done. Failures to poll the state are logged and retried
+func (w *UpgradePolicyWatcher) Watch(ctx context.Context) <-chan UpgradePolicyTransition {
+	transitions := make(chan UpgradePolicyTransition)
+
+	go func() {
+		defer close(transitions)
+
+		ticker := time.NewTicker(w.Interval)
+		defer ticker.Stop()
+
+		for {
+			status, err := w.status(ctx)
+			if err != nil {
+				log.Printf("Failed to poll upgrade policy state: %v", err)
+			} else if transition, changed := w.observe(status); changed {
+				select {
+				case transitions <- transition:
+				case <-ctx.Done():
+					return
+				}
+				if status.State.Terminal() {
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return transitions
+}
+
+// Wait watches the upgrade policy until it reaches a terminal state, returning an
+// error when the policy failed, was cancelled or the context is done first
+func (w *UpgradePolicyWatcher) Wait(ctx context.Context) (*UpgradePolicyStatus, error) {
+	for transition := range w.Watch(ctx) {
+		log.Printf("Upgrade policy state changed %s", transition)
+	}
+
+	status := w.Status()
+	switch {
+	case status == nil || !status.State.Terminal():
+		return status, fmt.Errorf("upgrade policy did not reach a terminal state: %v", ctx.Err())
+	case status.State != UpgradePolicyCompleted:
+		return status, fmt.Errorf("upgrade policy %s: %s", status.State, status.Description)
+	}
+
+	return status, nil
+}
+
+// Status returns the last observed upgrade policy status, nil when none has been observed
+func (w *UpgradePolicyWatcher) Status() *UpgradePolicyStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current
+}
+
+// Transitions returns the state transitions observed so far
+func (w *UpgradePolicyWatcher) Transitions() []UpgradePolicyTransition {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]UpgradePolicyTransition(nil), w.transitions...)
+}
+
+// observe records the status returning the transition when the state changed
+func (w *UpgradePolicyWatcher) observe(status *UpgradePolicyStatus) (UpgradePolicyTransition, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	previous := w.current
+	w.current = status
+
+	if previous != nil && previous.State == status.State {
+		return UpgradePolicyTransition{}, false
+	}
+
+	var from UpgradePolicyState
+	if previous != nil {
+		from = previous.State
+	}
+
+	transition := UpgradePolicyTransition{From: from, To: status.State, Reason: status.Description, Time: time.Now()}
+	w.transitions = append(w.transitions, transition)
+
+	return transition, true
+}
+
+// WaitForUpgradePolicy waits for the clusters upgrade policy to complete using only
+// ocm, for callers without access to the cluster itself
+func (o *Provider) WaitForUpgradePolicy(ctx context.Context, clusterID, policyID string, timeout time.Duration) (*UpgradePolicyStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	status, err := o.NewUpgradePolicyWatcher(clusterID, policyID).Wait(ctx)
+	if err != nil {
+		return status, &upgradeError{err: err}
+	}
+
+	return status, nil
+}
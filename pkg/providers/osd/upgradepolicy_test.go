@@ -0,0 +1,76 @@
+package osd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeUpgradePolicyStatuses returns a status func that replays the states in order
+func fakeUpgradePolicyStatuses(states ...UpgradePolicyState) func(ctx context.Context) (*UpgradePolicyStatus, error) {
+	i := 0
+	return func(ctx context.Context) (*UpgradePolicyStatus, error) {
+		state := states[i]
+		if i < len(states)-1 {
+			i++
+		}
+		if state == "" {
+			return nil, fmt.Errorf("ocm unavailable")
+		}
+		return &UpgradePolicyStatus{PolicyID: "policy", State: state, Description: string(state) + " reason"}, nil
+	}
+}
+
+var _ = Describe("UpgradePolicyWatcher", func() {
+	It("should emit each state transition until completed", func(ctx context.Context) {
+		watcher := &UpgradePolicyWatcher{
+			Interval: time.Millisecond,
+			status: fakeUpgradePolicyStatuses(
+				UpgradePolicyPending, UpgradePolicyPending, "", UpgradePolicyScheduled,
+				UpgradePolicyStarted, UpgradePolicyStarted, UpgradePolicyCompleted,
+			),
+		}
+
+		status, err := watcher.Wait(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(status.State).To(Equal(UpgradePolicyCompleted))
+
+		var states []UpgradePolicyState
+		for _, transition := range watcher.Transitions() {
+			states = append(states, transition.To)
+		}
+		Expect(states).To(Equal([]UpgradePolicyState{
+			UpgradePolicyPending, UpgradePolicyScheduled, UpgradePolicyStarted, UpgradePolicyCompleted,
+		}))
+		Expect(watcher.Transitions()[1].From).To(Equal(UpgradePolicyPending))
+		Expect(watcher.Transitions()[1].Reason).To(Equal("scheduled reason"))
+	})
+
+	It("should return an error when the policy fails", func(ctx context.Context) {
+		watcher := &UpgradePolicyWatcher{
+			Interval: time.Millisecond,
+			status:   fakeUpgradePolicyStatuses(UpgradePolicyStarted, UpgradePolicyFailed),
+		}
+
+		status, err := watcher.Wait(ctx)
+		Expect(err).To(MatchError(ContainSubstring("failed reason")))
+		Expect(status.State).To(Equal(UpgradePolicyFailed))
+	})
+
+	It("should stop when the context is done", func(ctx context.Context) {
+		watcher := &UpgradePolicyWatcher{
+			Interval: time.Millisecond,
+			status:   fakeUpgradePolicyStatuses(UpgradePolicyDelayed),
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+
+		status, err := watcher.Wait(ctx)
+		Expect(err).To(HaveOccurred())
+		Expect(status.State).To(Equal(UpgradePolicyDelayed))
+	})
+})
@@ -0,0 +1,72 @@
+package osd
+
+import (
+	"context"
+	"log"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+)
+
+// UpgradeResult represents the outcome of an osd upgrade, it is returned whether or not the upgrade succeeded
+type UpgradeResult struct {
+	ClusterID   string
+	FromVersion string
+	ToVersion   string
+	Start       time.Time
+	End         time.Time
+	Duration    time.Duration
+	// Phases are the upgrade phases in the order they started, the managed upgrade operator
+	// phases (e.g. Pending, Upgrading) are recorded as they are observed
+	Phases []UpgradePhase
+	// Checks are the results of the pre and post upgrade checks run
+	Checks []UpgradeCheckResult
+	// StuckDrains are the nodes reported as stuck draining during the upgrade
+	StuckDrains []StuckDrain
+	// ClusterVersion is the clusters version once the upgrade finished, nil when it could not be retrieved
+	ClusterVersion *configv1.ClusterVersion
+}
+
+// UpgradePhase represents a phase of the upgrade
+type UpgradePhase struct {
+	Name  string
+	Start time.Time
+	// End is unset when the upgrade finished before the phase ended
+	End time.Time
+}
+
+// Duration returns how long the phase took, zero when it did not end
+func (p UpgradePhase) Duration() time.Duration {
+	if p.End.IsZero() {
+		return 0
+	}
+	return p.End.Sub(p.Start)
+}
+
+// startPhase ends the current phase and starts the named phase
+func (r *UpgradeResult) startPhase(name string, at time.Time) {
+	r.endPhase(at)
+	r.Phases = append(r.Phases, UpgradePhase{Name: name, Start: at})
+}
+
+// endPhase ends the current phase
+func (r *UpgradeResult) endPhase(at time.Time) {
+	if len(r.Phases) > 0 && r.Phases[len(r.Phases)-1].End.IsZero() {
+		r.Phases[len(r.Phases)-1].End = at
+	}
+}
+
+// finish records the end of the upgrade and the clusters version, the cluster version is best effort
+func (r *UpgradeResult) finish(ctx context.Context, client *openshift.Client) {
+	r.End = time.Now()
+	r.Duration = r.End.Sub(r.Start)
+	r.endPhase(r.End)
+
+	var clusterVersion configv1.ClusterVersion
+	if err := client.Get(ctx, "version", "", &clusterVersion); err != nil {
+		log.Printf("Failed to get cluster %q version after the upgrade: %v", r.ClusterID, err)
+		return
+	}
+	r.ClusterVersion = &clusterVersion
+}
@@ -0,0 +1,44 @@
+package osd
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/osde2e-framework/pkg/clients/kubernetesfake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Upgrade result", func() {
+	It("should record the upgrade phases and the final cluster version", func(ctx context.Context) {
+		server, err := kubernetesfake.NewServer(&configv1.ClusterVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: "version"},
+			Status:     configv1.ClusterVersionStatus{Desired: configv1.Release{Version: "4.14.1"}},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		DeferCleanup(server.Close)
+
+		client, err := server.Client()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		start := time.Now()
+		result := &UpgradeResult{ClusterID: "abc", Start: start}
+
+		result.startPhase("Schedule", start)
+		result.startPhase("Pending", start.Add(time.Minute))
+		result.startPhase("Upgrading", start.Add(5*time.Minute))
+		result.endPhase(start.Add(50 * time.Minute))
+		result.startPhase("PostUpgradeChecks", start.Add(51*time.Minute))
+		result.finish(ctx, client)
+
+		Expect(result.Phases).To(HaveLen(4))
+		Expect(result.Phases[0].Duration()).To(Equal(time.Minute))
+		Expect(result.Phases[2].Duration()).To(Equal(45 * time.Minute))
+		Expect(result.Phases[3].End).To(Equal(result.End))
+		Expect(result.Duration).To(Equal(result.End.Sub(start)))
+		Expect(result.ClusterVersion.Status.Desired.Version).To(Equal("4.14.1"))
+	})
+})
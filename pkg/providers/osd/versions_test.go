@@ -0,0 +1,73 @@
+package osd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/osde2e-framework/pkg/clients/ocmfake"
+)
+
+var _ = Describe("Versions", func() {
+	var (
+		server   *ocmfake.Server
+		provider *Provider
+		search   string
+	)
+
+	BeforeEach(func(ctx context.Context) {
+		server = ocmfake.NewServer()
+		DeferCleanup(server.Close)
+
+		client, err := server.Client(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		provider = &Provider{Client: client}
+
+		server.Handle(http.MethodGet, "/api/clusters_mgmt/v1/versions", func(w http.ResponseWriter, r *http.Request) {
+			search = r.URL.Query().Get("search")
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"kind":"VersionList","page":1,"size":4,"total":4,"items":[
+				{"id":"openshift-v4.14.2","raw_id":"4.14.2","channel_group":"stable","rosa_enabled":true,"hosted_control_plane_enabled":true},
+				{"id":"openshift-v4.13.10","raw_id":"4.13.10","channel_group":"stable","default":true,"rosa_enabled":true},
+				{"id":"openshift-v4.13.4","raw_id":"4.13.4","channel_group":"stable"},
+				{"id":"openshift-v4.14.1","raw_id":"4.14.1","channel_group":"stable","rosa_enabled":true}]}`)
+		})
+	})
+
+	It("should return the channel groups enabled versions sorted by version", func(ctx context.Context) {
+		versions, err := provider.Versions(ctx, "")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(search).To(Equal("enabled = 't' and channel_group = 'stable'"))
+		Expect(versions.ChannelGroup).To(Equal("stable"))
+
+		var ids []string
+		for _, version := range versions.Available {
+			ids = append(ids, version.ID)
+		}
+		Expect(ids).To(Equal([]string{"4.13.4", "4.13.10", "4.14.1", "4.14.2"}))
+
+		Expect(versions.Available[0].ROSA).To(BeFalse())
+		Expect(versions.Available[3].ROSA).To(BeTrue())
+		Expect(versions.Available[3].HostedCP).To(BeTrue())
+	})
+
+	It("should return the default and latest versions", func(ctx context.Context) {
+		versions, err := provider.Versions(ctx, "stable")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(versions.Default().ID).To(Equal("4.13.10"))
+
+		latest, err := versions.Latest(0)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(latest.ID).To(Equal("4.14.2"))
+
+		previous, err := versions.Latest(1)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(previous.ID).To(Equal("4.13.10"))
+
+		_, err = versions.Latest(2)
+		Expect(err).Should(HaveOccurred())
+	})
+})
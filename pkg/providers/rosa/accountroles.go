@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"os/exec"
 	"strings"
 
 	"github.com/openshift/osde2e-framework/internal/cmd"
 )
 
+// accountRolesPrefixProperty is the cluster property recording the account roles prefix
+const accountRolesPrefixProperty = "osde2e_account_roles_prefix"
+
 // accountRoles represents all roles for a given prefix/version
 type accountRoles struct {
 	controlPlaneRoleARN string
@@ -29,36 +31,25 @@ func (a *accountRolesError) Error() string {
 	return fmt.Sprintf("%s account roles failed: %v", a.action, a.err)
 }
 
-// createAccountRoles creates the account roles to be used when creating rosa clusters
-func (r *Provider) createAccountRoles(ctx context.Context, prefix, version, channelGroup string) (*accountRoles, error) {
+// createAccountRoles creates the account roles to be used when creating rosa clusters.
+// It reports whether the account roles were created or already existed
+func (r *Provider) createAccountRoles(ctx context.Context, prefix, version, channelGroup string) (*accountRoles, bool, error) {
 	const action = "create"
 	var accountRoles *accountRoles
 
 	accountRoles, err := r.getAccountRoles(ctx, prefix, version)
 	if err != nil {
-		return nil, &accountRolesError{action: action, err: err}
+		return nil, false, &accountRolesError{action: action, err: err}
 	}
 
 	// TODO: Open an RFE to rosa to support --output option
 	if accountRoles == nil {
 		log.Printf("Creating account roles with prefix/version \"%s/%s\n", prefix, version)
 
-		commandArgs := []string{
-			"create",
-			"account-roles",
-			"--prefix",
-			prefix,
-			"--version",
-			version,
-			"--channel-group",
-			channelGroup,
-			"--mode",
-			"auto",
-			"--yes",
-		}
+		commandArgs := createAccountRolesCommandArgs(prefix, version, channelGroup)
 
 		err := r.awsCredentials.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
-			_, _, err := cmd.Run(exec.CommandContext(ctx, r.rosaBinary, commandArgs...))
+			_, _, err := r.runCommand(r.rosaCommand(ctx, commandArgs...))
 			if err != nil {
 				return err
 			}
@@ -71,27 +62,49 @@ func (r *Provider) createAccountRoles(ctx context.Context, prefix, version, chan
 			return nil
 		})
 		if err != nil {
-			return nil, &accountRolesError{action: action, err: err}
+			return nil, true, &accountRolesError{action: action, err: err}
 		}
 
 		log.Printf("Account roles created with prefix/version \"%s/%s\n", prefix, version)
 
-		return accountRoles, nil
+		return accountRoles, true, nil
 	}
 
 	log.Printf("Account roles already exist with prefix/version \"%s/%s\n", prefix, version)
 
-	return accountRoles, nil
+	return accountRoles, false, nil
+}
+
+// createAccountRolesCommandArgs returns the rosa command arguments to create account roles
+func createAccountRolesCommandArgs(prefix, version, channelGroup string) []string {
+	return []string{
+		"create",
+		"account-roles",
+		"--prefix",
+		prefix,
+		"--version",
+		version,
+		"--channel-group",
+		channelGroup,
+		"--mode",
+		"auto",
+		"--yes",
+	}
+}
+
+// deleteAccountRolesCommandArgs returns the rosa command arguments to delete account roles
+func deleteAccountRolesCommandArgs(prefix string) []string {
+	return []string{"delete", "account-roles", "--prefix", prefix, "--mode", "auto", "--yes"}
 }
 
 // deleteAccountRoles deletes the account roles that were created to create rosa clusters
 func (r *Provider) deleteAccountRoles(ctx context.Context, prefix string) error {
 	log.Printf("Deleting account roles with prefix %q", prefix)
 
-	commandArgs := []string{"delete", "account-roles", "--prefix", prefix, "--mode", "auto", "--yes"}
+	commandArgs := deleteAccountRolesCommandArgs(prefix)
 
 	err := r.awsCredentials.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
-		_, _, err := cmd.Run(exec.CommandContext(ctx, r.rosaBinary, commandArgs...))
+		_, _, err := r.runCommand(r.rosaCommand(ctx, commandArgs...))
 		return err
 	})
 	if err != nil {
@@ -114,7 +127,7 @@ func (r *Provider) getAccountRoles(ctx context.Context, prefix, version string)
 	commandArgs := []string{"list", "account-roles", "--output", "json"}
 
 	err := r.awsCredentials.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
-		stdout, _, err := cmd.Run(exec.CommandContext(ctx, r.rosaBinary, commandArgs...))
+		stdout, _, err := r.runCommand(r.rosaCommand(ctx, commandArgs...))
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,77 @@
+package rosa
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/openshift/osde2e-framework/internal/cmd"
+)
+
+// artifactTimestampFormat prefixes the artifact file names so they sort in the order they were written
+const artifactTimestampFormat = "20060102T150405.000"
+
+// artifactPath returns the timestamped path in the artifact directory for the file name
+func (r *Provider) artifactPath(name string) string {
+	return filepath.Join(r.artifactDir, fmt.Sprintf("%s-%s", time.Now().UTC().Format(artifactTimestampFormat), name))
+}
+
+// runCommand runs the command and writes its stdout and stderr to the artifact directory when one is set
+func (r *Provider) runCommand(command *exec.Cmd) (io.Writer, io.Writer, error) {
+	stdout, stderr, err := cmd.Run(command)
+	if r.artifactDir == "" {
+		return stdout, stderr, err
+	}
+
+	operation := cmd.Operation(command)
+	name := strings.Join(append([]string{filepath.Base(operation[0])}, operation[1:]...), "-")
+
+	path := r.artifactPath(name)
+	for suffix, output := range map[string]io.Writer{"stdout": stdout, "stderr": stderr} {
+		if writeErr := os.WriteFile(fmt.Sprintf("%s.%s.log", path, suffix), []byte(fmt.Sprint(output)), 0o600); writeErr != nil {
+			log.Printf("Failed to write %s %s artifact: %v", name, suffix, writeErr)
+		}
+	}
+
+	return stdout, stderr, err
+}
+
+// terraformOutput is implemented by the terraform runner
+type terraformOutput interface {
+	SetOutput(stdout, stderr io.Writer)
+}
+
+// saveTerraformOutput writes the terraform runners output to the artifact directory when
+// one is set, the returned function closes the artifact files
+func (r *Provider) saveTerraformOutput(tf terraformOutput, name string) func() {
+	if r.artifactDir == "" {
+		return func() {}
+	}
+
+	path := r.artifactPath(fmt.Sprintf("terraform-%s", name))
+
+	stdout, err := os.OpenFile(fmt.Sprintf("%s.stdout.log", path), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		log.Printf("Failed to create terraform %s stdout artifact: %v", name, err)
+		return func() {}
+	}
+
+	stderr, err := os.OpenFile(fmt.Sprintf("%s.stderr.log", path), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		_ = stdout.Close()
+		log.Printf("Failed to create terraform %s stderr artifact: %v", name, err)
+		return func() {}
+	}
+
+	tf.SetOutput(stdout, stderr)
+
+	return func() {
+		_ = stdout.Close()
+		_ = stderr.Close()
+	}
+}
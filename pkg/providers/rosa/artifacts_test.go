@@ -0,0 +1,29 @@
+package rosa
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Artifacts", func() {
+	It("should write the command output to the artifact directory", func() {
+		provider := &Provider{artifactDir: GinkgoT().TempDir()}
+
+		_, _, err := provider.runCommand(exec.CommandContext(context.Background(), "echo", "whoami", "--token", "secret"))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		files, err := filepath.Glob(filepath.Join(provider.artifactDir, "*-echo-whoami-*.stdout.log"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(files).To(HaveLen(1))
+		Expect(files[0]).ToNot(ContainSubstring("secret"))
+
+		content, err := os.ReadFile(files[0])
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(content)).To(Equal("whoami --token secret\n"))
+	})
+})
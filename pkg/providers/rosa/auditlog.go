@@ -0,0 +1,167 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// AuditLogForwardingOptions represents data used to forward a hosted control plane clusters
+// audit logs to aws cloudwatch, the only destination ocm supports (s3 is not supported)
+type AuditLogForwardingOptions struct {
+	// RoleARN is the iam role the hosted control plane assumes to write to cloudwatch (rosa --audit-log-arn)
+	RoleARN string
+	// LogGroupPrefix is the prefix of the cloudwatch log group the audit logs are expected
+	// in when verifying the forwarding, defaults to the cluster id
+	LogGroupPrefix string
+	// VerifyTimeout is how long to wait for audit logs to arrive once the cluster is installed,
+	// defaults to 15 minutes. Verification requires the aws cli
+	VerifyTimeout time.Duration
+}
+
+// auditLogError represents the custom error
+type auditLogError struct {
+	action string
+	err    error
+}
+
+// Error returns the formatted error message when auditLogError is invoked
+func (a *auditLogError) Error() string {
+	return fmt.Sprintf("%s audit log forwarding failed: %v", a.action, a.err)
+}
+
+// validateAuditLogForwarding verifies audit log forwarding is supported for the cluster options
+func validateAuditLogForwarding(options *CreateClusterOptions) error {
+	if options.AuditLogForwarding == nil {
+		return nil
+	}
+
+	if !options.HostedCP {
+		return fmt.Errorf("audit log forwarding is only supported for hosted control plane clusters")
+	}
+
+	if !isIAMRoleARN(options.AuditLogForwarding.RoleARN) {
+		return fmt.Errorf("audit log forwarding role arn %q is not an iam role arn", options.AuditLogForwarding.RoleARN)
+	}
+
+	return nil
+}
+
+// EnableAuditLogForwarding configures an existing hosted control plane cluster to forward
+// its audit logs to cloudwatch using the iam role, an empty role arn disables the forwarding
+func (r *Provider) EnableAuditLogForwarding(ctx context.Context, clusterID, roleARN string) error {
+	action := "enable"
+	if roleARN == "" {
+		action = "disable"
+	}
+
+	cluster, err := clustersmgmtv1.NewCluster().
+		AWS(clustersmgmtv1.NewAWS().AuditLog(clustersmgmtv1.NewAuditLog().RoleArn(roleARN))).
+		Build()
+	if err != nil {
+		return &auditLogError{action: action, err: fmt.Errorf("failed to build cluster: %v", err)}
+	}
+
+	_, err = r.ClustersMgmt().V1().Clusters().Cluster(clusterID).Update().Body(cluster).SendContext(ctx)
+	if err != nil {
+		return &auditLogError{action: action, err: fmt.Errorf("failed to update cluster %q: %v", clusterID, err)}
+	}
+
+	return nil
+}
+
+// VerifyAuditLogForwarding waits for the clusters audit logs to arrive in cloudwatch (requires the aws cli)
+func (r *Provider) VerifyAuditLogForwarding(ctx context.Context, clusterID string, options *AuditLogForwardingOptions) error {
+	const action = "verify"
+
+	logGroupPrefix := options.LogGroupPrefix
+	if logGroupPrefix == "" {
+		logGroupPrefix = clusterID
+	}
+
+	timeout := options.VerifyTimeout
+	if timeout == 0 {
+		timeout = 15 * time.Minute
+	}
+
+	start := time.Now()
+	var lastErr error
+
+	err := wait.PollUntilContextTimeout(ctx, 30*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		logGroups, err := r.auditLogGroups(ctx, logGroupPrefix)
+		if err != nil {
+			lastErr = err
+			log.Println(err)
+			return false, nil
+		}
+
+		for _, logGroup := range logGroups {
+			events, err := r.auditLogEventCount(ctx, logGroup, start.Add(-time.Hour))
+			if err != nil {
+				lastErr = err
+				log.Println(err)
+				continue
+			}
+
+			if events > 0 {
+				log.Printf("Cluster %q audit logs are forwarded to cloudwatch log group %s", clusterID, logGroup)
+				return true, nil
+			}
+		}
+
+		lastErr = fmt.Errorf("no audit logs found in log groups prefixed with %q", logGroupPrefix)
+		log.Printf("Waiting for cluster %q audit logs to arrive in cloudwatch", clusterID)
+
+		return false, nil
+	})
+	if err != nil {
+		if lastErr != nil {
+			err = lastErr
+		}
+		return &auditLogError{action: action, err: fmt.Errorf("cluster %q: %v", clusterID, err)}
+	}
+
+	return nil
+}
+
+// auditLogGroups returns the names of the cloudwatch log groups with the prefix
+func (r *Provider) auditLogGroups(ctx context.Context, prefix string) ([]string, error) {
+	var output struct {
+		LogGroups []struct {
+			LogGroupName string `json:"logGroupName"`
+		} `json:"logGroups"`
+	}
+
+	err := awsCLI(ctx, r.awsCredentials, &output, "logs", "describe-log-groups", "--log-group-name-prefix", prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe log groups: %v", err)
+	}
+
+	logGroups := make([]string, 0, len(output.LogGroups))
+	for _, logGroup := range output.LogGroups {
+		logGroups = append(logGroups, logGroup.LogGroupName)
+	}
+
+	return logGroups, nil
+}
+
+// auditLogEventCount returns the number of log events (up to 10) in the log group since the time
+func (r *Provider) auditLogEventCount(ctx context.Context, logGroup string, since time.Time) (int, error) {
+	var output struct {
+		Events []struct{} `json:"events"`
+	}
+
+	err := awsCLI(ctx, r.awsCredentials, &output, "logs", "filter-log-events",
+		"--log-group-name", logGroup,
+		"--start-time", fmt.Sprint(since.UnixMilli()),
+		"--max-items", "10")
+	if err != nil {
+		return 0, fmt.Errorf("failed to filter log group %s events: %v", logGroup, err)
+	}
+
+	return len(output.Events), nil
+}
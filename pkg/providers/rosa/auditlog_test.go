@@ -0,0 +1,18 @@
+package rosa
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit Log Forwarding", func() {
+	It("should only support hosted control plane clusters with an iam role", func() {
+		forwarding := &AuditLogForwardingOptions{RoleARN: "arn:aws:iam::123456789012:role/audit-logs"}
+
+		Expect(validateAuditLogForwarding(&CreateClusterOptions{})).To(Succeed())
+		Expect(validateAuditLogForwarding(&CreateClusterOptions{HostedCP: true, AuditLogForwarding: forwarding})).To(Succeed())
+		Expect(validateAuditLogForwarding(&CreateClusterOptions{AuditLogForwarding: forwarding})).ToNot(Succeed())
+		Expect(validateAuditLogForwarding(&CreateClusterOptions{HostedCP: true, AuditLogForwarding: &AuditLogForwardingOptions{RoleARN: "audit-logs"}})).ToNot(Succeed())
+		Expect(validateAuditLogForwarding(&CreateClusterOptions{HostedCP: true, AuditLogForwarding: &AuditLogForwardingOptions{RoleARN: "arn:aws-us-gov:iam::123456789012:role/audit-logs"}})).To(Succeed())
+	})
+})
@@ -0,0 +1,29 @@
+package rosa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+
+	"github.com/openshift/osde2e-framework/internal/cmd"
+	awscloud "github.com/openshift/osde2e-framework/pkg/providers/clouds/aws"
+)
+
+// awsCLI runs the aws cli command with the credentials provided and decodes the json output into result
+func awsCLI(ctx context.Context, credentials *awscloud.AWSCredentials, result any, args ...string) error {
+	args = append(args, "--output", "json")
+
+	return credentials.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
+		stdout, stderr, err := cmd.Run(exec.CommandContext(ctx, "aws", args...))
+		if err != nil {
+			return fmt.Errorf("aws %s: %v: %s", args[0], err, stderr)
+		}
+
+		if err = json.Unmarshal([]byte(fmt.Sprint(stdout)), result); err != nil {
+			return fmt.Errorf("failed to decode aws %s output: %v", args[0], err)
+		}
+
+		return nil
+	})
+}
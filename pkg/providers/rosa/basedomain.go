@@ -0,0 +1,89 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/osde2e-framework/internal/workload"
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+)
+
+// validateBaseDomain verifies the base dns domain is reserved in ocm (rosa create dns-domain)
+// by the current accounts organization and is not used by another cluster
+func (r *Provider) validateBaseDomain(ctx context.Context, baseDomain string) error {
+	dnsDomain, err := r.DNSDomain(ctx, baseDomain)
+	if err != nil {
+		return fmt.Errorf("%v, reserve it using rosa create dns-domain", err)
+	}
+
+	if dnsDomain.Cluster.ID != "" {
+		return fmt.Errorf("dns domain %q is used by cluster %q", baseDomain, dnsDomain.Cluster.ID)
+	}
+
+	response, err := r.AccountsMgmt().V1().CurrentAccount().Get().SendContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current account: %v", err)
+	}
+
+	organizationID := response.Body().Organization().ID()
+	if dnsDomain.Organization.ID != "" && dnsDomain.Organization.ID != organizationID {
+		return fmt.Errorf("dns domain %q is reserved by organization %q, not %q", baseDomain, dnsDomain.Organization.ID, organizationID)
+	}
+
+	return nil
+}
+
+// verifyBaseDomain verifies the cluster dns, console and apps (ingress) domains are in the
+// base domain and the console and apps routes resolve
+func verifyBaseDomain(ctx context.Context, client *openshift.Client, baseDomain string) error {
+	var dns configv1.DNS
+	if err := client.Get(ctx, "cluster", "", &dns); err != nil {
+		return fmt.Errorf("failed to get dns configuration: %v", err)
+	}
+
+	if !inDomain(dns.Spec.BaseDomain, baseDomain) {
+		return fmt.Errorf("cluster base domain %q is not in %q", dns.Spec.BaseDomain, baseDomain)
+	}
+
+	var ingress configv1.Ingress
+	if err := client.Get(ctx, "cluster", "", &ingress); err != nil {
+		return fmt.Errorf("failed to get ingress configuration: %v", err)
+	}
+
+	var console configv1.Console
+	if err := client.Get(ctx, "cluster", "", &console); err != nil {
+		return fmt.Errorf("failed to get console configuration: %v", err)
+	}
+
+	consoleHost := strings.TrimPrefix(strings.TrimPrefix(console.Status.ConsoleURL, "https://"), "http://")
+	consoleHost = strings.SplitN(consoleHost, "/", 2)[0]
+
+	// Any host in the apps domain resolves using the ingress wildcard record
+	appsHost := fmt.Sprintf("osde2e-%s.%s", workload.RandomSuffix(6), ingress.Spec.Domain)
+
+	for name, host := range map[string]string{"console": consoleHost, "apps": appsHost} {
+		if !inDomain(host, baseDomain) {
+			return fmt.Errorf("%s host %q is not in %q", name, host, baseDomain)
+		}
+
+		addresses, err := net.DefaultResolver.LookupHost(ctx, host)
+		if err != nil {
+			return fmt.Errorf("%s host %q does not resolve: %v", name, host, err)
+		}
+
+		log.Printf("Cluster %s host %s resolves to %v", name, host, addresses)
+	}
+
+	return nil
+}
+
+// inDomain returns whether the host is the domain or a subdomain of it
+func inDomain(host, domain string) bool {
+	host = strings.TrimSuffix(host, ".")
+	domain = strings.TrimSuffix(domain, ".")
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
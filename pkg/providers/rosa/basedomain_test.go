@@ -0,0 +1,18 @@
+package rosa
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Base Domain", func() {
+	DescribeTable("should check the host is in the domain",
+		func(host string, expected bool) {
+			Expect(inDomain(host, "abcd.s1.devshift.org")).To(Equal(expected))
+		},
+		Entry("subdomain", "console-openshift-console.apps.my-cluster.abcd.s1.devshift.org", true),
+		Entry("fully qualified", "abcd.s1.devshift.org.", true),
+		Entry("suffix without a dot", "xabcd.s1.devshift.org", false),
+		Entry("other domain", "console.apps.my-cluster.p1.openshiftapps.com", false),
+	)
+})
@@ -0,0 +1,39 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+)
+
+// validateBillingAccount verifies the aws billing account is linked to the current accounts
+// organization in ocm, hosted control plane clusters can only be billed to linked accounts
+func (r *Provider) validateBillingAccount(ctx context.Context, billingAccountID string) error {
+	response, err := r.AccountsMgmt().V1().CurrentAccount().Get().SendContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current account: %v", err)
+	}
+
+	organizationID := response.Body().Organization().ID()
+
+	quotaCost, err := r.AccountsMgmt().V1().Organizations().Organization(organizationID).QuotaCost().List().
+		Parameter("fetchCloudAccounts", true).
+		SendContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get organization %q quota cost: %v", organizationID, err)
+	}
+
+	var linked []string
+	for _, cost := range quotaCost.Items().Slice() {
+		for _, cloudAccount := range cost.CloudAccounts() {
+			if cloudAccount.CloudProviderID() != "aws" {
+				continue
+			}
+			if cloudAccount.CloudAccountID() == billingAccountID {
+				return nil
+			}
+			linked = append(linked, cloudAccount.CloudAccountID())
+		}
+	}
+
+	return fmt.Errorf("billing account %q is not linked to organization %q, linked accounts: %v", billingAccountID, organizationID, linked)
+}
@@ -0,0 +1,90 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// createdResources tracks the resources created while creating a cluster
+// so they can be removed when cluster creation fails midway
+type createdResources struct {
+	// accountRolesPrefix is the prefix of the account roles, empty when no account roles were created
+	accountRolesPrefix string
+	clusterID          string
+	clusterName        string
+	oidcConfigID       string
+	// oidcConfigReused is true when the cluster uses a caller supplied oidc config whose
+	// oidc provider must be left in place
+	oidcConfigReused bool
+	// operatorRolesReused is true when the cluster uses pre-created operator roles
+	operatorRolesReused bool
+	// subnetSet is the name of the subnet set leased, empty when none was leased
+	subnetSet string
+	// subnetSetLease is the id of the subnet set lease, required to return it
+	subnetSetLease string
+	// vpcWorkingDir is the terraform working directory of the vpc, empty when no vpc was created
+	vpcWorkingDir string
+}
+
+// cleanupCreatedResources removes the resources created in reverse order of
+// creation, continuing past failures and returning all errors encountered
+func (r *Provider) cleanupCreatedResources(ctx context.Context, created *createdResources) error {
+	var errs []string
+
+	log.Printf("Cluster %q creation failed, cleaning up the resources created", created.clusterName)
+
+	if created.clusterID != "" {
+		if err := r.deleteCluster(ctx, created.clusterID, false); err != nil {
+			errs = append(errs, err.Error())
+		} else if err = r.waitForClusterToBeDeleted(ctx, created.clusterID, created.clusterName, 30*time.Minute); err != nil {
+			errs = append(errs, err.Error())
+		}
+
+		if !created.operatorRolesReused {
+			if err := r.deleteOperatorRoles(ctx, created.clusterID, ""); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+
+		if !created.oidcConfigReused {
+			if err := r.deleteOIDCConfigProvider(ctx, created.clusterID, ""); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+	}
+
+	if created.oidcConfigID != "" {
+		if err := r.deleteOIDCConfig(ctx, created.oidcConfigID); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if created.subnetSet != "" {
+		if err := r.ReturnSubnetSet(ctx, created.subnetSet, created.subnetSetLease); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if created.vpcWorkingDir != "" {
+		if err := r.deleteHostedControlPlaneVPC(ctx, created.clusterName, r.awsCredentials.Region, created.vpcWorkingDir); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if created.accountRolesPrefix != "" {
+		if err := r.deleteAccountRoles(ctx, created.accountRolesPrefix); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+
+	log.Printf("Cluster %q resources cleaned up", created.clusterName)
+
+	return nil
+}
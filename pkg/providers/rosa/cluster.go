@@ -1,47 +1,165 @@
 package rosa
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
-	"os/exec"
+	"strings"
 	"time"
 
 	"github.com/Masterminds/semver"
-	"github.com/openshift/osde2e-framework/internal/cmd"
+	"github.com/openshift/osde2e-framework/internal/workload"
+	ocmclient "github.com/openshift/osde2e-framework/pkg/clients/ocm"
 	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+	"github.com/openshift/osde2e-framework/pkg/healthcheck"
+	"github.com/openshift/osde2e-framework/pkg/summary"
 
 	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
+const (
+	autoMode   = "auto"
+	manualMode = "manual"
+	// modeProperty is the cluster property recording the iam mode, iam resources of
+	// manual mode clusters are not deleted with the cluster
+	modeProperty = "osde2e_iam_mode"
+	// clusterStatePollInterval is how often the clusters state is polled while waiting for it to be ready or deleted
+	clusterStatePollInterval = time.Minute
+)
+
 // CreateClusterOptions represents data used to create clusters
 type CreateClusterOptions struct {
 	ChannelGroup       string
 	ClusterName        string
 	ComputeMachineType string
+	EtcdEncryption     bool
 	HostedCP           bool
 	MachineCidr        string
-	Mode               string
+	MultiAZ            bool
 	OIDCConfigManaged  bool
-	Properties         string
 	Replicas           int
 	STS                bool
 	Version            string
-
-	accountRoles accountRoles
-	oidcConfigID string
-	subnetIDs    string
+	WorkerDiskSize     int
+
+	// Properties are additional cluster properties, keys prefixed with osde2e_ or rosa_
+	// are reserved for the properties set by the framework
+	Properties map[string]string
+
+	// WorkingDir is the directory holding the hosted control plane vpc terraform state,
+	// it defaults to a per cluster directory and is recorded on the cluster for deletion
+	WorkingDir string
+
+	// DryRun validates the options and resolves existing resources, logging the rosa commands,
+	// terraform plan and ocm request that would be executed without creating anything
+	DryRun bool
+
+	// LeaseSubnets leases a pre-created subnet set from the registry (see RegisterSubnetSet)
+	// for hosted control plane clusters instead of creating a vpc using terraform
+	LeaseSubnets bool
+
+	// SharedVPC installs the cluster into subnets shared from another aws account,
+	// the shared vpc resources are validated before anything is created
+	SharedVPC *SharedVPCOptions
+
+	// Architecture is the compute nodes architecture (amd64, arm64) used to resolve the
+	// default compute machine type for the region when ComputeMachineType is undefined
+	Architecture string
+
+	// BaseDomain is a base dns domain reserved in ocm (rosa create dns-domain) the cluster
+	// domains are created in, classic clusters only. The console and apps routes are
+	// verified to resolve in the domain once the cluster is installed
+	BaseDomain string
+
+	// WorkerLabels are the labels of the default compute machine pools nodes (rosa
+	// --worker-mp-labels), verified on the worker nodes once the cluster is installed
+	WorkerLabels map[string]string
+
+	// AuditLogForwarding forwards the hosted control plane audit logs to cloudwatch, the
+	// logs are verified to arrive once the cluster is installed
+	AuditLogForwarding *AuditLogForwardingOptions
+
+	// Ec2MetadataHttpTokens is the node instances metadata service mode, optional (imdsv1
+	// and imdsv2) or required (imdsv2 only), defaults to the ocm default. The node instances
+	// are verified to enforce the mode once the cluster is installed (requires the aws cli)
+	Ec2MetadataHttpTokens string
+
+	// BillingAccountID is the aws account hosted control plane clusters are billed to
+	// (rosa --billing-account), it must be linked to the ocm organization
+	BillingAccountID string
+
+	// Mode is the iam mode, auto (default) creates the account roles, operator roles and oidc
+	// resources. Manual requires them to be pre-created (see OIDCConfigID and OperatorRolesPrefix)
+	// and never creates or deletes iam resources, for accounts forbidding iam mutation from ci
+	Mode string
+
+	// OperatorRolesPrefix is the prefix of the operator roles, defaults to the cluster name.
+	// Operator roles already existing with the prefix are reused and not deleted with the cluster
+	OperatorRolesPrefix string
+
+	// OIDCConfigID is a pre-created (e.g. shared managed) oidc config the cluster uses instead
+	// of creating one, it and its oidc provider are left in place when the cluster is deleted
+	OIDCConfigID string
+
+	// UniquePrefixes derives the account roles and oidc config prefixes from the cluster name
+	// and a random suffix, so clusters sharing a name prefix in an aws account do not share them
+	UniquePrefixes bool
+
+	// SkipCleanupOnFailure leaves the resources created (account roles, oidc config,
+	// vpc, cluster) in place when cluster creation fails, e.g. for debugging
+	SkipCleanupOnFailure bool
+	// SkipTrustPolicyVerification skips verifying the account and operator roles trust
+	// policies after they are created, verification requires the aws cli
+	SkipTrustPolicyVerification bool
+
+	accountRoles       accountRoles
+	accountRolesPrefix string
+	oidcConfigID       string
+	oidcConfigPrefix   string
+	// operatorRolesReused is true when the operator roles were pre-created
+	operatorRolesReused bool
+	operatorRolesPrefix string
+	subnetIDs           string
+	subnetSet           string
+	subnetSetLease      string
 }
 
 // DeleteClusterOptions represents data used to delete clusters
 type DeleteClusterOptions struct {
+	// ClusterID and ClusterName identify the cluster, only one of them is required
 	ClusterID   string
 	ClusterName string
-	HostedCP    bool
-	STS         bool
+	// HostedCP and STS are detected from the cluster when unset
+	HostedCP bool
+	STS      bool
+
+	// DryRun logs the ocm request, rosa and terraform commands that would be executed
+	// without deleting anything
+	DryRun bool
+
+	// Force continues deleting the operator roles, oidc config, vpc and account roles when
+	// a step fails (or the cluster no longer exists), returning all errors once finished.
+	// When the cluster no longer exists its resources are only deleted using the cluster id
+	// or the prefixes, oidc config id and working dir provided, steps without them are skipped
+	Force bool
+
+	// AccountRolesPrefix is the prefix of the account roles to delete, it defaults
+	// to the prefix recorded on the cluster at creation or the cluster name
+	AccountRolesPrefix string
+	// OperatorRolesPrefix and OIDCConfigID identify the operator roles and the oidc config
+	// (and its oidc provider) to delete when the cluster no longer exists and its id is unknown
+	OperatorRolesPrefix string
+	OIDCConfigID        string
+
+	// WorkingDir is the directory holding the hosted control plane vpc terraform state,
+	// it defaults to the directory recorded on the cluster at creation
+	WorkingDir string
 }
 
 // clusterError represents the custom error
@@ -55,148 +173,530 @@ func (c *clusterError) Error() string {
 	return fmt.Sprintf("%s cluster failed: %v", c.action, c.err)
 }
 
-// CreateCluster creates a rosa cluster using the provided inputs
+// CreateCluster creates a rosa cluster using the provided inputs and waits
+// for it to be ready and healthy
 func (r *Provider) CreateCluster(ctx context.Context, options *CreateClusterOptions) (string, error) {
 	const action = "create"
-	clusterReadyAttempts := 120
+
+	start := r.events.PhaseStart("create", "", options.ClusterName)
+	clusterID, err := r.CreateClusterAsync(ctx, options)
+	summary.Global().Phase("create", options.ClusterName, start, err)
+	r.events.PhaseEnd("create", clusterID, options.ClusterName, start, err)
+	if err != nil || options.DryRun {
+		return clusterID, err
+	}
+
+	summary.Global().ClusterCreated(clusterID, options.ClusterName)
+
+	var cluster *ClusterHandle
+	err = r.runPhase("install", clusterID, options.ClusterName, func() error {
+		cluster, err = r.WaitForClusterReady(ctx, clusterID)
+		return err
+	})
+	if err != nil {
+		return clusterID, err
+	}
+
+	err = r.runPhase("health checks", clusterID, options.ClusterName, func() error {
+		return r.RunInstallHealthChecks(ctx, cluster)
+	})
+	if err != nil {
+		return clusterID, &clusterError{action: action, err: err}
+	}
+
+	r.snapshotClusterMetrics(ctx, clusterID, options.ClusterName, "after install")
+	r.warnClusterSpecDrift(ctx, clusterID, options)
+
+	err = r.verifyClusterConfiguration(ctx, cluster, options)
+	if err != nil {
+		summary.Global().Failure("verify cluster configuration", err)
+		return clusterID, &clusterError{action: action, err: err}
+	}
+
+	return clusterID, nil
+}
+
+// CreateClusterAsync creates a rosa cluster using the provided inputs and
+// returns the cluster id once the cluster creation request is accepted without
+// waiting for the cluster to be ready. Use WaitForClusterReady and
+// RunInstallHealthChecks to complete the installation. Resources created
+// before a failure are removed unless SkipCleanupOnFailure is set
+func (r *Provider) CreateClusterAsync(ctx context.Context, options *CreateClusterOptions) (clusterID string, err error) {
+	const action = "create"
+
+	created := &createdResources{clusterName: options.ClusterName, oidcConfigReused: options.OIDCConfigID != ""}
+	defer func() {
+		if err == nil || options.SkipCleanupOnFailure || options.DryRun {
+			return
+		}
+
+		if cleanupErr := r.cleanupCreatedResources(ctx, created); cleanupErr != nil {
+			err = fmt.Errorf("%v (cleanup failed: %v)", err, cleanupErr)
+		}
+	}()
 
 	options.setDefaultCreateClusterOptions()
 
-	if options.STS {
-		version, err := semver.NewVersion(options.Version)
+	err = validateProperties(options.Properties)
+	if err != nil {
+		return "", &clusterError{action: action, err: err}
+	}
+
+	err = r.resolveVersion(ctx, options)
+	if err != nil {
+		return "", &clusterError{action: action, err: err}
+	}
+
+	err = r.resolveComputeMachineType(ctx, options)
+	if err != nil {
+		return "", &clusterError{action: action, err: err}
+	}
+
+	err = r.validateWorkerDiskSize(ctx, options)
+	if err != nil {
+		return "", &clusterError{action: action, err: err}
+	}
+
+	regionResult, err := r.ValidateRegion(ctx, options)
+	if err != nil {
+		return "", &clusterError{action: action, err: err}
+	}
+
+	if len(regionResult.Failed()) > 0 {
+		return "", &clusterError{action: action, err: fmt.Errorf("region pre-flight checks failed:\n%s", regionResult.String())}
+	}
+
+	quotaResult, err := r.ValidateQuota(ctx, options)
+	if err != nil {
+		return "", &clusterError{action: action, err: err}
+	}
+
+	if len(quotaResult.Failed()) > 0 {
+		return "", &clusterError{action: action, err: fmt.Errorf("quota pre-flight checks failed:\n%s", quotaResult.String())}
+	}
+
+	if options.SharedVPC != nil {
+		result, err := r.ValidateSharedVPC(ctx, options.SharedVPC)
 		if err != nil {
-			return "", &clusterError{action: action, err: fmt.Errorf("failed to parse version into semantic version: %v", err)}
+			return "", &clusterError{action: action, err: err}
+		}
+
+		if len(result.Failed()) > 0 {
+			return "", &clusterError{action: action, err: fmt.Errorf("shared vpc pre-flight checks failed:\n%s", result.String())}
+		}
+
+		options.subnetIDs = strings.Join(options.SharedVPC.SubnetIDs, ",")
+	}
+
+	if options.OIDCConfigID != "" {
+		if !options.STS {
+			return "", &clusterError{action: action, err: fmt.Errorf("oidc config id requires sts")}
 		}
-		majorMinor := fmt.Sprintf("%d.%d", version.Major(), version.Minor())
 
-		accountRoles, err := r.createAccountRoles(ctx, options.ClusterName, majorMinor, options.ChannelGroup)
+		oidcConfig, err := r.getOIDCConfig(ctx, options.OIDCConfigID)
 		if err != nil {
 			return "", &clusterError{action: action, err: err}
 		}
-		options.accountRoles = *accountRoles
+
+		log.Printf("Using oidc config %q", oidcConfig.ID())
+		options.oidcConfigID = oidcConfig.ID()
 	}
 
-	if options.HostedCP {
-		clusterReadyAttempts = 30
+	if options.Mode != "" && options.Mode != autoMode && options.Mode != manualMode {
+		return "", &clusterError{action: action, err: fmt.Errorf("mode %q is not supported, use %q or %q", options.Mode, autoMode, manualMode)}
+	}
+
+	if options.Mode == manualMode && (!options.STS || options.OIDCConfigID == "") {
+		return "", &clusterError{action: action, err: fmt.Errorf("manual mode requires sts and a pre-created oidc config id")}
+	}
+
+	if options.BillingAccountID != "" {
+		if !options.HostedCP {
+			return "", &clusterError{action: action, err: fmt.Errorf("billing account id is only supported for hosted control plane clusters")}
+		}
 
-		// TODO: region check for hcp support
+		err = r.validateBillingAccount(ctx, options.BillingAccountID)
+		if err != nil {
+			return "", &clusterError{action: action, err: err}
+		}
+	}
+
+	err = validateEc2MetadataHTTPTokens(options.Ec2MetadataHttpTokens)
+	if err != nil {
+		return "", &clusterError{action: action, err: err}
+	}
+
+	err = validateWorkerLabels(options.WorkerLabels)
+	if err != nil {
+		return "", &clusterError{action: action, err: err}
+	}
+
+	err = validateAuditLogForwarding(options)
+	if err != nil {
+		return "", &clusterError{action: action, err: err}
+	}
+
+	if options.BaseDomain != "" {
+		if options.HostedCP {
+			return "", &clusterError{action: action, err: fmt.Errorf("base domain is only supported for classic clusters")}
+		}
+
+		err = r.validateBaseDomain(ctx, options.BaseDomain)
+		if err != nil {
+			return "", &clusterError{action: action, err: err}
+		}
+	}
 
-		oidcConfigID, err := r.createOIDCConfig(
-			ctx,
-			options.ClusterName,
-			options.accountRoles.installerRoleARN,
-			options.OIDCConfigManaged,
-		)
+	if options.DryRun {
+		err = r.dryRunCreateCluster(ctx, options)
 		if err != nil {
 			return "", &clusterError{action: action, err: err}
 		}
+		return "", nil
+	}
 
-		options.oidcConfigID = oidcConfigID
+	if options.STS {
+		version, err := semver.NewVersion(options.Version)
+		if err != nil {
+			return "", &clusterError{action: action, err: fmt.Errorf("failed to parse version into semantic version: %v", err)}
+		}
+		majorMinor := fmt.Sprintf("%d.%d", version.Major(), version.Minor())
 
-		// TODO: Handle working directory
-		vpc, err := r.createHostedControlPlaneVPC(
-			ctx,
-			options.ClusterName,
-			r.awsCredentials.Region,
-			"/tmp",
-		)
+		var accountRoles *accountRoles
+		if options.Mode == manualMode {
+			accountRoles, err = r.getAccountRoles(ctx, options.accountRolesPrefix, majorMinor)
+			if err == nil && accountRoles == nil {
+				err = fmt.Errorf("account roles with prefix %q do not exist, manual mode requires pre-created account roles", options.accountRolesPrefix)
+			}
+		} else {
+			var accountRolesCreated bool
+			accountRoles, accountRolesCreated, err = r.createAccountRoles(ctx, options.accountRolesPrefix, majorMinor, options.ChannelGroup)
+			if accountRolesCreated {
+				created.accountRolesPrefix = options.accountRolesPrefix
+			}
+		}
 		if err != nil {
 			return "", &clusterError{action: action, err: err}
 		}
+		options.accountRoles = *accountRoles
+
+		if options.Mode == manualMode || options.OperatorRolesPrefix != "" {
+			missing, err := r.getOperatorRoles(ctx, options.operatorRolesPrefix, options.HostedCP)
+			if err != nil {
+				return "", &clusterError{action: action, err: err}
+			}
+
+			switch {
+			case len(missing) == 0:
+				log.Printf("Reusing operator roles with prefix %q", options.operatorRolesPrefix)
+				options.operatorRolesReused = true
+				created.operatorRolesReused = true
+			case options.Mode == manualMode:
+				return "", &clusterError{action: action, err: fmt.Errorf("manual mode requires pre-created operator roles, missing: %v", missing)}
+			}
+		}
 
-		options.subnetIDs = fmt.Sprintf("%s,%s", vpc.privateSubnet, vpc.publicSubnet)
+		if !options.SkipTrustPolicyVerification {
+			err = r.verifyAccountRolesTrustPolicies(ctx, accountRoles)
+			if err != nil {
+				return "", &clusterError{action: action, err: err}
+			}
+		}
 	}
 
-	clusterID, err := r.createCluster(ctx, options)
+	if options.HostedCP {
+		if options.oidcConfigID == "" {
+			oidcConfigID, oidcConfigCreated, err := r.createOIDCConfig(
+				ctx,
+				options.oidcConfigPrefix,
+				options.accountRoles.installerRoleARN,
+				options.OIDCConfigManaged,
+			)
+			if oidcConfigCreated {
+				created.oidcConfigID = oidcConfigID
+			}
+			if err != nil {
+				return "", &clusterError{action: action, err: err}
+			}
+
+			options.oidcConfigID = oidcConfigID
+		}
+
+		if options.SharedVPC != nil {
+			log.Printf("Using shared vpc subnets %s", options.subnetIDs)
+		} else if options.LeaseSubnets {
+			subnetSet, err := r.LeaseSubnetSet(ctx, r.awsCredentials.Region, options.ClusterName)
+			if err != nil {
+				return "", &clusterError{action: action, err: err}
+			}
+
+			created.subnetSet, created.subnetSetLease = subnetSet.Name, subnetSet.LeasedBy
+			options.subnetSet, options.subnetSetLease = subnetSet.Name, subnetSet.LeasedBy
+			options.subnetIDs = fmt.Sprintf("%s,%s", subnetSet.PrivateSubnet, subnetSet.PublicSubnet)
+		} else {
+			created.vpcWorkingDir = options.WorkingDir
+			vpc, err := r.createHostedControlPlaneVPC(
+				ctx,
+				options.ClusterName,
+				r.awsCredentials.Region,
+				options.WorkingDir,
+			)
+			if err != nil {
+				return "", &clusterError{action: action, err: err}
+			}
+
+			options.subnetIDs = fmt.Sprintf("%s,%s", vpc.privateSubnet, vpc.publicSubnet)
+		}
+	}
+
+	clusterID, err = r.createCluster(ctx, options)
+	created.clusterID = clusterID
 	if err != nil {
 		return "", &clusterError{action: action, err: err}
 	}
 
 	log.Printf("Cluster ID: %s\n", clusterID)
 
-	err = r.waitForClusterToBeReady(ctx, clusterID, clusterReadyAttempts)
+	return clusterID, nil
+}
+
+// WaitForClusterReady waits for the cluster to be in a ready state and
+// returns a handle to the cluster with its kubeconfig fetched
+func (r *Provider) WaitForClusterReady(ctx context.Context, clusterID string) (*ClusterHandle, error) {
+	const action = "create"
+	clusterReadyTimeout := 2 * time.Hour
+
+	response, err := r.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().SendContext(ctx)
 	if err != nil {
-		return clusterID, &clusterError{action: action, err: err}
+		return nil, &clusterError{action: action, err: fmt.Errorf("failed to get cluster %q: %v", clusterID, err)}
+	}
+
+	if response.Body().Hypershift().Enabled() {
+		clusterReadyTimeout = 30 * time.Minute
 	}
 
-	kubeConfigFile, err := r.Client.KubeConfigFile(ctx, clusterID)
+	err = r.waitForClusterToBeReady(ctx, clusterID, clusterReadyTimeout)
 	if err != nil {
-		return clusterID, &clusterError{action: action, err: err}
+		return nil, &clusterError{action: action, err: err}
 	}
 
-	err = r.waitForClusterHealthChecksToSucceed(ctx, kubeConfigFile, options.HostedCP)
+	response, err = r.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().SendContext(ctx)
 	if err != nil {
-		return clusterID, &clusterError{action: action, err: err}
+		return nil, &clusterError{action: action, err: fmt.Errorf("failed to get cluster %q: %v", clusterID, err)}
 	}
 
-	return clusterID, nil
+	kubeConfigFile, err := r.KubeConfigFile(ctx, clusterID)
+	if err != nil {
+		return nil, &clusterError{action: action, err: err}
+	}
+
+	if r.expiryWatcher != nil {
+		r.expiryWatcher.Watch(kubeConfigExpiryName(clusterID), func(ctx context.Context) (time.Time, error) {
+			return openshift.KubeConfigCertificateExpiration(kubeConfigFile)
+		})
+	}
+
+	return &ClusterHandle{ClusterSummary: newClusterSummary(response.Body()), KubeConfigFile: kubeConfigFile}, nil
 }
 
-// DeleteCluster deletes a rosa cluster using the provided inputs
+// kubeConfigExpiryName returns the name the clusters kubeconfig is watched by the expiry watcher as
+func kubeConfigExpiryName(clusterID string) string {
+	return fmt.Sprintf("cluster %s kubeconfig", clusterID)
+}
+
+// removeClusterKubeConfig stops watching the deleted clusters kubeconfig expiry and removes its
+// kubeconfig file, the credentials it holds are no longer valid
+func (r *Provider) removeClusterKubeConfig(clusterID string) {
+	if r.expiryWatcher != nil {
+		r.expiryWatcher.Unwatch(kubeConfigExpiryName(clusterID))
+	}
+
+	if err := r.RemoveKubeConfigFile(clusterID); err != nil {
+		log.Printf("Cluster %q kubeconfig cleanup failed: %v", clusterID, err)
+	}
+}
+
+// DeleteCluster deletes a rosa cluster using the provided inputs. Clusters in error state
+// are deprovisioned and, like when forced, their iam and vpc resources are cleaned up best
+// effort using the properties recorded when the cluster was created
 func (r *Provider) DeleteCluster(ctx context.Context, options *DeleteClusterOptions) error {
 	const action = "delete"
 	var (
-		clusterDeletedAttempts = 30
-		oidcConfigID           string
+		clusterDeletedTimeout = 30 * time.Minute
+		errs                  []string
+		oidcConfigID          string
+		reused                = &reusedResources{}
+		subnetSet             string
+		subnetSetLease        string
 	)
 
+	cluster, err := r.resolveDeleteClusterOptions(ctx, options)
+	_, clusterNotFound := err.(*clusterNotFoundError)
+	switch {
+	case clusterNotFound && options.Force:
+		if err = options.validateClusterNotFound(); err != nil {
+			return &clusterError{action: action, err: err}
+		}
+		log.Printf("Cluster %q not found, force deleting its remaining resources", options.ClusterName)
+	case err != nil:
+		return &clusterError{action: action, err: err}
+	}
+
 	options.setDefaultDeleteClusterOptions()
 
-	if options.HostedCP {
-		oidcConfig, err := r.getClusterOIDCConfig(ctx, options.ClusterID)
-		if err != nil {
+	// clusters in error state never finished installing, their metadata and resources may be
+	// incomplete so the cleanup is best effort and continues past failures
+	errorState := cluster.State() == clustersmgmtv1.ClusterStateError
+	if errorState {
+		log.Printf("Cluster %q is in error state, deprovisioning and cleaning up its resources best effort", options.ClusterName)
+	}
+	bestEffort := errorState || options.Force
+
+	// handleError returns the error unless the cleanup is best effort, where errors are collected
+	handleError := func(err error) error {
+		if err == nil {
+			return nil
+		}
+		if !bestEffort {
 			return &clusterError{action: action, err: err}
 		}
-		oidcConfigID = oidcConfig.ID()
+		log.Printf("Cluster %q cleanup failed, continuing: %v", options.ClusterName, err)
+		errs = append(errs, err.Error())
+		return nil
 	}
 
-	err := r.deleteCluster(ctx, options.ClusterID)
-	if err != nil {
-		return &clusterError{action: action, err: err}
+	// skip records the step was not performed as its resources could not be identified
+	skip := func(step string) {
+		log.Printf("Cluster %q cleanup skipped deleting the %s, it could not be identified", options.ClusterName, step)
+		errs = append(errs, fmt.Sprintf("skipped deleting the %s: no cluster id or identifier provided", step))
 	}
 
-	err = r.waitForClusterToBeDeleted(ctx, options.ClusterName, clusterDeletedAttempts)
-	if err != nil {
-		return &clusterError{action: action, err: err}
+	if options.HostedCP {
+		oidcConfigID = cluster.AWS().STS().OidcConfig().ID()
+		if oidcConfigID == "" {
+			oidcConfigID = options.OIDCConfigID
+		}
 	}
 
 	if options.STS {
-		err = r.deleteOperatorRoles(ctx, options.ClusterID)
-		if err != nil {
-			return &clusterError{action: action, err: err}
+		properties := cluster.Properties()
+
+		if options.AccountRolesPrefix == "" {
+			options.AccountRolesPrefix = properties[accountRolesPrefixProperty]
 		}
 
-		err = r.deleteOIDCConfigProvider(ctx, options.ClusterID)
-		if err != nil {
-			return &clusterError{action: action, err: err}
+		if options.WorkingDir == "" {
+			options.WorkingDir = properties[vpcWorkingDirProperty]
 		}
+
+		subnetSet, subnetSetLease = properties[subnetSetProperty], properties[subnetSetLeaseProperty]
+		reused = reusedResourcesFromProperties(properties)
 	}
 
-	if options.HostedCP {
-		err := r.deleteOIDCConfig(ctx, oidcConfigID)
+	// the name based defaults are not used when the cluster no longer exists, its resources may
+	// have been created with unique prefixes and the defaults would identify other resources
+	if options.AccountRolesPrefix == "" && !clusterNotFound {
+		options.AccountRolesPrefix = options.ClusterName
+	}
+
+	if options.HostedCP && options.WorkingDir == "" && !clusterNotFound {
+		options.WorkingDir = defaultVPCWorkingDir(options.ClusterName)
+	}
+
+	if options.DryRun {
+		err := r.dryRunDeleteCluster(ctx, options, oidcConfigID, subnetSet, reused)
 		if err != nil {
 			return &clusterError{action: action, err: err}
 		}
+		return nil
+	}
 
-		// TODO: Handle working directory
-		err = r.deleteHostedControlPlaneVPC(
-			ctx,
-			options.ClusterName,
-			r.awsCredentials.Region,
-			"/tmp",
-		)
-		if err != nil {
-			return &clusterError{action: action, err: err}
+	if !clusterNotFound {
+		if !errorState {
+			r.snapshotClusterMetrics(ctx, options.ClusterID, options.ClusterName, "before delete")
+		}
+
+		deleteErr := r.runPhase("delete", options.ClusterID, options.ClusterName, func() error {
+			if err := r.deleteCluster(ctx, options.ClusterID, errorState); err != nil {
+				return err
+			}
+			return r.waitForClusterToBeDeleted(ctx, options.ClusterID, options.ClusterName, clusterDeletedTimeout)
+		})
+		if err = handleError(deleteErr); err != nil {
+			return err
+		}
+
+		if deleteErr == nil {
+			summary.Global().ClusterDeleted(options.ClusterID, options.ClusterName)
+			r.removeClusterKubeConfig(options.ClusterID)
 		}
 	}
 
 	if options.STS {
-		err = r.deleteAccountRoles(ctx, options.ClusterName)
-		if err != nil {
-			return &clusterError{action: action, err: err}
+		switch {
+		case reused.operatorRoles:
+		case options.ClusterID == "" && options.OperatorRolesPrefix == "":
+			skip("operator roles")
+		default:
+			if err = handleError(r.deleteOperatorRoles(ctx, options.ClusterID, options.OperatorRolesPrefix)); err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case reused.oidcConfig:
+		case options.ClusterID == "" && oidcConfigID == "":
+			skip("oidc provider")
+		default:
+			if err = handleError(r.deleteOIDCConfigProvider(ctx, options.ClusterID, oidcConfigID)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if options.HostedCP {
+		switch {
+		case reused.oidcConfig:
+		case oidcConfigID == "":
+			if clusterNotFound {
+				skip("oidc config")
+			}
+		default:
+			if err = handleError(r.deleteOIDCConfig(ctx, oidcConfigID)); err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case subnetSet != "":
+			err = r.ReturnSubnetSet(ctx, subnetSet, subnetSetLease)
+		case options.WorkingDir == "":
+			skip("hosted control plane vpc")
+		default:
+			err = r.deleteHostedControlPlaneVPC(
+				ctx,
+				options.ClusterName,
+				r.awsCredentials.Region,
+				options.WorkingDir,
+			)
+		}
+		if err = handleError(err); err != nil {
+			return err
 		}
 	}
 
+	if options.STS && !reused.accountRoles {
+		if options.AccountRolesPrefix == "" {
+			skip("account roles")
+		} else if err = handleError(r.deleteAccountRoles(ctx, options.AccountRolesPrefix)); err != nil {
+			return err
+		}
+	}
+
+	if len(errs) > 0 {
+		return &clusterError{action: action, err: fmt.Errorf("cluster %q cleanup failed:\n%s", options.ClusterName, strings.Join(errs, "\n"))}
+	}
+
 	return nil
 }
 
@@ -211,7 +711,7 @@ func validateCreateClusterOptions(options *CreateClusterOptions) (*CreateCluster
 	}
 
 	if options.ComputeMachineType == "" {
-		options.ComputeMachineType = "m5.xlarge"
+		return options, fmt.Errorf("compute machine type is required")
 	}
 
 	if options.MachineCidr == "" {
@@ -224,6 +724,19 @@ func validateCreateClusterOptions(options *CreateClusterOptions) (*CreateCluster
 
 	if options.Replicas == 0 {
 		options.Replicas = 2
+		if options.MultiAZ {
+			options.Replicas = 3
+		}
+	}
+
+	if options.MultiAZ {
+		if options.HostedCP {
+			return options, fmt.Errorf("multi az is determined by the subnets for hosted control plane clusters")
+		}
+
+		if options.Replicas%3 != 0 {
+			return options, fmt.Errorf("replicas must be a multiple of 3 for multi az clusters")
+		}
 	}
 
 	if options.HostedCP {
@@ -262,146 +775,381 @@ func (r *Provider) createCluster(ctx context.Context, options *CreateClusterOpti
 		return "", fmt.Errorf("cluster options validation failed: %v", err)
 	}
 
-	commandArgs := []string{"create", "cluster", "--output", "json", "--mode", "auto", "--yes"}
-	commandArgs = append(commandArgs, "--cluster-name", options.ClusterName)
-	commandArgs = append(commandArgs, "--channel-group", options.ChannelGroup)
-	commandArgs = append(commandArgs, "--compute-machine-type", options.ComputeMachineType)
-	commandArgs = append(commandArgs, "--machine-cidr", options.MachineCidr)
-	commandArgs = append(commandArgs, "--region", r.awsCredentials.Region)
-	commandArgs = append(commandArgs, "--version", options.Version)
-	commandArgs = append(commandArgs, "--replicas", fmt.Sprint(options.Replicas))
-	commandArgs = append(commandArgs, "--properties", options.Properties)
-	commandArgs = append(commandArgs, "--controlplane-iam-role", options.accountRoles.controlPlaneRoleARN)
-	commandArgs = append(commandArgs, "--role-arn", options.accountRoles.installerRoleARN)
-	commandArgs = append(commandArgs, "--support-role-arn", options.accountRoles.supportRoleARN)
-	commandArgs = append(commandArgs, "--worker-iam-role", options.accountRoles.workerRoleARN)
+	identity, err := r.awsIdentity(ctx)
+	if err != nil {
+		return "", err
+	}
 
+	body, err := r.buildClusterBody(ctx, options, identity)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := r.Connection.Post().Path("/api/clusters_mgmt/v1/clusters").Bytes(body).SendContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to send create cluster request: %v", err)
+	}
+
+	if response.Status() != http.StatusCreated {
+		return "", fmt.Errorf("create cluster request failed with status %d: %s", response.Status(), response.String())
+	}
+
+	cluster, err := clustersmgmtv1.UnmarshalCluster(response.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("failed to parse create cluster response: %v", err)
+	}
+
+	if options.STS {
+		// Operator roles and the oidc provider are aws iam resources and can only be created using the rosa cli
+		if !options.operatorRolesReused {
+			err = r.createOperatorRoles(ctx, cluster.ID())
+			if err != nil {
+				return cluster.ID(), err
+			}
+		}
+
+		if options.oidcConfigID == "" {
+			err = r.createOIDCConfigProvider(ctx, cluster.ID())
+			if err != nil {
+				return cluster.ID(), err
+			}
+		}
+
+		if !options.SkipTrustPolicyVerification {
+			err = r.verifyOperatorRolesTrustPolicies(ctx, cluster)
+			if err != nil {
+				return cluster.ID(), err
+			}
+		}
+	}
+
+	return cluster.ID(), nil
+}
+
+// buildClusterBody builds the ocm cluster request body from the cluster options
+func (r *Provider) buildClusterBody(ctx context.Context, options *CreateClusterOptions, identity *awsIdentity) ([]byte, error) {
+	versionID := fmt.Sprintf("openshift-v%s", options.Version)
+	if options.ChannelGroup != "stable" {
+		versionID = fmt.Sprintf("%s-%s", versionID, options.ChannelGroup)
+	}
+
+	properties := clusterProperties(options.Properties)
+	properties["rosa_creator_arn"] = identity.arn
+	if options.STS {
+		properties[accountRolesPrefixProperty] = options.accountRolesPrefix
+	}
+	if options.Mode == manualMode {
+		properties[modeProperty] = manualMode
+	}
+	if options.operatorRolesReused {
+		properties[operatorRolesReusedProperty] = "true"
+	}
+	if options.OIDCConfigID != "" {
+		properties[oidcConfigReusedProperty] = "true"
+	} else if options.HostedCP {
+		properties[oidcConfigPrefixProperty] = options.oidcConfigPrefix
+	}
 	if options.HostedCP {
-		commandArgs = append(commandArgs, "--hosted-cp")
-		commandArgs = append(commandArgs, "--oidc-config-id", options.oidcConfigID)
-		commandArgs = append(commandArgs, "--subnet-ids", options.subnetIDs)
+		if options.subnetSet != "" {
+			properties[subnetSetProperty] = options.subnetSet
+			properties[subnetSetLeaseProperty] = options.subnetSetLease
+		} else {
+			properties[vpcWorkingDirProperty] = options.WorkingDir
+		}
 	}
 
+	awsBuilder := clustersmgmtv1.NewAWS().AccountID(identity.accountID)
+
 	if options.STS {
-		commandArgs = append(commandArgs, "--sts")
+		operatorIAMRoles, err := r.operatorIAMRoles(ctx, identity.accountID, options.operatorRolesPrefix, options.HostedCP)
+		if err != nil {
+			return nil, err
+		}
+
+		stsBuilder := clustersmgmtv1.NewSTS().
+			AutoMode(options.Mode != manualMode).
+			RoleARN(options.accountRoles.installerRoleARN).
+			SupportRoleARN(options.accountRoles.supportRoleARN).
+			InstanceIAMRoles(clustersmgmtv1.NewInstanceIAMRoles().
+				MasterRoleARN(options.accountRoles.controlPlaneRoleARN).
+				WorkerRoleARN(options.accountRoles.workerRoleARN)).
+			OperatorRolePrefix(options.operatorRolesPrefix).
+			OperatorIAMRoles(operatorIAMRoles...)
+
+		if options.oidcConfigID != "" {
+			stsBuilder = stsBuilder.OidcConfig(clustersmgmtv1.NewOidcConfig().ID(options.oidcConfigID))
+		}
+
+		awsBuilder = awsBuilder.STS(stsBuilder)
+	} else {
+		// Non sts clusters are installed using the osdCcsAdmin user access keys
+		if r.awsCredentials.AccessKeyID == "" || r.awsCredentials.SecretAccessKey == "" {
+			return nil, fmt.Errorf("aws access key id and secret access key are required to create non sts clusters")
+		}
+
+		awsBuilder = awsBuilder.
+			AccessKeyID(r.awsCredentials.AccessKeyID).
+			SecretAccessKey(r.awsCredentials.SecretAccessKey)
 	}
 
-	err = r.awsCredentials.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
-		_, _, err := cmd.Run(exec.CommandContext(ctx, r.rosaBinary, commandArgs...))
-		return err
-	})
-	if err != nil {
-		return "", err
+	if options.BillingAccountID != "" {
+		awsBuilder = awsBuilder.BillingAccountID(options.BillingAccountID)
 	}
 
-	cluster, err := r.getCluster(ctx, options.ClusterName)
+	if options.AuditLogForwarding != nil {
+		awsBuilder = awsBuilder.AuditLog(clustersmgmtv1.NewAuditLog().RoleArn(options.AuditLogForwarding.RoleARN))
+	}
+
+	if options.subnetIDs != "" {
+		awsBuilder = awsBuilder.SubnetIDs(strings.Split(options.subnetIDs, ",")...)
+	}
+
+	clusterBuilder := clustersmgmtv1.NewCluster().
+		Name(options.ClusterName).
+		Product(clustersmgmtv1.NewProduct().ID("rosa")).
+		CloudProvider(clustersmgmtv1.NewCloudProvider().ID("aws")).
+		Region(clustersmgmtv1.NewCloudRegion().ID(r.awsCredentials.Region)).
+		Version(clustersmgmtv1.NewVersion().ID(versionID).ChannelGroup(options.ChannelGroup)).
+		CCS(clustersmgmtv1.NewCCS().Enabled(true)).
+		Nodes(clustersmgmtv1.NewClusterNodes().
+			ComputeMachineType(clustersmgmtv1.NewMachineType().ID(options.ComputeMachineType)).
+			Compute(options.Replicas).
+			ComputeLabels(options.WorkerLabels)).
+		Network(clustersmgmtv1.NewNetwork().MachineCIDR(options.MachineCidr)).
+		EtcdEncryption(options.EtcdEncryption).
+		MultiAZ(options.MultiAZ).
+		Properties(properties).
+		AWS(awsBuilder)
+
+	if options.BaseDomain != "" {
+		clusterBuilder = clusterBuilder.DNS(clustersmgmtv1.NewDNS().BaseDomain(options.BaseDomain))
+	}
+
+	if options.HostedCP {
+		clusterBuilder = clusterBuilder.
+			Hypershift(clustersmgmtv1.NewHypershift().Enabled(true)).
+			BillingModel(clustersmgmtv1.BillingModel("marketplace-aws"))
+	}
+
+	cluster, err := clusterBuilder.Build()
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("failed to build cluster: %v", err)
+	}
+
+	var buffer bytes.Buffer
+	if err = clustersmgmtv1.MarshalCluster(cluster, &buffer); err != nil {
+		return nil, fmt.Errorf("failed to marshal cluster: %v", err)
+	}
+
+	// Fields not available in the ocm sdk cluster type are set directly on the request body
+	body := map[string]any{}
+	if err = json.Unmarshal(buffer.Bytes(), &body); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cluster: %v", err)
+	}
+
+	if options.SharedVPC != nil {
+		aws, _ := body["aws"].(map[string]any)
+		aws["private_hosted_zone_id"] = options.SharedVPC.HostedZoneID
+		aws["private_hosted_zone_role_arn"] = options.SharedVPC.RoleARN
 	}
 
-	return cluster.ID(), err
+	if options.Ec2MetadataHttpTokens != "" {
+		aws, _ := body["aws"].(map[string]any)
+		aws["ec2_metadata_http_tokens"] = options.Ec2MetadataHttpTokens
+	}
+
+	if options.WorkerDiskSize != 0 {
+		nodes, _ := body["nodes"].(map[string]any)
+		nodes["compute_root_volume"] = map[string]any{
+			"aws": map[string]any{"size": options.WorkerDiskSize},
+		}
+	}
+
+	return json.Marshal(body)
+}
+
+// clusterNotFoundError is returned when no rosa cluster matches the id or name
+type clusterNotFoundError struct {
+	search string
 }
 
-// getCluster gets the cluster the body
-func (r *Provider) getCluster(ctx context.Context, clusterName string) (*clustersmgmtv1.Cluster, error) {
-	query := fmt.Sprintf("product.id = 'rosa' AND name = '%s'", clusterName)
-	response, err := r.ClustersMgmt().V1().Clusters().List().
-		Search(query).
-		Page(1).
-		Size(1).
-		SendContext(ctx)
+// Error returns the formatted error message when clusterNotFoundError is invoked
+func (c *clusterNotFoundError) Error() string {
+	return fmt.Sprintf("cluster %q not found", c.search)
+}
+
+// multipleClustersError is returned when more than one rosa cluster matches the name
+type multipleClustersError struct {
+	search     string
+	candidates []*clustersmgmtv1.Cluster
+}
 
-	switch response.Total() {
+// Error returns the formatted error message listing the matching clusters when multipleClustersError is invoked
+func (m *multipleClustersError) Error() string {
+	candidates := make([]string, 0, len(m.candidates))
+	for _, cluster := range m.candidates {
+		candidates = append(candidates, fmt.Sprintf("%s (id=%s, state=%s)", cluster.Name(), cluster.ID(), cluster.State()))
+	}
+	return fmt.Sprintf("cluster %q matches %d clusters, use the cluster id: %s", m.search, len(m.candidates), strings.Join(candidates, ", "))
+}
+
+// getCluster gets the rosa cluster by id or name, returning a clusterNotFoundError when no cluster
+// matches and a multipleClustersError when the name matches several clusters
+func (r *Provider) getCluster(ctx context.Context, idOrName string) (*clustersmgmtv1.Cluster, error) {
+	var clusters []*clustersmgmtv1.Cluster
+
+	query := fmt.Sprintf("product.id = 'rosa' AND (id = %s OR name = %s)", quoteSearchValue(idOrName), quoteSearchValue(idOrName))
+
+	for page := 1; ; page++ {
+		response, err := r.ClustersMgmt().V1().Clusters().List().
+			Search(query).
+			Page(page).
+			Size(listClustersPageSize).
+			SendContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cluster %q: %v", idOrName, err)
+		}
+
+		clusters = append(clusters, response.Items().Slice()...)
+
+		if response.Size() < listClustersPageSize {
+			break
+		}
+	}
+
+	for _, cluster := range clusters {
+		if cluster.ID() == idOrName {
+			return cluster, nil
+		}
+	}
+
+	switch len(clusters) {
+	case 0:
+		return nil, &clusterNotFoundError{search: idOrName}
 	case 1:
-		return response.Items().Slice()[0], nil
+		return clusters[0], nil
 	default:
-		return nil, fmt.Errorf("cluster %q not found: %v", clusterName, err)
+		return nil, &multipleClustersError{search: idOrName, candidates: clusters}
 	}
 }
 
-// deleteCluster handles sending the request to delete the cluster
-func (r *Provider) deleteCluster(ctx context.Context, clusterID string) error {
+// deleteCluster handles sending the request to delete the cluster, deprovision
+// explicitly requests the clusters cloud resources are deprovisioned
+func (r *Provider) deleteCluster(ctx context.Context, clusterID string, deprovision bool) error {
 	if clusterID == "" {
 		return fmt.Errorf("cluster ID is undefined and is required")
 	}
 
-	commandArgs := []string{"delete", "cluster", "--cluster", clusterID, "--yes"}
-	err := r.awsCredentials.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
-		_, _, err := cmd.Run(exec.CommandContext(ctx, r.rosaBinary, commandArgs...))
-		return err
-	})
+	request := r.ClustersMgmt().V1().Clusters().Cluster(clusterID).Delete()
+	if deprovision {
+		request = request.Deprovision(true)
+	}
 
+	response, err := request.SendContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to send delete cluster request: %v", err)
+	}
+
+	if response.Status() != http.StatusNoContent {
+		return fmt.Errorf("delete cluster request failed with status %d", response.Status())
+	}
+
+	return nil
+}
+
+// runPhase runs the provisioning phase, recording it to the run summary and notifying the event hooks
+func (r *Provider) runPhase(name, clusterID, clusterName string, phase func() error) error {
+	start := r.events.PhaseStart(name, clusterID, clusterName)
+	err := phase()
+	summary.Global().Phase(name, clusterName, start, err)
+	r.events.PhaseEnd(name, clusterID, clusterName, start, err)
 	return err
 }
 
-// waitForClusterToBeReady waits for the cluster to be in a ready state
-func (r *Provider) waitForClusterToBeReady(ctx context.Context, clusterID string, attempts int) error {
-	getClusterState := func() (string, error) {
-		var clusterState string
+// snapshotClusterMetrics records the clusters ocm metrics to the run summary, failures are only logged
+func (r *Provider) snapshotClusterMetrics(ctx context.Context, clusterID, clusterName, when string) {
+	metrics, err := r.ClusterMetrics(ctx, clusterID)
+	if err != nil {
+		log.Printf("Failed to snapshot cluster %q metrics: %v", clusterName, err)
+		return
+	}
 
-		commandArgs := []string{"describe", "cluster", "--cluster", clusterID, "--output", "json"}
-		err := r.awsCredentials.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
-			stdout, _, err := cmd.Run(exec.CommandContext(ctx, r.rosaBinary, commandArgs...))
-			if err != nil {
-				return err
-			}
+	summary.Global().Snapshot(fmt.Sprintf("%s metrics %s", clusterName, when), metrics.String())
+}
 
-			output, err := cmd.ConvertJSONStringToMap(stdout)
-			if err != nil {
-				return fmt.Errorf("failed to convert output to map: %v", err)
+// waitForClusterToBeReady waits for the cluster to be in a ready state, streaming its install logs
+func (r *Provider) waitForClusterToBeReady(ctx context.Context, clusterID string, timeout time.Duration) error {
+	installLogs := newClusterLogStreamer(clusterID, installLog)
+
+	err := r.WaitForClusterStateWithOptions(ctx, clusterID, clustersmgmtv1.ClusterStateReady, &ocmclient.ClusterStateOptions{
+		Timeout:       timeout,
+		PollInterval:  clusterStatePollInterval,
+		OnStateChange: r.stateChangeHandler(clusterID, ""),
+		OnPoll: func(ctx context.Context, state clustersmgmtv1.ClusterState) error {
+			installLogs.stream(ctx, r)
+
+			if state == clustersmgmtv1.ClusterStateReady {
+				return nil
 			}
 
-			clusterState = fmt.Sprint(output["status"].(map[string]any)["state"])
+			// Failed inflight checks (e.g. egress verification) leave the cluster waiting until the timeout
+			if _, err := r.checkInflightChecks(ctx, clusterID); err != nil {
+				if _, ok := err.(*inflightCheckError); ok {
+					return err
+				}
+			}
 
 			return nil
-		})
-		return clusterState, err
+		},
+	})
+	if err != nil {
+		installLogs.stream(ctx, r)
+		filename, persistErr := installLogs.persist()
+		if persistErr != nil {
+			log.Println(persistErr)
+		}
+		return fmt.Errorf("%v (install log: %s)", err, filename)
 	}
 
-	for i := 1; i <= attempts; i++ {
-		clusterState, err := getClusterState()
-		if err != nil {
-			clusterState = "n/a"
-		}
+	return nil
+}
 
-		if clusterState != "ready" {
-			log.Printf("%d/%d : Cluster %q not in ready state (state=%s)\n", i, attempts, clusterID, clusterState)
-			time.Sleep(1 * time.Minute)
-			continue
+// waitForClusterToBeDeleted waits for the cluster to be deleted, streaming its uninstall logs
+func (r *Provider) waitForClusterToBeDeleted(ctx context.Context, clusterID, clusterName string, timeout time.Duration) error {
+	uninstallLogs := newClusterLogStreamer(clusterID, uninstallLog)
+
+	err := r.WaitForClusterStateWithOptions(ctx, clusterID, ocmclient.ClusterStateDeleted, &ocmclient.ClusterStateOptions{
+		Timeout:       timeout,
+		PollInterval:  clusterStatePollInterval,
+		OnStateChange: r.stateChangeHandler(clusterID, clusterName),
+		OnPoll: func(ctx context.Context, state clustersmgmtv1.ClusterState) error {
+			if state != ocmclient.ClusterStateDeleted {
+				uninstallLogs.stream(ctx, r)
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		filename, persistErr := uninstallLogs.persist()
+		if persistErr != nil {
+			log.Println(persistErr)
 		}
-
-		log.Printf("Cluster id: %q is ready!", clusterID)
-		return nil
+		return fmt.Errorf("%v (uninstall log: %s)", err, filename)
 	}
 
-	return fmt.Errorf("cluster %q failed to enter ready state in the alloted attempts", clusterID)
+	return nil
 }
 
-// waitForClusterToBeDeleted waits for the cluster to be deleted
-func (r *Provider) waitForClusterToBeDeleted(ctx context.Context, clusterName string, attempts int) error {
-	for i := 1; i <= attempts; i++ {
-		cluster, err := r.getCluster(ctx, clusterName)
-		if err == nil && cluster != nil {
-			log.Printf("%d/%d : Cluster %q is still uninstalling (state=%s)\n", i, attempts, clusterName, cluster.State())
-			time.Sleep(1 * time.Minute)
-			continue
-		}
-
-		log.Printf("Cluster %q no longer exists!", clusterName)
-		return nil
+// stateChangeHandler returns the handler notifying the event hooks of the clusters state changes
+func (r *Provider) stateChangeHandler(clusterID, clusterName string) func(previous, current clustersmgmtv1.ClusterState) {
+	return func(previous, current clustersmgmtv1.ClusterState) {
+		r.events.StateChange(clusterID, clusterName, string(previous), string(current))
 	}
-
-	return fmt.Errorf("cluster %q failed to finish uninstalling in the alloted attempts", clusterName)
 }
 
 // waitForClusterHealthChecksToSucceed waits for the cluster health check job to succeed
 func (r *Provider) waitForClusterHealthChecksToSucceed(ctx context.Context, kubeConfigFile string, hostedCP bool) error {
-	defer func() {
-		_ = os.Unsetenv("KUBECONFIG")
-	}()
-
-	os.Setenv("KUBECONFIG", kubeConfigFile)
-	client, err := openshift.New()
+	client, err := openshift.NewFromKubeconfig(kubeConfigFile)
 	if err != nil {
 		return fmt.Errorf("failed to construct openshift client: %v", err)
 	}
@@ -418,8 +1166,13 @@ func (r *Provider) waitForClusterHealthChecksToSucceed(ctx context.Context, kube
 
 // classicClusterInstallHealthChecks waits for the classic cluster to be healthy and operational
 func (r *Provider) classicClusterInstallHealthChecks(ctx context.Context, client *openshift.Client) error {
-	// TODO Implement this and port existing check of waiting for osd ready job
 	log.Println("Start: ROSA Classic Cluster health checks..")
+
+	err := healthcheck.OSDClusterReady(ctx, client, r.artifactDir, 45*time.Minute)
+	if err != nil {
+		return err
+	}
+
 	log.Println("End: ROSA Classic Cluster health checks..")
 	return nil
 }
@@ -463,11 +1216,86 @@ func (r *Provider) hcpClusterInstallHealthChecks(ctx context.Context, client *op
 	return nil
 }
 
+// reusedResources represents the pre-created resources a cluster uses that are not deleted with it
+type reusedResources struct {
+	accountRoles  bool
+	oidcConfig    bool
+	operatorRoles bool
+}
+
+// reusedResourcesFromProperties returns the pre-created resources recorded in the cluster properties
+func reusedResourcesFromProperties(properties map[string]string) *reusedResources {
+	manual := properties[modeProperty] == manualMode
+
+	return &reusedResources{
+		accountRoles:  manual,
+		oidcConfig:    manual || properties[oidcConfigReusedProperty] == "true",
+		operatorRoles: manual || properties[operatorRolesReusedProperty] == "true",
+	}
+}
+
 // setDefaultCreateClusterOptions sets default options when creating clusters
 func (o *CreateClusterOptions) setDefaultCreateClusterOptions() {
+	o.accountRolesPrefix = o.ClusterName
+	o.oidcConfigPrefix = o.ClusterName
+	o.operatorRolesPrefix = o.ClusterName
+
+	if o.OperatorRolesPrefix != "" {
+		o.operatorRolesPrefix = o.OperatorRolesPrefix
+	}
+
+	if o.UniquePrefixes {
+		suffix := workload.RandomSuffix(4)
+		o.accountRolesPrefix = fmt.Sprintf("%s-%s", o.ClusterName, suffix)
+		o.oidcConfigPrefix = fmt.Sprintf("%s-%s", o.ClusterName, suffix)
+	}
+
 	if o.HostedCP {
 		o.STS = true
+
+		if o.WorkingDir == "" {
+			o.WorkingDir = defaultVPCWorkingDir(o.ClusterName)
+		}
+	}
+}
+
+// resolveDeleteClusterOptions reads the cluster from ocm by id or name, setting whichever
+// of the two is undefined and whether the cluster is sts and hosted
+func (r *Provider) resolveDeleteClusterOptions(ctx context.Context, options *DeleteClusterOptions) (*clustersmgmtv1.Cluster, error) {
+	search := options.ClusterID
+	if search == "" {
+		search = options.ClusterName
+	}
+	if search == "" {
+		return nil, fmt.Errorf("cluster id or name is required")
 	}
+
+	cluster, err := r.getCluster(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	options.ClusterID = cluster.ID()
+	if options.ClusterName == "" {
+		options.ClusterName = cluster.Name()
+	}
+	options.HostedCP = options.HostedCP || cluster.Hypershift().Enabled()
+	options.STS = options.STS || cluster.AWS().STS().Enabled()
+
+	return cluster, nil
+}
+
+// validateClusterNotFound verifies the resources of a cluster that no longer exists can be identified,
+// the cluster id or at least one of the prefixes, oidc config id or working dir is required. The
+// cluster is treated as sts when any of its sts resources are identified
+func (o *DeleteClusterOptions) validateClusterNotFound() error {
+	stsResources := o.AccountRolesPrefix != "" || o.OperatorRolesPrefix != "" || o.OIDCConfigID != ""
+	if o.ClusterID == "" && !stsResources && o.WorkingDir == "" {
+		return fmt.Errorf("cluster %q not found, its id, account or operator roles prefix, oidc config id or working dir is required to delete its resources", o.ClusterName)
+	}
+
+	o.STS = o.STS || stsResources
+	return nil
 }
 
 // setDefaultDeleteClusterOptions sets default options when creating clusters
@@ -0,0 +1,141 @@
+package rosa
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/osde2e-framework/pkg/clients/ocmfake"
+	awscloud "github.com/openshift/osde2e-framework/pkg/providers/clouds/aws"
+)
+
+var _ = Describe("Build Cluster Body", func() {
+	var (
+		provider *Provider
+		identity = &awsIdentity{accountID: "123456789012", arn: "arn:aws:iam::123456789012:user/osdCcsAdmin"}
+	)
+
+	BeforeEach(func(ctx context.Context) {
+		server := ocmfake.NewServer()
+		DeferCleanup(server.Close)
+
+		server.Handle(http.MethodGet, "/api/clusters_mgmt/v1/aws_inquiries/sts_credential_requests", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"kind":"STSCredentialRequestList","page":1,"size":1,"total":1,
+				"items":[{"name":"ingress","operator":{"name":"cloud-credentials","namespace":"openshift-ingress-operator"}}]}`))
+		})
+
+		client, err := server.Client(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		provider = &Provider{
+			Client:         client,
+			awsCredentials: &awscloud.AWSCredentials{AccessKeyID: "key", SecretAccessKey: "secret", Region: "us-east-1"},
+		}
+	})
+
+	// buildBody returns the aws section and hypershift setting of the built cluster body
+	buildBody := func(ctx context.Context, options *CreateClusterOptions) (map[string]any, any) {
+		options.ClusterName, options.Version, options.ChannelGroup = "my-cluster", "4.14.1", "stable"
+
+		body, err := provider.buildClusterBody(ctx, options, identity)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		cluster := map[string]any{}
+		Expect(json.Unmarshal(body, &cluster)).To(Succeed())
+
+		aws, _ := cluster["aws"].(map[string]any)
+		return aws, cluster["hypershift"]
+	}
+
+	It("should set the access keys for non sts clusters", func(ctx context.Context) {
+		aws, hypershift := buildBody(ctx, &CreateClusterOptions{})
+		Expect(aws).To(HaveKeyWithValue("access_key_id", "key"))
+		Expect(aws).To(HaveKeyWithValue("secret_access_key", "secret"))
+		Expect(aws).NotTo(HaveKey("sts"))
+		Expect(hypershift).To(BeNil())
+	})
+
+	It("should require the access keys for non sts clusters", func(ctx context.Context) {
+		provider.awsCredentials = &awscloud.AWSCredentials{Profile: "default", Region: "us-east-1"}
+		_, err := provider.buildClusterBody(ctx, &CreateClusterOptions{ClusterName: "my-cluster"}, identity)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should set the sts roles and not the access keys for sts clusters", func(ctx context.Context) {
+		aws, hypershift := buildBody(ctx, &CreateClusterOptions{
+			STS:          true,
+			accountRoles: accountRoles{installerRoleARN: "arn:aws:iam::123456789012:role/installer"},
+		})
+		Expect(aws).NotTo(HaveKey("access_key_id"))
+		Expect(aws).NotTo(HaveKey("secret_access_key"))
+		Expect(aws).To(HaveKeyWithValue("sts", HaveKeyWithValue("role_arn", "arn:aws:iam::123456789012:role/installer")))
+		Expect(aws).To(HaveKeyWithValue("sts", HaveKeyWithValue("operator_iam_roles", HaveLen(1))))
+		Expect(hypershift).To(BeNil())
+	})
+
+	It("should enable hypershift for hosted control plane clusters", func(ctx context.Context) {
+		aws, hypershift := buildBody(ctx, &CreateClusterOptions{
+			STS:          true,
+			HostedCP:     true,
+			oidcConfigID: "oidc-id",
+			subnetIDs:    "subnet-a,subnet-b",
+		})
+		Expect(aws).NotTo(HaveKey("access_key_id"))
+		Expect(aws).To(HaveKeyWithValue("sts", HaveKeyWithValue("oidc_config", HaveKeyWithValue("id", "oidc-id"))))
+		Expect(aws).To(HaveKeyWithValue("subnet_ids", ConsistOf("subnet-a", "subnet-b")))
+		Expect(hypershift).To(HaveKeyWithValue("enabled", true))
+	})
+})
+
+var _ = Describe("Get Cluster", func() {
+	DescribeTable("should search for the cluster by quoted id or name",
+		func(ctx context.Context, idOrName, expected string) {
+			server := ocmfake.NewServer()
+			DeferCleanup(server.Close)
+
+			var search string
+			server.Handle(http.MethodGet, "/api/clusters_mgmt/v1/clusters", func(w http.ResponseWriter, r *http.Request) {
+				search = r.URL.Query().Get("search")
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"kind":"ClusterList","page":1,"size":0,"total":0,"items":[]}`))
+			})
+
+			client, err := server.Client(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			_, err = (&Provider{Client: client}).getCluster(ctx, idOrName)
+			Expect(err).To(BeAssignableToTypeOf(&clusterNotFoundError{}))
+			Expect(search).To(Equal(expected))
+		},
+		Entry("by id", "abc", "product.id = 'rosa' AND (id = 'abc' OR name = 'abc')"),
+		Entry("by a name containing a quote", "x' OR name like '%",
+			"product.id = 'rosa' AND (id = 'x'' OR name like ''%' OR name = 'x'' OR name like ''%')"),
+	)
+})
+
+var _ = Describe("Delete Cluster", func() {
+	It("should require identifiers to delete the resources of a cluster not found", func() {
+		options := &DeleteClusterOptions{ClusterName: "my-cluster", Force: true}
+		Expect(options.validateClusterNotFound()).ShouldNot(Succeed())
+	})
+
+	It("should treat a cluster not found as sts when its sts resources are identified", func() {
+		options := &DeleteClusterOptions{ClusterName: "my-cluster", OperatorRolesPrefix: "my-cluster-a1b2"}
+		Expect(options.validateClusterNotFound()).To(Succeed())
+		Expect(options.STS).To(BeTrue())
+
+		options = &DeleteClusterOptions{ClusterName: "my-cluster", WorkingDir: "/tmp/vpc"}
+		Expect(options.validateClusterNotFound()).To(Succeed())
+		Expect(options.STS).To(BeFalse())
+	})
+
+	It("should delete the operator roles and oidc provider by prefix and oidc config without a cluster id", func() {
+		Expect(deleteOperatorRolesCommandArgs("abc", "my-prefix")).To(ContainElements("--cluster", "abc"))
+		Expect(deleteOperatorRolesCommandArgs("", "my-prefix")).To(Equal([]string{"delete", "operator-roles", "--prefix", "my-prefix", "--mode", "auto", "--yes"}))
+		Expect(deleteOIDCProviderCommandArgs("", "oidc-id")).To(ContainElements("--oidc-config-id", "oidc-id"))
+		Expect(deleteOIDCProviderCommandArgs("abc", "oidc-id")).NotTo(ContainElement("--oidc-config-id"))
+	})
+})
@@ -0,0 +1,197 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+const listClustersPageSize = 100
+
+// searchFieldPattern matches the characters allowed in an ocm search field name
+var searchFieldPattern = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+// ClusterFilters represents the data used to search for clusters
+type ClusterFilters struct {
+	NamePrefix string
+	Properties map[string]string
+	State      string
+}
+
+// ClusterSummary represents the commonly used details of a rosa cluster
+type ClusterSummary struct {
+	CreationTimestamp time.Time         `json:"creation_timestamp"`
+	HostedCP          bool              `json:"hosted_cp"`
+	ID                string            `json:"id"`
+	Name              string            `json:"name"`
+	Properties        map[string]string `json:"properties,omitempty"`
+	Region            string            `json:"region"`
+	State             string            `json:"state"`
+	STS               bool              `json:"sts"`
+	Version           string            `json:"version"`
+}
+
+// newClusterSummary converts the ocm cluster into a cluster summary
+func newClusterSummary(cluster *clustersmgmtv1.Cluster) *ClusterSummary {
+	return &ClusterSummary{
+		CreationTimestamp: cluster.CreationTimestamp(),
+		HostedCP:          cluster.Hypershift().Enabled(),
+		ID:                cluster.ID(),
+		Name:              cluster.Name(),
+		Properties:        cluster.Properties(),
+		Region:            cluster.Region().ID(),
+		State:             string(cluster.State()),
+		STS:               cluster.AWS().STS().Enabled(),
+		Version:           cluster.OpenshiftVersion(),
+	}
+}
+
+// searchQuery builds the ocm search query for the filters provided, values are quoted
+// and property keys restricted to the characters allowed in a search field name
+func (f *ClusterFilters) searchQuery() (string, error) {
+	query := []string{"product.id = 'rosa'"}
+
+	if f == nil {
+		return query[0], nil
+	}
+
+	if f.NamePrefix != "" {
+		query = append(query, fmt.Sprintf("name like %s", quoteSearchValue(f.NamePrefix+"%")))
+	}
+
+	if f.State != "" {
+		query = append(query, fmt.Sprintf("state = %s", quoteSearchValue(f.State)))
+	}
+
+	keys := make([]string, 0, len(f.Properties))
+	for key := range f.Properties {
+		if !searchFieldPattern.MatchString(key) {
+			return "", fmt.Errorf("invalid property filter key %q", key)
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		query = append(query, fmt.Sprintf("properties.%s = %s", key, quoteSearchValue(f.Properties[key])))
+	}
+
+	return strings.Join(query, " AND "), nil
+}
+
+// quoteSearchValue quotes the value for use in an ocm search query, escaping single quotes
+func quoteSearchValue(value string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", "''"))
+}
+
+// ListClusters lists the rosa clusters matching the provided filters
+func (r *Provider) ListClusters(ctx context.Context, filters *ClusterFilters) ([]*ClusterSummary, error) {
+	var clusters []*ClusterSummary
+
+	query, err := filters.searchQuery()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clusters: %v", err)
+	}
+
+	for page := 1; ; page++ {
+		response, err := r.ClustersMgmt().V1().Clusters().List().
+			Search(query).
+			Page(page).
+			Size(listClustersPageSize).
+			SendContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list clusters: %v", err)
+		}
+
+		for _, cluster := range response.Items().Slice() {
+			clusters = append(clusters, newClusterSummary(cluster))
+		}
+
+		if response.Size() < listClustersPageSize {
+			break
+		}
+	}
+
+	return clusters, nil
+}
+
+// DescribeCluster returns the ocm cluster resource for the cluster id or name provided
+func (r *Provider) DescribeCluster(ctx context.Context, idOrName string) (*clustersmgmtv1.Cluster, error) {
+	return r.getCluster(ctx, idOrName)
+}
+
+// DescribeClusterSummary returns the summary of the cluster id or name provided
+func (r *Provider) DescribeClusterSummary(ctx context.Context, idOrName string) (*ClusterSummary, error) {
+	cluster, err := r.getCluster(ctx, idOrName)
+	if err != nil {
+		return nil, err
+	}
+	return newClusterSummary(cluster), nil
+}
+
+// ClusterHandle represents an existing rosa cluster that further operations
+// (delete, upgrade, health checks) can be performed against
+type ClusterHandle struct {
+	*ClusterSummary
+	KubeConfigFile string
+}
+
+// DeleteClusterOptions returns the options to delete the cluster
+func (h *ClusterHandle) DeleteClusterOptions() *DeleteClusterOptions {
+	return &DeleteClusterOptions{
+		ClusterID:          h.ID,
+		ClusterName:        h.Name,
+		HostedCP:           h.HostedCP,
+		STS:                h.STS,
+		AccountRolesPrefix: h.Properties[accountRolesPrefixProperty],
+		WorkingDir:         h.Properties[vpcWorkingDirProperty],
+	}
+}
+
+// SemanticVersion returns the clusters openshift version as a semantic version
+func (h *ClusterHandle) SemanticVersion() (*semver.Version, error) {
+	version, err := semver.NewVersion(h.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cluster version %q into semantic version: %v", h.Version, err)
+	}
+	return version, nil
+}
+
+// Client returns an openshift client for the cluster
+func (h *ClusterHandle) Client() (*openshift.Client, error) {
+	return openshift.NewFromKubeconfig(h.KubeConfigFile)
+}
+
+// AdoptCluster resolves an existing cluster by name or id and fetches its
+// kubeconfig, returning a handle that can be used to resume operations
+// against clusters created elsewhere (e.g. a previous ci job)
+func (r *Provider) AdoptCluster(ctx context.Context, nameOrID string) (*ClusterHandle, error) {
+	const action = "adopt"
+
+	cluster, err := r.getCluster(ctx, nameOrID)
+	if err != nil {
+		return nil, &clusterError{action: action, err: err}
+	}
+
+	summary := newClusterSummary(cluster)
+
+	kubeConfigFile, err := r.KubeConfigFile(ctx, summary.ID)
+	if err != nil {
+		return nil, &clusterError{action: action, err: err}
+	}
+
+	return &ClusterHandle{ClusterSummary: summary, KubeConfigFile: kubeConfigFile}, nil
+}
+
+// RunInstallHealthChecks runs the post install health checks against the cluster
+func (r *Provider) RunInstallHealthChecks(ctx context.Context, cluster *ClusterHandle) error {
+	return r.waitForClusterHealthChecksToSucceed(ctx, cluster.KubeConfigFile, cluster.HostedCP)
+}
@@ -0,0 +1,34 @@
+package rosa
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cluster Filters", func() {
+	DescribeTable("searchQuery",
+		func(filters *ClusterFilters, expected string, valid bool) {
+			query, err := filters.searchQuery()
+			if !valid {
+				Expect(err).Should(HaveOccurred())
+				return
+			}
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(query).To(Equal(expected))
+		},
+		Entry("should only filter by product without filters", nil, "product.id = 'rosa'", true),
+		Entry("should filter by name prefix, state and sorted properties", &ClusterFilters{
+			NamePrefix: "osde2e-",
+			State:      "ready",
+			Properties: map[string]string{"owner": "ci", "job_id": "123"},
+		}, "product.id = 'rosa' AND name like 'osde2e-%' AND state = 'ready' AND properties.job_id = '123' AND properties.owner = 'ci'", true),
+		Entry("should escape single quotes in values", &ClusterFilters{
+			NamePrefix: "it's",
+			State:      "ready' OR 'a' = 'a",
+			Properties: map[string]string{"owner": "o'brien"},
+		}, "product.id = 'rosa' AND name like 'it''s%' AND state = 'ready'' OR ''a'' = ''a' AND properties.owner = 'o''brien'", true),
+		Entry("should reject invalid property keys", &ClusterFilters{
+			Properties: map[string]string{"owner = 'x' OR properties.a": "ci"},
+		}, "", false),
+	)
+})
@@ -0,0 +1,159 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Masterminds/semver"
+)
+
+// dryRunPlaceholder is used in place of values only known once resources are created
+const dryRunPlaceholder = "<dry-run>"
+
+// logDryRunCommand logs the rosa command that would be executed
+func logDryRunCommand(args []string) {
+	log.Printf("[dry-run] rosa %s", strings.Join(args, " "))
+}
+
+// dryRunCreateCluster validates the options and resolves the existing account roles and
+// oidc config, logging the rosa commands, terraform plan and ocm request that creating
+// the cluster would execute without creating anything
+func (r *Provider) dryRunCreateCluster(ctx context.Context, options *CreateClusterOptions) error {
+	if options.STS {
+		version, err := semver.NewVersion(options.Version)
+		if err != nil {
+			return fmt.Errorf("failed to parse version into semantic version: %v", err)
+		}
+		majorMinor := fmt.Sprintf("%d.%d", version.Major(), version.Minor())
+
+		roles, err := r.getAccountRoles(ctx, options.accountRolesPrefix, majorMinor)
+		if err != nil {
+			return err
+		}
+
+		if roles == nil && options.Mode == manualMode {
+			return fmt.Errorf("account roles with prefix %q do not exist, manual mode requires pre-created account roles", options.accountRolesPrefix)
+		}
+
+		if roles == nil {
+			logDryRunCommand(createAccountRolesCommandArgs(options.accountRolesPrefix, majorMinor, options.ChannelGroup))
+			roles = &accountRoles{
+				controlPlaneRoleARN: dryRunPlaceholder,
+				installerRoleARN:    dryRunPlaceholder,
+				supportRoleARN:      dryRunPlaceholder,
+				workerRoleARN:       dryRunPlaceholder,
+			}
+		}
+
+		options.accountRoles = *roles
+	}
+
+	if options.HostedCP && options.oidcConfigID == "" {
+		oidcConfig, err := r.oidcConfigLookup(ctx, options.oidcConfigPrefix)
+		if err != nil {
+			return err
+		}
+
+		if oidcConfig == nil {
+			logDryRunCommand(createOIDCConfigCommandArgs(options.oidcConfigPrefix, options.accountRoles.installerRoleARN, options.OIDCConfigManaged))
+			options.oidcConfigID = dryRunPlaceholder
+		} else {
+			options.oidcConfigID = oidcConfig.ID()
+		}
+	}
+
+	if options.HostedCP {
+
+		switch {
+		case options.SharedVPC != nil:
+			log.Printf("[dry-run] use shared vpc subnets %s", options.subnetIDs)
+		case options.LeaseSubnets:
+			subnetSets, err := r.SubnetSets(ctx, r.awsCredentials.Region)
+			if err != nil {
+				return err
+			}
+
+			available := 0
+			for _, subnetSet := range subnetSets {
+				if !subnetSet.leased() {
+					available++
+				}
+			}
+
+			log.Printf("[dry-run] lease subnet set in region %q (%d available)", r.awsCredentials.Region, available)
+			options.subnetIDs = fmt.Sprintf("%s,%s", dryRunPlaceholder, dryRunPlaceholder)
+		default:
+			plan, err := r.planHostedControlPlaneVPC(ctx, options.ClusterName, r.awsCredentials.Region, options.WorkingDir)
+			if err != nil {
+				return err
+			}
+
+			log.Printf("[dry-run] terraform apply in %s:\n%s", options.WorkingDir, plan)
+			options.subnetIDs = fmt.Sprintf("%s,%s", dryRunPlaceholder, dryRunPlaceholder)
+		}
+	}
+
+	options, err := validateCreateClusterOptions(options)
+	if err != nil {
+		return fmt.Errorf("cluster options validation failed: %v", err)
+	}
+
+	identity, err := r.awsIdentity(ctx)
+	if err != nil {
+		return err
+	}
+
+	body, err := r.buildClusterBody(ctx, options, identity)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[dry-run] POST /api/clusters_mgmt/v1/clusters\n%s", body)
+	log.Printf("[dry-run] cluster properties: %s", strings.Join(propertiesCommandArgs(clusterProperties(options.Properties)), " "))
+
+	if options.STS {
+		if options.Mode != manualMode {
+			logDryRunCommand(operatorRolesCommandArgs("create", dryRunPlaceholder))
+		}
+
+		if options.oidcConfigID == "" {
+			logDryRunCommand(oidcProviderCommandArgs("create", dryRunPlaceholder))
+		}
+	}
+
+	return nil
+}
+
+// dryRunDeleteCluster logs the ocm request, rosa commands and terraform command that
+// deleting the resolved cluster would execute without deleting anything
+func (r *Provider) dryRunDeleteCluster(ctx context.Context, options *DeleteClusterOptions, oidcConfigID, subnetSet string, reused *reusedResources) error {
+	log.Printf("[dry-run] DELETE /api/clusters_mgmt/v1/clusters/%s", options.ClusterID)
+
+	if options.STS {
+		if !reused.operatorRoles {
+			logDryRunCommand(deleteOperatorRolesCommandArgs(options.ClusterID, options.OperatorRolesPrefix))
+		}
+		if !reused.oidcConfig {
+			logDryRunCommand(deleteOIDCProviderCommandArgs(options.ClusterID, oidcConfigID))
+		}
+	}
+
+	if options.HostedCP {
+		if !reused.oidcConfig {
+			logDryRunCommand(deleteOIDCConfigCommandArgs(oidcConfigID))
+		}
+		if subnetSet != "" {
+			log.Printf("[dry-run] return subnet set %q", subnetSet)
+		} else {
+			log.Printf("[dry-run] terraform destroy in %s", options.WorkingDir)
+		}
+	}
+
+	if options.STS && !reused.accountRoles {
+		logDryRunCommand(deleteAccountRolesCommandArgs(options.AccountRolesPrefix))
+	}
+
+	return nil
+}
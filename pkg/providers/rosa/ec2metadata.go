@@ -0,0 +1,96 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ec2MetadataHTTPTokensOptional allows both imdsv1 and imdsv2 requests to the instance metadata service
+	ec2MetadataHTTPTokensOptional = "optional"
+	// ec2MetadataHTTPTokensRequired enforces imdsv2 (session token) requests to the instance metadata service
+	ec2MetadataHTTPTokensRequired = "required"
+)
+
+// validateEc2MetadataHTTPTokens verifies the instance metadata service mode is supported
+func validateEc2MetadataHTTPTokens(value string) error {
+	switch value {
+	case "", ec2MetadataHTTPTokensOptional, ec2MetadataHTTPTokensRequired:
+		return nil
+	}
+
+	return fmt.Errorf("ec2 metadata http tokens %q is not supported, use %q or %q", value, ec2MetadataHTTPTokensOptional, ec2MetadataHTTPTokensRequired)
+}
+
+// nodeInstanceIDs returns the aws instance ids of the clusters nodes
+func nodeInstanceIDs(ctx context.Context, client *openshift.Client) ([]string, error) {
+	var nodes corev1.NodeList
+	if err := client.List(ctx, &nodes); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %v", err)
+	}
+
+	instanceIDs := make([]string, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		// The provider id is formatted as aws:///<availability zone>/<instance id>
+		providerID := node.Spec.ProviderID
+		if !strings.HasPrefix(providerID, "aws://") {
+			return nil, fmt.Errorf("node %q provider id %q is not an aws instance", node.Name, providerID)
+		}
+		instanceIDs = append(instanceIDs, providerID[strings.LastIndex(providerID, "/")+1:])
+	}
+
+	return instanceIDs, nil
+}
+
+// verifyEc2MetadataHTTPTokens verifies the clusters node instances enforce the instance
+// metadata service mode (requires the aws cli)
+func (r *Provider) verifyEc2MetadataHTTPTokens(ctx context.Context, client *openshift.Client, expected string) error {
+	instanceIDs, err := nodeInstanceIDs(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	if len(instanceIDs) == 0 {
+		return fmt.Errorf("no node instances found to verify ec2 metadata http tokens")
+	}
+
+	var output struct {
+		Reservations []struct {
+			Instances []struct {
+				InstanceID      string `json:"InstanceId"`
+				MetadataOptions struct {
+					HTTPTokens string `json:"HttpTokens"`
+				} `json:"MetadataOptions"`
+			} `json:"Instances"`
+		} `json:"Reservations"`
+	}
+
+	args := append([]string{"ec2", "describe-instances", "--instance-ids"}, instanceIDs...)
+	if err = awsCLI(ctx, r.awsCredentials, &output, args...); err != nil {
+		return fmt.Errorf("failed to describe node instances: %v", err)
+	}
+
+	var mismatched []string
+	for _, reservation := range output.Reservations {
+		for _, instance := range reservation.Instances {
+			if instance.MetadataOptions.HTTPTokens != expected {
+				mismatched = append(mismatched, fmt.Sprintf("%s (%s)", instance.InstanceID, instance.MetadataOptions.HTTPTokens))
+			}
+		}
+	}
+
+	if len(mismatched) > 0 {
+		sort.Strings(mismatched)
+		return fmt.Errorf("node instances do not enforce ec2 metadata http tokens %q: %s", expected, strings.Join(mismatched, ", "))
+	}
+
+	log.Printf("Node instances enforce ec2 metadata http tokens %q", expected)
+
+	return nil
+}
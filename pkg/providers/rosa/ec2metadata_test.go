@@ -0,0 +1,35 @@
+package rosa
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/osde2e-framework/pkg/clients/kubernetesfake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Ec2 Metadata", func() {
+	It("should validate the http tokens mode", func() {
+		Expect(validateEc2MetadataHTTPTokens("")).To(Succeed())
+		Expect(validateEc2MetadataHTTPTokens("required")).To(Succeed())
+		Expect(validateEc2MetadataHTTPTokens("v2")).ToNot(Succeed())
+	})
+
+	It("should return the node instance ids", func() {
+		server, err := kubernetesfake.NewServer(
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}, Spec: corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-0123"}},
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		DeferCleanup(server.Close)
+
+		client, err := server.Client()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		instanceIDs, err := nodeInstanceIDs(context.Background(), client)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(instanceIDs).To(Equal([]string{"i-0123"}))
+	})
+})
@@ -0,0 +1,27 @@
+package rosa
+
+import (
+	"fmt"
+	"strings"
+)
+
+// govCloudRegionPrefix is the prefix of the aws govcloud regions (e.g. us-gov-west-1)
+const govCloudRegionPrefix = "us-gov-"
+
+// awsPartition returns the aws partition of the region, govcloud regions are in the aws-us-gov partition
+func awsPartition(region string) string {
+	if strings.HasPrefix(region, govCloudRegionPrefix) {
+		return "aws-us-gov"
+	}
+	return "aws"
+}
+
+// iamRoleARN returns the arn of the iam role in the regions partition
+func iamRoleARN(region, accountID, roleName string) string {
+	return fmt.Sprintf("arn:%s:iam::%s:role/%s", awsPartition(region), accountID, roleName)
+}
+
+// isIAMRoleARN returns true when the arn is an iam role arn in any partition
+func isIAMRoleARN(arn string) bool {
+	return strings.HasPrefix(arn, "arn:aws:iam::") || strings.HasPrefix(arn, "arn:aws-us-gov:iam::")
+}
@@ -0,0 +1,13 @@
+package rosa
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GovCloud", func() {
+	It("should use the regions aws partition for iam role arns", func() {
+		Expect(iamRoleARN("us-east-1", "123456789012", "my-role")).To(Equal("arn:aws:iam::123456789012:role/my-role"))
+		Expect(iamRoleARN("us-gov-west-1", "123456789012", "my-role")).To(Equal("arn:aws-us-gov:iam::123456789012:role/my-role"))
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/openshift/osde2e-framework/assets"
@@ -14,6 +15,9 @@ import (
 	"github.com/hashicorp/terraform-exec/tfexec"
 )
 
+// vpcWorkingDirProperty is the cluster property recording the hcp vpc terraform working directory
+const vpcWorkingDirProperty = "osde2e_vpc_working_dir"
+
 // vpc represents the details of an aws vpc
 type vpc struct {
 	privateSubnet     string
@@ -54,6 +58,12 @@ func copyFile(srcFile, destFile string) error {
 	return nil
 }
 
+// defaultVPCWorkingDir returns the per cluster terraform working directory
+// used when one is not provided
+func defaultVPCWorkingDir(clusterName string) string {
+	return filepath.Join(os.TempDir(), fmt.Sprintf("osde2e-vpc-%s", clusterName))
+}
+
 // createHostedControlPlaneVPC creates the aws vpc used for provisioning hosted control plane clusters
 func (r *Provider) createHostedControlPlaneVPC(ctx context.Context, clusterName, awsRegion, workingDir string) (*vpc, error) {
 	action := "create"
@@ -63,6 +73,11 @@ func (r *Provider) createHostedControlPlaneVPC(ctx context.Context, clusterName,
 		return nil, &hcpVPCError{action: action, err: fmt.Errorf("one or more parameters is empty")}
 	}
 
+	err := os.MkdirAll(workingDir, os.FileMode(0o755))
+	if err != nil {
+		return nil, &hcpVPCError{action: action, err: fmt.Errorf("failed to create working directory: %v", err)}
+	}
+
 	tf, err := terraform.New(workingDir)
 	if err != nil {
 		return nil, &hcpVPCError{action: action, err: fmt.Errorf("failed to construct terraform runner: %v", err)}
@@ -72,6 +87,9 @@ func (r *Provider) createHostedControlPlaneVPC(ctx context.Context, clusterName,
 		_ = tf.Uninstall(ctx)
 	}()
 
+	closeArtifacts := r.saveTerraformOutput(tf, "create-vpc")
+	defer closeArtifacts()
+
 	log.Println("Creating AWS VPC")
 
 	err = copyFile("terraform/setup-vpc.tf", fmt.Sprintf("%s/setup-vpc.tf", workingDir))
@@ -116,9 +134,67 @@ func (r *Provider) createHostedControlPlaneVPC(ctx context.Context, clusterName,
 	return &vpc, err
 }
 
+// planHostedControlPlaneVPC returns the terraform plan to create the aws vpc used for
+// provisioning hosted control plane clusters without applying it
+func (r *Provider) planHostedControlPlaneVPC(ctx context.Context, clusterName, awsRegion, workingDir string) (string, error) {
+	const action = "plan"
+	var plan string
+
+	if clusterName == "" || awsRegion == "" || workingDir == "" {
+		return "", &hcpVPCError{action: action, err: fmt.Errorf("one or more parameters is empty")}
+	}
+
+	err := os.MkdirAll(workingDir, os.FileMode(0o755))
+	if err != nil {
+		return "", &hcpVPCError{action: action, err: fmt.Errorf("failed to create working directory: %v", err)}
+	}
+
+	tf, err := terraform.New(workingDir)
+	if err != nil {
+		return "", &hcpVPCError{action: action, err: fmt.Errorf("failed to construct terraform runner: %v", err)}
+	}
+
+	defer func() {
+		_ = tf.Uninstall(ctx)
+	}()
+
+	closeArtifacts := r.saveTerraformOutput(tf, "plan-vpc")
+	defer closeArtifacts()
+
+	err = copyFile("terraform/setup-vpc.tf", fmt.Sprintf("%s/setup-vpc.tf", workingDir))
+	if err != nil {
+		return "", &hcpVPCError{action: action, err: fmt.Errorf("failed to copy terraform file to working directory: %v", err)}
+	}
+
+	err = tf.Init(ctx)
+	if err != nil {
+		return "", &hcpVPCError{action: action, err: fmt.Errorf("failed to perform terraform init: %v", err)}
+	}
+
+	err = r.awsCredentials.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
+		err = tf.Plan(
+			ctx,
+			tfexec.Var(fmt.Sprintf("aws_region=%s", awsRegion)),
+			tfexec.Var(fmt.Sprintf("cluster_name=%s", clusterName)),
+		)
+		if err != nil {
+			return &hcpVPCError{action: action, err: fmt.Errorf("failed to perform terraform plan: %v", err)}
+		}
+
+		plan, err = tf.ShowPlan(ctx)
+		if err != nil {
+			return &hcpVPCError{action: action, err: fmt.Errorf("failed to perform terraform show: %v", err)}
+		}
+
+		return nil
+	})
+
+	return plan, err
+}
+
 // deleteHostedControlPlaneVPC deletes the aws vpc used for provisioning hosted control plane clusters
 func (r *Provider) deleteHostedControlPlaneVPC(ctx context.Context, clusterName, awsRegion, workingDir string) error {
-	const action = "create"
+	const action = "delete"
 
 	if clusterName == "" || awsRegion == "" || workingDir == "" {
 		return &hcpVPCError{action: action, err: fmt.Errorf("one or more parameters is empty")}
@@ -133,6 +209,9 @@ func (r *Provider) deleteHostedControlPlaneVPC(ctx context.Context, clusterName,
 		_ = tf.Uninstall(ctx)
 	}()
 
+	closeArtifacts := r.saveTerraformOutput(tf, "delete-vpc")
+	defer closeArtifacts()
+
 	log.Println("Deleting AWS VPC")
 
 	err = tf.Init(ctx)
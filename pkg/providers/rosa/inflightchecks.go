@@ -0,0 +1,114 @@
+package rosa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// InflightCheck represents an ocm inflight check (e.g. egress verification) run while installing a cluster
+type InflightCheck struct {
+	ID        string
+	Name      string
+	State     string
+	Details   string
+	StartedAt time.Time
+	EndedAt   time.Time
+}
+
+// inflightCheckError represents the custom error
+type inflightCheckError struct {
+	clusterID string
+	failed    []*InflightCheck
+}
+
+// Error returns the formatted error message when inflightCheckError is invoked
+func (i *inflightCheckError) Error() string {
+	checks := make([]string, 0, len(i.failed))
+	for _, check := range i.failed {
+		checks = append(checks, fmt.Sprintf("%s: %s", check.Name, check.Details))
+	}
+	return fmt.Sprintf("cluster %q inflight checks failed:\n%s", i.clusterID, strings.Join(checks, "\n"))
+}
+
+// InflightChecks returns the clusters inflight checks
+func (r *Provider) InflightChecks(ctx context.Context, clusterID string) ([]*InflightCheck, error) {
+	response, err := r.ClustersMgmt().V1().Clusters().Cluster(clusterID).InflightChecks().List().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster %q inflight checks: %v", clusterID, err)
+	}
+
+	checks := make([]*InflightCheck, 0, response.Items().Len())
+	for _, item := range response.Items().Slice() {
+		details, err := json.Marshal(item.Details())
+		if err != nil {
+			details = []byte(fmt.Sprint(item.Details()))
+		}
+
+		checks = append(checks, &InflightCheck{
+			ID:        item.ID(),
+			Name:      item.Name(),
+			State:     string(item.State()),
+			Details:   string(details),
+			StartedAt: item.StartedAt(),
+			EndedAt:   item.EndedAt(),
+		})
+	}
+
+	return checks, nil
+}
+
+// checkInflightChecks returns an error identifying the failed inflight checks,
+// it reports whether the clusters inflight checks exist and have all passed
+func (r *Provider) checkInflightChecks(ctx context.Context, clusterID string) (bool, error) {
+	checks, err := r.InflightChecks(ctx, clusterID)
+	if err != nil {
+		return false, err
+	}
+
+	var failed []*InflightCheck
+	passed := len(checks) > 0
+	for _, check := range checks {
+		switch clustersmgmtv1.InflightCheckState(check.State) {
+		case clustersmgmtv1.InflightCheckStateFailed:
+			failed = append(failed, check)
+		case clustersmgmtv1.InflightCheckStatePassed:
+		default:
+			passed = false
+		}
+	}
+
+	if len(failed) > 0 {
+		return false, &inflightCheckError{clusterID: clusterID, failed: failed}
+	}
+
+	return passed, nil
+}
+
+// WaitForInflightChecks waits for the clusters inflight checks, created once the cluster starts
+// installing, to pass. It fails as soon as any check fails with the checks details (e.g. the
+// egress destinations that are blocked) rather than waiting for the timeout
+func (r *Provider) WaitForInflightChecks(ctx context.Context, clusterID string, timeout time.Duration) error {
+	return wait.PollUntilContextTimeout(ctx, 30*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		passed, err := r.checkInflightChecks(ctx, clusterID)
+		if _, ok := err.(*inflightCheckError); ok {
+			return false, err
+		}
+		if err != nil {
+			log.Println(err)
+			return false, nil
+		}
+
+		if !passed {
+			log.Printf("Cluster %q inflight checks are running", clusterID)
+		}
+
+		return passed, nil
+	})
+}
@@ -0,0 +1,196 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const ingressOperatorNamespace = "openshift-ingress-operator"
+
+// IngressOptions represents data used to create or update an additional (non default) ingress
+type IngressOptions struct {
+	// Private exposes the ingress on an internal load balancer only
+	Private bool
+	// RouteSelectors are the route labels the ingress admits, an empty selector admits all routes
+	RouteSelectors map[string]string
+}
+
+// ingressError represents the custom error
+type ingressError struct {
+	action string
+	err    error
+}
+
+// Error returns the formatted error message when ingressError is invoked
+func (i *ingressError) Error() string {
+	return fmt.Sprintf("%s ingress failed: %v", i.action, i.err)
+}
+
+// buildIngress builds the ocm ingress from the ingress options
+func buildIngress(options *IngressOptions) (*clustersmgmtv1.Ingress, error) {
+	listening := clustersmgmtv1.ListeningMethodExternal
+	if options.Private {
+		listening = clustersmgmtv1.ListeningMethodInternal
+	}
+
+	ingress, err := clustersmgmtv1.NewIngress().
+		Listening(listening).
+		RouteSelectors(options.RouteSelectors).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build ingress: %v", err)
+	}
+
+	return ingress, nil
+}
+
+// CreateIngress adds an additional ingress to an existing cluster and returns it, the
+// ingresses dns name and id are assigned by ocm
+func (r *Provider) CreateIngress(ctx context.Context, clusterID string, options *IngressOptions) (*clustersmgmtv1.Ingress, error) {
+	const action = "create"
+
+	ingress, err := buildIngress(options)
+	if err != nil {
+		return nil, &ingressError{action: action, err: err}
+	}
+
+	response, err := r.ClustersMgmt().V1().Clusters().Cluster(clusterID).Ingresses().Add().Body(ingress).SendContext(ctx)
+	if err != nil {
+		return nil, &ingressError{action: action, err: fmt.Errorf("failed to add cluster %q ingress: %v", clusterID, err)}
+	}
+
+	log.Printf("Cluster %q ingress %q created (dns name=%s)", clusterID, response.Body().ID(), response.Body().DNSName())
+
+	return response.Body(), nil
+}
+
+// UpdateIngress updates the visibility and route selectors of an existing cluster ingress
+func (r *Provider) UpdateIngress(ctx context.Context, clusterID, ingressID string, options *IngressOptions) (*clustersmgmtv1.Ingress, error) {
+	const action = "update"
+
+	ingress, err := buildIngress(options)
+	if err != nil {
+		return nil, &ingressError{action: action, err: err}
+	}
+
+	response, err := r.ClustersMgmt().V1().Clusters().Cluster(clusterID).Ingresses().Ingress(ingressID).Update().Body(ingress).SendContext(ctx)
+	if err != nil {
+		return nil, &ingressError{action: action, err: fmt.Errorf("failed to update cluster %q ingress %q: %v", clusterID, ingressID, err)}
+	}
+
+	return response.Body(), nil
+}
+
+// DeleteIngress removes an additional ingress from the cluster
+func (r *Provider) DeleteIngress(ctx context.Context, clusterID, ingressID string) error {
+	_, err := r.ClustersMgmt().V1().Clusters().Cluster(clusterID).Ingresses().Ingress(ingressID).Delete().SendContext(ctx)
+	if err != nil {
+		return &ingressError{action: "delete", err: fmt.Errorf("failed to delete cluster %q ingress %q: %v", clusterID, ingressID, err)}
+	}
+
+	return nil
+}
+
+// Ingresses returns the clusters ingresses, including the default ingress
+func (r *Provider) Ingresses(ctx context.Context, clusterID string) ([]*clustersmgmtv1.Ingress, error) {
+	response, err := r.ClustersMgmt().V1().Clusters().Cluster(clusterID).Ingresses().List().SendContext(ctx)
+	if err != nil {
+		return nil, &ingressError{action: "list", err: fmt.Errorf("failed to list cluster %q ingresses: %v", clusterID, err)}
+	}
+
+	return response.Items().Slice(), nil
+}
+
+// ingressControllerName returns the name of the ingress controller the managed ingress
+// operator creates for the ingress, the first label of its dns name (e.g. apps2)
+func ingressControllerName(ingress *clustersmgmtv1.Ingress) string {
+	if ingress.Default() {
+		return "default"
+	}
+
+	name, _, _ := strings.Cut(ingress.DNSName(), ".")
+
+	return name
+}
+
+// VerifyIngressController waits for the ingresses ingress controller to be available in the
+// cluster with the ingresses visibility and route selectors
+//
+//	ingress, err := provider.CreateIngress(ctx, clusterID, &rosa.IngressOptions{Private: true})
+//	Expect(err).ShouldNot(HaveOccurred())
+//	Expect(rosa.VerifyIngressController(ctx, client, ingress, 15*time.Minute)).To(Succeed())
+func VerifyIngressController(ctx context.Context, client *openshift.Client, ingress *clustersmgmtv1.Ingress, timeout time.Duration) error {
+	name := ingressControllerName(ingress)
+	if name == "" {
+		return &ingressError{action: "verify", err: fmt.Errorf("ingress %q has no dns name", ingress.ID())}
+	}
+
+	var lastErr error
+
+	err := wait.PollUntilContextTimeout(ctx, 15*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		var controller operatorv1.IngressController
+		if err := client.Get(ctx, name, ingressOperatorNamespace, &controller); err != nil {
+			lastErr = fmt.Errorf("failed to get ingress controller %q: %v", name, err)
+			log.Println(lastErr)
+			return false, nil
+		}
+
+		if lastErr = checkIngressController(&controller, ingress); lastErr != nil {
+			log.Printf("Waiting for ingress controller %q: %v", name, lastErr)
+			return false, nil
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		if lastErr != nil {
+			err = lastErr
+		}
+		return &ingressError{action: "verify", err: err}
+	}
+
+	log.Printf("Ingress controller %q matches ingress %q", name, ingress.ID())
+
+	return nil
+}
+
+// checkIngressController verifies the ingress controller is available and matches the ingress
+func checkIngressController(controller *operatorv1.IngressController, ingress *clustersmgmtv1.Ingress) error {
+	scope := operatorv1.ExternalLoadBalancer
+	if ingress.Listening() == clustersmgmtv1.ListeningMethodInternal {
+		scope = operatorv1.InternalLoadBalancer
+	}
+
+	strategy := controller.Spec.EndpointPublishingStrategy
+	if strategy == nil || strategy.LoadBalancer == nil || strategy.LoadBalancer.Scope != scope {
+		return fmt.Errorf("ingress controller %q is not published with a %s load balancer", controller.Name, scope)
+	}
+
+	var matchLabels map[string]string
+	if controller.Spec.RouteSelector != nil {
+		matchLabels = controller.Spec.RouteSelector.MatchLabels
+	}
+
+	if len(matchLabels) > 0 || len(ingress.RouteSelectors()) > 0 {
+		if !reflect.DeepEqual(matchLabels, ingress.RouteSelectors()) {
+			return fmt.Errorf("ingress controller %q route selector %v does not match %v", controller.Name, matchLabels, ingress.RouteSelectors())
+		}
+	}
+
+	for _, condition := range controller.Status.Conditions {
+		if condition.Type == operatorv1.OperatorStatusTypeAvailable && condition.Status == operatorv1.ConditionTrue {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("ingress controller %q is not available", controller.Name)
+}
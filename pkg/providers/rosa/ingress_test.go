@@ -0,0 +1,68 @@
+package rosa
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/osde2e-framework/pkg/clients/kubernetesfake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Ingress", func() {
+	ingressController := func(scope operatorv1.LoadBalancerScope, selectors map[string]string, available operatorv1.ConditionStatus) *operatorv1.IngressController {
+		return &operatorv1.IngressController{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "operator.openshift.io/v1", Kind: "IngressController"},
+			ObjectMeta: metav1.ObjectMeta{Name: "apps2", Namespace: ingressOperatorNamespace},
+			Spec: operatorv1.IngressControllerSpec{
+				EndpointPublishingStrategy: &operatorv1.EndpointPublishingStrategy{
+					Type:         operatorv1.LoadBalancerServiceStrategyType,
+					LoadBalancer: &operatorv1.LoadBalancerStrategy{Scope: scope},
+				},
+				RouteSelector: &metav1.LabelSelector{MatchLabels: selectors},
+			},
+			Status: operatorv1.IngressControllerStatus{
+				Conditions: []operatorv1.OperatorCondition{{Type: operatorv1.OperatorStatusTypeAvailable, Status: available}},
+			},
+		}
+	}
+
+	It("should build the ingress from the options", func() {
+		ingress, err := buildIngress(&IngressOptions{Private: true, RouteSelectors: map[string]string{"route": "private"}})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ingress.Listening()).To(Equal(clustersmgmtv1.ListeningMethodInternal))
+		Expect(ingress.RouteSelectors()).To(HaveKeyWithValue("route", "private"))
+	})
+
+	It("should check the ingress controller matches the ingress", func() {
+		ingress, err := clustersmgmtv1.NewIngress().ID("a1b2").DNSName("apps2.my-cluster.abcd.p1.openshiftapps.com").
+			Listening(clustersmgmtv1.ListeningMethodInternal).RouteSelectors(map[string]string{"route": "private"}).Build()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ingressControllerName(ingress)).To(Equal("apps2"))
+
+		selectors := map[string]string{"route": "private"}
+		Expect(checkIngressController(ingressController(operatorv1.InternalLoadBalancer, selectors, operatorv1.ConditionTrue), ingress)).To(Succeed())
+		Expect(checkIngressController(ingressController(operatorv1.ExternalLoadBalancer, selectors, operatorv1.ConditionTrue), ingress)).ToNot(Succeed())
+		Expect(checkIngressController(ingressController(operatorv1.InternalLoadBalancer, nil, operatorv1.ConditionTrue), ingress)).ToNot(Succeed())
+		Expect(checkIngressController(ingressController(operatorv1.InternalLoadBalancer, selectors, operatorv1.ConditionFalse), ingress)).ToNot(Succeed())
+	})
+
+	It("should verify the clusters ingress controller", func() {
+		server, err := kubernetesfake.NewServer(ingressController(operatorv1.ExternalLoadBalancer, nil, operatorv1.ConditionTrue))
+		Expect(err).ShouldNot(HaveOccurred())
+		DeferCleanup(server.Close)
+
+		client, err := server.Client()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		ingress, err := clustersmgmtv1.NewIngress().ID("a1b2").DNSName("apps2.my-cluster.abcd.p1.openshiftapps.com").
+			Listening(clustersmgmtv1.ListeningMethodExternal).Build()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(VerifyIngressController(context.Background(), client, ingress, time.Second)).To(Succeed())
+	})
+})
@@ -0,0 +1,73 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/openshift/osde2e-framework/pkg/summary"
+)
+
+// clusterLogType represents the type of cluster log
+type clusterLogType string
+
+const (
+	installLog   clusterLogType = "install"
+	uninstallLog clusterLogType = "uninstall"
+)
+
+// clusterLogStreamer tracks the clusters install/uninstall log content emitted so far
+type clusterLogStreamer struct {
+	clusterID string
+	logType   clusterLogType
+	offset    int
+	content   strings.Builder
+}
+
+// newClusterLogStreamer creates a cluster log streamer for the cluster log type
+func newClusterLogStreamer(clusterID string, logType clusterLogType) *clusterLogStreamer {
+	return &clusterLogStreamer{clusterID: clusterID, logType: logType}
+}
+
+// stream fetches the log lines added since the last call and emits them
+func (s *clusterLogStreamer) stream(ctx context.Context, r *Provider) {
+	logs := r.ClustersMgmt().V1().Clusters().Cluster(s.clusterID).Logs()
+
+	logClient := logs.Install()
+	if s.logType == uninstallLog {
+		logClient = logs.Uninstall()
+	}
+
+	response, err := logClient.Get().Offset(s.offset).SendContext(ctx)
+	if err != nil {
+		// Logs are unavailable until the installer starts and once the cluster is removed
+		return
+	}
+
+	content := response.Body().Content()
+	if content == "" {
+		return
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+		log.Printf("[%s %s] %s", s.clusterID, s.logType, line)
+		s.content.WriteString(line + "\n")
+		s.offset++
+	}
+}
+
+// persist writes the full log content streamed to a file and returns the file name
+func (s *clusterLogStreamer) persist() (string, error) {
+	filename := fmt.Sprintf("%s-%s.log", s.clusterID, s.logType)
+
+	err := os.WriteFile(filename, []byte(s.content.String()), 0o600)
+	if err != nil {
+		return filename, fmt.Errorf("failed to write %s log file: %v", s.logType, err)
+	}
+
+	summary.Global().Artifact(filename)
+
+	return filename, nil
+}
@@ -0,0 +1,201 @@
+package rosa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+const (
+	// maximumWorkerDiskSize is the largest root volume size (GiB) supported for worker nodes
+	maximumWorkerDiskSize = 16384
+	// minimumWorkerDiskSize is the smallest root volume size (GiB) supported for classic worker nodes
+	minimumWorkerDiskSize = 128
+	// minimumHostedCPWorkerDiskSize is the smallest root volume size (GiB) supported for hosted control plane worker nodes
+	minimumHostedCPWorkerDiskSize = 75
+)
+
+// defaultComputeMachineTypes are the preferred compute machine types per architecture
+// used when available in the region
+var defaultComputeMachineTypes = map[string]string{
+	"amd64": "m5.xlarge",
+	"arm64": "m6g.xlarge",
+}
+
+// ocmArchitectures maps the architectures to the ocm machine type architecture
+var ocmArchitectures = map[string]string{
+	"amd64": "x86_64",
+	"arm64": "arm64",
+}
+
+// WithDefaultMachineTypes overrides the default compute machine type per region
+// (e.g. {"ap-southeast-4": "m6i.xlarge"}) used when ComputeMachineType is undefined
+func WithDefaultMachineTypes(machineTypes map[string]string) Option {
+	return func(p *Provider) {
+		p.defaultMachineTypes = machineTypes
+	}
+}
+
+// regionMachineType represents a machine type available in a region as returned by the rosa cli
+type regionMachineType struct {
+	ID           string `json:"id"`
+	Architecture string `json:"architecture"`
+	Category     string `json:"category"`
+	CPU          struct {
+		Value float64 `json:"value"`
+	} `json:"cpu"`
+}
+
+// regionMachineTypes returns the machine types available in the region
+func (r *Provider) regionMachineTypes(ctx context.Context, region string) ([]regionMachineType, error) {
+	var machineTypes []regionMachineType
+
+	err := r.awsCredentials.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
+		stdout, _, err := r.runCommand(r.rosaCommand(ctx, "list", "instance-types", "--region", region, "--output", "json"))
+		if err != nil {
+			return err
+		}
+
+		return json.Unmarshal([]byte(fmt.Sprint(stdout)), &machineTypes)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list machine types for region %q: %v", region, err)
+	}
+
+	return machineTypes, nil
+}
+
+// selectDefaultMachineType selects the default machine type for the architecture from the
+// available machine types, the preferred machine type when available otherwise the first
+// general purpose machine type with the same number of cpus
+func selectDefaultMachineType(machineTypes []regionMachineType, architecture string) (string, error) {
+	preferred := defaultComputeMachineTypes[architecture]
+	ocmArchitecture := ocmArchitectures[architecture]
+
+	var candidates []string
+	for _, machineType := range machineTypes {
+		machineTypeArchitecture := machineType.Architecture
+		if machineTypeArchitecture == "" {
+			machineTypeArchitecture = ocmArchitectures["amd64"]
+		}
+
+		if machineTypeArchitecture != ocmArchitecture {
+			continue
+		}
+
+		if machineType.ID == preferred {
+			return preferred, nil
+		}
+
+		if machineType.Category == "general_purpose" && machineType.CPU.Value == 4 {
+			candidates = append(candidates, machineType.ID)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no general purpose %s machine types available", architecture)
+	}
+
+	sort.Strings(candidates)
+
+	return candidates[0], nil
+}
+
+// resolveComputeMachineType sets the compute machine type when undefined using the providers
+// region override, otherwise the default machine type available in the region
+func (r *Provider) resolveComputeMachineType(ctx context.Context, options *CreateClusterOptions) error {
+	if options.ComputeMachineType != "" {
+		return nil
+	}
+
+	region := r.awsCredentials.Region
+
+	if machineType, ok := r.defaultMachineTypes[region]; ok {
+		options.ComputeMachineType = machineType
+		return nil
+	}
+
+	architecture := options.Architecture
+	if architecture == "" {
+		architecture = "amd64"
+	}
+
+	if _, ok := ocmArchitectures[architecture]; !ok {
+		return fmt.Errorf("architecture %q is not supported", architecture)
+	}
+
+	machineTypes, err := r.regionMachineTypes(ctx, region)
+	if err != nil {
+		return err
+	}
+
+	machineType, err := selectDefaultMachineType(machineTypes, architecture)
+	if err != nil {
+		return fmt.Errorf("failed to resolve default compute machine type for region %q: %v", region, err)
+	}
+
+	if machineType != defaultComputeMachineTypes[architecture] {
+		log.Printf("Default compute machine type %q is unavailable in region %q, using %q",
+			defaultComputeMachineTypes[architecture], region, machineType)
+	}
+
+	options.ComputeMachineType = machineType
+
+	return nil
+}
+
+// getMachineType gets the aws machine type from ocm
+func (r *Provider) getMachineType(ctx context.Context, machineType string) (*clustersmgmtv1.MachineType, error) {
+	response, err := r.ClustersMgmt().V1().MachineTypes().List().
+		Search(fmt.Sprintf("cloud_provider.id = 'aws' AND id = %s", quoteSearchValue(machineType))).
+		Page(1).
+		Size(1).
+		SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get machine type %q: %v", machineType, err)
+	}
+
+	if response.Total() == 0 {
+		return nil, fmt.Errorf("machine type %q does not exist", machineType)
+	}
+
+	return response.Items().Slice()[0], nil
+}
+
+// validateWorkerDiskSize verifies the worker disk size is within the allowed range for the compute machine type
+func (r *Provider) validateWorkerDiskSize(ctx context.Context, options *CreateClusterOptions) error {
+	if options.WorkerDiskSize == 0 {
+		return nil
+	}
+
+	machineType, err := r.getMachineType(ctx, options.ComputeMachineType)
+	if err != nil {
+		return err
+	}
+
+	return checkWorkerDiskSize(options, machineType)
+}
+
+// checkWorkerDiskSize verifies the worker disk size is within the allowed range, ocm only reports the
+// machine types root volume size (no maximum) which raises the classic or hosted control plane minimum
+func checkWorkerDiskSize(options *CreateClusterOptions, machineType *clustersmgmtv1.MachineType) error {
+	minimum := minimumWorkerDiskSize
+	if options.HostedCP {
+		minimum = minimumHostedCPWorkerDiskSize
+	}
+
+	if size := machineType.RootVolume().AWS().Size(); size > minimum {
+		minimum = size
+	}
+
+	if options.WorkerDiskSize < minimum || options.WorkerDiskSize > maximumWorkerDiskSize {
+		return fmt.Errorf("worker disk size %dGiB for machine type %q must be between %dGiB and %dGiB",
+			options.WorkerDiskSize, machineType.ID(), minimum, maximumWorkerDiskSize)
+	}
+
+	return nil
+}
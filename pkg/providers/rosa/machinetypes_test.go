@@ -0,0 +1,102 @@
+package rosa
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osde2e-framework/pkg/clients/ocmfake"
+)
+
+var _ = Describe("selectDefaultMachineType", func() {
+	machineType := func(id, architecture, category string, cpu float64) regionMachineType {
+		m := regionMachineType{ID: id, Architecture: architecture, Category: category}
+		m.CPU.Value = cpu
+		return m
+	}
+
+	It("should prefer the default machine type when available", func() {
+		machineTypes := []regionMachineType{
+			machineType("m6i.xlarge", "x86_64", "general_purpose", 4),
+			machineType("m5.xlarge", "x86_64", "general_purpose", 4),
+		}
+
+		Expect(selectDefaultMachineType(machineTypes, "amd64")).To(Equal("m5.xlarge"))
+	})
+
+	It("should fall back to a general purpose machine type of the architecture", func() {
+		machineTypes := []regionMachineType{
+			machineType("r6i.xlarge", "x86_64", "memory_optimized", 4),
+			machineType("m7g.xlarge", "arm64", "general_purpose", 4),
+			machineType("m7i.xlarge", "", "general_purpose", 4),
+			machineType("m6i.xlarge", "x86_64", "general_purpose", 4),
+			machineType("m6i.2xlarge", "x86_64", "general_purpose", 8),
+		}
+
+		Expect(selectDefaultMachineType(machineTypes, "amd64")).To(Equal("m6i.xlarge"))
+		Expect(selectDefaultMachineType(machineTypes, "arm64")).To(Equal("m7g.xlarge"))
+	})
+
+	It("should fail when no machine types are available", func() {
+		_, err := selectDefaultMachineType(nil, "amd64")
+		Expect(err).Should(HaveOccurred())
+	})
+})
+
+var _ = Describe("validateWorkerDiskSize", func() {
+	machineType := func(rootVolumeSize int) *clustersmgmtv1.MachineType {
+		builder := clustersmgmtv1.NewMachineType().ID("m5.xlarge")
+		if rootVolumeSize > 0 {
+			builder = builder.RootVolume(clustersmgmtv1.NewMachineTypeRootVolume().AWS(clustersmgmtv1.NewAWSVolume().Size(rootVolumeSize)))
+		}
+		m, err := builder.Build()
+		Expect(err).ShouldNot(HaveOccurred())
+		return m
+	}
+
+	DescribeTable("checkWorkerDiskSize",
+		func(options *CreateClusterOptions, rootVolumeSize int, valid bool) {
+			err := checkWorkerDiskSize(options, machineType(rootVolumeSize))
+			if valid {
+				Expect(err).ShouldNot(HaveOccurred())
+				return
+			}
+			Expect(err).Should(HaveOccurred())
+		},
+		Entry("should allow the classic minimum", &CreateClusterOptions{WorkerDiskSize: 128}, 0, true),
+		Entry("should allow the hosted control plane minimum", &CreateClusterOptions{WorkerDiskSize: 75, HostedCP: true}, 0, true),
+		Entry("should allow the maximum", &CreateClusterOptions{WorkerDiskSize: 16384}, 0, true),
+		Entry("should reject sizes below the classic minimum", &CreateClusterOptions{WorkerDiskSize: 75}, 0, false),
+		Entry("should reject sizes above the maximum", &CreateClusterOptions{WorkerDiskSize: 16385, HostedCP: true}, 0, false),
+		Entry("should reject sizes below the machine types root volume size", &CreateClusterOptions{WorkerDiskSize: 200}, 300, false),
+		Entry("should allow sizes from the machine types root volume size", &CreateClusterOptions{WorkerDiskSize: 300}, 300, true),
+		Entry("should keep the minimum when the machine types root volume is smaller", &CreateClusterOptions{WorkerDiskSize: 100}, 50, false),
+	)
+
+	It("should look up the quoted compute machine type", func(ctx context.Context) {
+		server := ocmfake.NewServer()
+		DeferCleanup(server.Close)
+
+		var search string
+		server.Handle(http.MethodGet, "/api/clusters_mgmt/v1/machine_types", func(w http.ResponseWriter, r *http.Request) {
+			search = r.URL.Query().Get("search")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"kind":"MachineTypeList","page":1,"size":1,"total":1,
+				"items":[{"kind":"MachineType","id":"m5.xlarge","root_volume":{"aws":{"size":300}}}]}`))
+		})
+
+		client, err := server.Client(ctx)
+		Expect(err).ShouldNot(HaveOccurred())
+		provider := &Provider{Client: client}
+
+		Expect(provider.validateWorkerDiskSize(ctx, &CreateClusterOptions{})).To(Succeed())
+		Expect(search).To(BeEmpty())
+
+		err = provider.validateWorkerDiskSize(ctx, &CreateClusterOptions{ComputeMachineType: "m5'", WorkerDiskSize: 128})
+		Expect(err).To(MatchError(ContainSubstring("between 300GiB and 16384GiB")))
+		Expect(search).To(Equal("cloud_provider.id = 'aws' AND id = 'm5'''"))
+	})
+})
@@ -0,0 +1,200 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// AWSCommand represents an aws cli command rosa emits in manual mode
+type AWSCommand struct {
+	// Args are the aws cli arguments, excluding the aws executable
+	Args []string
+}
+
+// String returns the command as it would be entered in a shell
+func (a *AWSCommand) String() string {
+	quoted := make([]string, 0, len(a.Args)+1)
+	quoted = append(quoted, "aws")
+	for _, arg := range a.Args {
+		if strings.ContainsAny(arg, " \t\"'") {
+			arg = fmt.Sprintf("'%s'", strings.ReplaceAll(arg, "'", `'\''`))
+		}
+		quoted = append(quoted, arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// ManualModeCommands represents the aws cli commands rosa emits in manual mode to create a
+// resource, for environments where iam changes are reviewed before being applied
+type ManualModeCommands struct {
+	// Resource is the rosa resource the commands create (e.g. operator-roles)
+	Resource string
+	// Dir holds the policy documents the commands reference (file://), the commands must
+	// run from it. It is the callers responsibility to remove it
+	Dir      string
+	Commands []*AWSCommand
+}
+
+// manualModeError represents the custom error
+type manualModeError struct {
+	resource string
+	err      error
+}
+
+// Error returns the formatted error message when manualModeError is invoked
+func (m *manualModeError) Error() string {
+	return fmt.Sprintf("manual mode %s commands failed: %v", m.resource, m.err)
+}
+
+// AccountRolesCommands returns the aws cli commands to create the account roles
+func (r *Provider) AccountRolesCommands(ctx context.Context, prefix, version, channelGroup string) (*ManualModeCommands, error) {
+	return r.manualModeCommands(ctx, "account-roles", createAccountRolesCommandArgs(prefix, version, channelGroup))
+}
+
+// OperatorRolesCommands returns the aws cli commands to create the clusters operator roles
+func (r *Provider) OperatorRolesCommands(ctx context.Context, clusterID string) (*ManualModeCommands, error) {
+	return r.manualModeCommands(ctx, "operator-roles", operatorRolesCommandArgs("create", clusterID))
+}
+
+// OIDCProviderCommands returns the aws cli commands to create the clusters oidc provider
+func (r *Provider) OIDCProviderCommands(ctx context.Context, clusterID string) (*ManualModeCommands, error) {
+	return r.manualModeCommands(ctx, "oidc-provider", oidcProviderCommandArgs("create", clusterID))
+}
+
+// RunManualModeCommands executes the commands in order using the providers aws credentials,
+// stopping at the first failure
+func (r *Provider) RunManualModeCommands(ctx context.Context, commands *ManualModeCommands) error {
+	return r.awsCredentials.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
+		for _, command := range commands.Commands {
+			log.Printf("Running %s", command)
+
+			awsCommand := exec.CommandContext(ctx, "aws", command.Args...)
+			awsCommand.Dir = commands.Dir
+
+			_, stderr, err := r.runCommand(awsCommand)
+			if err != nil {
+				return &manualModeError{resource: commands.Resource, err: fmt.Errorf("%s: %v: %s", command, err, stderr)}
+			}
+		}
+
+		return nil
+	})
+}
+
+// manualModeCommands runs the rosa create command in manual mode from a new directory and
+// returns the aws cli commands it emits
+func (r *Provider) manualModeCommands(ctx context.Context, resource string, commandArgs []string) (*ManualModeCommands, error) {
+	for i, arg := range commandArgs {
+		if arg == "--mode" && i+1 < len(commandArgs) {
+			commandArgs[i+1] = manualMode
+		}
+	}
+
+	dir, err := os.MkdirTemp("", fmt.Sprintf("rosa-%s-", resource))
+	if err != nil {
+		return nil, &manualModeError{resource: resource, err: fmt.Errorf("failed to create directory: %v", err)}
+	}
+
+	var output string
+	err = r.awsCredentials.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
+		rosaCommand := r.rosaCommand(ctx, commandArgs...)
+		rosaCommand.Dir = dir
+
+		stdout, stderr, err := r.runCommand(rosaCommand)
+		if err != nil {
+			return fmt.Errorf("%v: %s", err, stderr)
+		}
+		output = fmt.Sprintf("%s\n%s", stdout, stderr)
+
+		return nil
+	})
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, &manualModeError{resource: resource, err: err}
+	}
+
+	commands, err := parseAWSCommands(output)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, &manualModeError{resource: resource, err: err}
+	}
+
+	return &ManualModeCommands{Resource: resource, Dir: dir, Commands: commands}, nil
+}
+
+// parseAWSCommands returns the aws cli commands in the rosa output, joining the lines
+// continued with a trailing backslash
+func parseAWSCommands(output string) ([]*AWSCommand, error) {
+	var (
+		commands []*AWSCommand
+		current  string
+	)
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+
+		if current == "" && !strings.HasPrefix(line, "aws ") {
+			continue
+		}
+
+		if strings.HasSuffix(line, "\\") {
+			current += strings.TrimSuffix(line, "\\") + " "
+			continue
+		}
+		current += line
+
+		fields, err := shellFields(current)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %q: %v", current, err)
+		}
+		commands = append(commands, &AWSCommand{Args: fields[1:]})
+		current = ""
+	}
+
+	return commands, nil
+}
+
+// shellFields splits the command into its arguments, removing single and double quotes
+func shellFields(command string) ([]string, error) {
+	var (
+		fields  []string
+		field   strings.Builder
+		inField bool
+		quote   rune
+	)
+
+	for _, c := range command {
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+			field.WriteRune(c)
+		case c == '\'' || c == '"':
+			quote = c
+			inField = true
+		case c == ' ' || c == '\t':
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+		default:
+			field.WriteRune(c)
+			inField = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+
+	if inField {
+		fields = append(fields, field.String())
+	}
+
+	return fields, nil
+}
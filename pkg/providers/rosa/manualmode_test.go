@@ -0,0 +1,37 @@
+package rosa
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manual Mode", func() {
+	It("should parse the aws commands rosa emits", func() {
+		output := `I: All policy files saved to the current directory
+I: Run the following commands to create the operator roles:
+
+aws iam create-role \
+	--role-name my-prefix-openshift-ingress-operator-cloud-credentials \
+	--assume-role-policy-document file://operator_ingress_policy.json \
+	--tags Key=rosa_cluster_id,Value=abc "Key=rosa_role_prefix,Value=my prefix"
+
+aws iam attach-role-policy --role-name my-prefix-openshift-ingress-operator-cloud-credentials --policy-arn arn:aws:iam::123:policy/ingress
+`
+		commands, err := parseAWSCommands(output)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(commands).To(HaveLen(2))
+		Expect(commands[0].Args).To(Equal([]string{
+			"iam", "create-role",
+			"--role-name", "my-prefix-openshift-ingress-operator-cloud-credentials",
+			"--assume-role-policy-document", "file://operator_ingress_policy.json",
+			"--tags", "Key=rosa_cluster_id,Value=abc", "Key=rosa_role_prefix,Value=my prefix",
+		}))
+		Expect(commands[0].String()).To(HaveSuffix(`'Key=rosa_role_prefix,Value=my prefix'`))
+		Expect(commands[1].Args[:2]).To(Equal([]string{"iam", "attach-role-policy"}))
+	})
+
+	It("should fail on unterminated quotes", func() {
+		_, err := parseAWSCommands(`aws iam create-role --description "unterminated`)
+		Expect(err).Should(HaveOccurred())
+	})
+})
@@ -3,7 +3,6 @@ package rosa
 import (
 	"context"
 	"fmt"
-	"os/exec"
 	"strconv"
 	"strings"
 
@@ -12,6 +11,14 @@ import (
 	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 )
 
+const (
+	// oidcConfigPrefixProperty is the cluster property recording the oidc config prefix
+	oidcConfigPrefixProperty = "osde2e_oidc_config_prefix"
+	// oidcConfigReusedProperty is the cluster property recording the oidc config was supplied
+	// by the caller, it and its oidc provider are not deleted with the cluster
+	oidcConfigReusedProperty = "osde2e_oidc_config_reused"
+)
+
 // oidcConfigError represents the custom error
 type oidcConfigError struct {
 	action string
@@ -23,29 +30,27 @@ func (o *oidcConfigError) Error() string {
 	return fmt.Sprintf("%s oidc config failed: %v", o.action, o.err)
 }
 
-// createOIDCConfig creates an oidc config if one does not already exist
-func (r *Provider) createOIDCConfig(ctx context.Context, prefix, installerRoleArn string, managed bool) (string, error) {
+// createOIDCConfig creates an oidc config if one does not already exist.
+// It reports whether the oidc config was created or already existed
+func (r *Provider) createOIDCConfig(ctx context.Context, prefix, installerRoleArn string, managed bool) (string, bool, error) {
 	const action = "create"
 	var oidcConfigID string
 
 	if prefix == "" || installerRoleArn == "" {
-		return "", &oidcConfigError{action: action, err: fmt.Errorf("some parameters are undefined")}
+		return "", false, &oidcConfigError{action: action, err: fmt.Errorf("some parameters are undefined")}
 	}
 
 	oidcConfig, err := r.oidcConfigLookup(ctx, prefix)
 	if oidcConfig != nil {
-		return oidcConfig.ID(), nil
+		return oidcConfig.ID(), false, nil
 	} else if err != nil {
-		return "", &oidcConfigError{action: action, err: err}
+		return "", false, &oidcConfigError{action: action, err: err}
 	}
 
-	commandArgs := []string{"create", "oidc-config", "--output", "json", "--mode", "auto", "--yes"}
-	commandArgs = append(commandArgs, fmt.Sprintf("--managed=%s", strconv.FormatBool(managed)))
-	commandArgs = append(commandArgs, "--installer-role-arn", installerRoleArn)
-	commandArgs = append(commandArgs, "--prefix", prefix)
+	commandArgs := createOIDCConfigCommandArgs(prefix, installerRoleArn, managed)
 
 	err = r.awsCredentials.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
-		stdout, _, err := cmd.Run(exec.CommandContext(ctx, r.rosaBinary, commandArgs...))
+		stdout, _, err := r.runCommand(r.rosaCommand(ctx, commandArgs...))
 		if err != nil {
 			return err
 		}
@@ -60,18 +65,45 @@ func (r *Provider) createOIDCConfig(ctx context.Context, prefix, installerRoleAr
 		return nil
 	})
 	if err != nil {
-		return "", &oidcConfigError{action: action, err: err}
+		return "", true, &oidcConfigError{action: action, err: err}
 	}
 
-	return oidcConfigID, nil
+	return oidcConfigID, true, nil
+}
+
+// createOIDCConfigCommandArgs returns the rosa command arguments to create an oidc config
+func createOIDCConfigCommandArgs(prefix, installerRoleArn string, managed bool) []string {
+	commandArgs := []string{"create", "oidc-config", "--output", "json", "--mode", "auto", "--yes"}
+	commandArgs = append(commandArgs, fmt.Sprintf("--managed=%s", strconv.FormatBool(managed)))
+	commandArgs = append(commandArgs, "--installer-role-arn", installerRoleArn)
+	return append(commandArgs, "--prefix", prefix)
+}
+
+// deleteOIDCConfigCommandArgs returns the rosa command arguments to delete an oidc config
+func deleteOIDCConfigCommandArgs(oidcConfigID string) []string {
+	return []string{"delete", "oidc-config", "--mode", "auto", "--oidc-config-id", oidcConfigID, "--yes"}
+}
+
+// oidcProviderCommandArgs returns the rosa command arguments to create or delete the clusters oidc provider
+func oidcProviderCommandArgs(action, clusterID string) []string {
+	return []string{action, "oidc-provider", "--cluster", clusterID, "--mode", "auto", "--yes"}
+}
+
+// deleteOIDCProviderCommandArgs returns the rosa command arguments to delete the clusters oidc
+// provider or, when the cluster id is empty, the oidc provider of the oidc config
+func deleteOIDCProviderCommandArgs(clusterID, oidcConfigID string) []string {
+	if clusterID == "" {
+		return []string{"delete", "oidc-provider", "--oidc-config-id", oidcConfigID, "--mode", "auto", "--yes"}
+	}
+	return oidcProviderCommandArgs("delete", clusterID)
 }
 
 // deleteOIDCConfig deletes the oidc config using the id
 func (r *Provider) deleteOIDCConfig(ctx context.Context, oidcConfigID string) error {
-	commandArgs := []string{"delete", "oidc-config", "--mode", "auto", "--oidc-config-id", oidcConfigID, "--yes"}
+	commandArgs := deleteOIDCConfigCommandArgs(oidcConfigID)
 
 	err := r.awsCredentials.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
-		_, _, err := cmd.Run(exec.CommandContext(ctx, r.rosaBinary, commandArgs...))
+		_, _, err := r.runCommand(r.rosaCommand(ctx, commandArgs...))
 		return err
 	})
 	if err != nil {
@@ -81,14 +113,14 @@ func (r *Provider) deleteOIDCConfig(ctx context.Context, oidcConfigID string) er
 	return nil
 }
 
-// getClusterOIDCConfig retrieves the oidc config associated with the cluster
-func (r *Provider) getClusterOIDCConfig(ctx context.Context, clusterID string) (*clustersmgmtv1.OidcConfig, error) {
-	response, err := r.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().SendContext(ctx)
+// getOIDCConfig retrieves the oidc config using the id
+func (r *Provider) getOIDCConfig(ctx context.Context, oidcConfigID string) (*clustersmgmtv1.OidcConfig, error) {
+	response, err := r.ClustersMgmt().V1().OidcConfigs().OidcConfig(oidcConfigID).Get().SendContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve cluster: %v", err)
+		return nil, &oidcConfigError{action: "get", err: fmt.Errorf("failed to retrieve oidc config %q from ocm: %v", oidcConfigID, err)}
 	}
 
-	return response.Body().AWS().STS().OidcConfig(), nil
+	return response.Body(), nil
 }
 
 // oidcConfigLookup checks if an oidc config already exists using the provided prefix
@@ -107,12 +139,32 @@ func (r *Provider) oidcConfigLookup(ctx context.Context, prefix string) (*cluste
 	return nil, nil
 }
 
-// deleteOIDCConfigProvider deletes the oidc config provider associated to the cluster
-func (r *Provider) deleteOIDCConfigProvider(ctx context.Context, clusterID string) error {
-	commandArgs := []string{"delete", "oidc-provider", "--cluster", clusterID, "--mode", "auto", "--yes"}
+// createOIDCConfigProvider creates the oidc config provider associated to the cluster
+func (r *Provider) createOIDCConfigProvider(ctx context.Context, clusterID string) error {
+	commandArgs := oidcProviderCommandArgs("create", clusterID)
+
+	err := r.awsCredentials.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
+		_, _, err := r.runCommand(r.rosaCommand(ctx, commandArgs...))
+		return err
+	})
+	if err != nil {
+		return &oidcConfigError{action: "create", err: err}
+	}
+
+	return nil
+}
+
+// deleteOIDCConfigProvider deletes the oidc config provider associated to the cluster or, when the
+// cluster id is empty (e.g. the cluster no longer exists), the oidc provider of the oidc config
+func (r *Provider) deleteOIDCConfigProvider(ctx context.Context, clusterID, oidcConfigID string) error {
+	if clusterID == "" && oidcConfigID == "" {
+		return &oidcConfigError{action: "delete", err: fmt.Errorf("cluster id or oidc config id is required")}
+	}
+
+	commandArgs := deleteOIDCProviderCommandArgs(clusterID, oidcConfigID)
 
 	err := r.awsCredentials.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
-		_, _, err := cmd.Run(exec.CommandContext(ctx, r.rosaBinary, commandArgs...))
+		_, _, err := r.runCommand(r.rosaCommand(ctx, commandArgs...))
 		return err
 	})
 	if err != nil {
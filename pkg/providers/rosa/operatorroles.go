@@ -3,9 +3,17 @@ package rosa
 import (
 	"context"
 	"fmt"
-	"os/exec"
+	"strings"
 
-	"github.com/openshift/osde2e-framework/internal/cmd"
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+const (
+	// maxRoleNameLength is the maximum length aws allows for iam role names
+	maxRoleNameLength = 64
+	// operatorRolesReusedProperty is the cluster property recording the operator roles were
+	// pre-created, they are not deleted with the cluster
+	operatorRolesReusedProperty = "osde2e_operator_roles_reused"
 )
 
 // operatorRoleError represents the custom error
@@ -19,12 +27,109 @@ func (o *operatorRoleError) Error() string {
 	return fmt.Sprintf("%s operator role failed: %v", o.action, o.err)
 }
 
-// deleteOIDCConfigProvider deletes the oidc config provider associated to the cluster
-func (r *Provider) deleteOperatorRoles(ctx context.Context, clusterID string) error {
-	commandArgs := []string{"delete", "operator-roles", "--cluster", clusterID, "--mode", "auto", "--yes"}
+// operatorIAMRoles returns the operator iam roles the cluster requires for the prefix provided
+func (r *Provider) operatorIAMRoles(ctx context.Context, accountID, prefix string, hostedCP bool) ([]*clustersmgmtv1.OperatorIAMRoleBuilder, error) {
+	var operatorIAMRoles []*clustersmgmtv1.OperatorIAMRoleBuilder
+
+	response, err := r.ClustersMgmt().V1().AWSInquiries().STSCredentialRequests().List().
+		Parameter("is_hypershift", hostedCP).
+		SendContext(ctx)
+	if err != nil {
+		return nil, &operatorRoleError{action: "get", err: fmt.Errorf("failed to get sts credential requests: %v", err)}
+	}
+
+	for _, credentialRequest := range response.Items().Slice() {
+		operator := credentialRequest.Operator()
+
+		roleName := fmt.Sprintf("%s-%s-%s", prefix, operator.Namespace(), operator.Name())
+		if len(roleName) > maxRoleNameLength {
+			roleName = roleName[:maxRoleNameLength]
+		}
+
+		operatorIAMRoles = append(operatorIAMRoles, clustersmgmtv1.NewOperatorIAMRole().
+			Name(operator.Name()).
+			Namespace(operator.Namespace()).
+			RoleARN(iamRoleARN(r.awsCredentials.Region, accountID, roleName)))
+	}
+
+	return operatorIAMRoles, nil
+}
+
+// getOperatorRoles returns the arns of the operator roles the cluster requires for the prefix
+// that do not exist in the aws account, requires the aws cli
+func (r *Provider) getOperatorRoles(ctx context.Context, prefix string, hostedCP bool) ([]string, error) {
+	identity, err := r.awsIdentity(ctx)
+	if err != nil {
+		return nil, &operatorRoleError{action: "get", err: err}
+	}
+
+	operatorIAMRoles, err := r.operatorIAMRoles(ctx, identity.accountID, prefix, hostedCP)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, operatorIAMRole := range operatorIAMRoles {
+		role, err := operatorIAMRole.Build()
+		if err != nil {
+			return nil, &operatorRoleError{action: "get", err: fmt.Errorf("failed to build operator role: %v", err)}
+		}
+
+		var output struct{}
+		roleName := role.RoleARN()[strings.LastIndex(role.RoleARN(), "/")+1:]
+
+		err = awsCLI(ctx, r.awsCredentials, &output, "iam", "get-role", "--role-name", roleName)
+		if err != nil {
+			if !strings.Contains(err.Error(), "NoSuchEntity") {
+				return nil, &operatorRoleError{action: "get", err: err}
+			}
+			missing = append(missing, role.RoleARN())
+		}
+	}
+
+	return missing, nil
+}
+
+// operatorRolesCommandArgs returns the rosa command arguments to create or delete the clusters operator roles
+func operatorRolesCommandArgs(action, clusterID string) []string {
+	return []string{action, "operator-roles", "--cluster", clusterID, "--mode", "auto", "--yes"}
+}
+
+// createOperatorRoles creates the operator roles for the cluster
+func (r *Provider) createOperatorRoles(ctx context.Context, clusterID string) error {
+	commandArgs := operatorRolesCommandArgs("create", clusterID)
+
+	err := r.awsCredentials.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
+		_, _, err := r.runCommand(r.rosaCommand(ctx, commandArgs...))
+		return err
+	})
+	if err != nil {
+		return &operatorRoleError{action: "create", err: err}
+	}
+
+	return nil
+}
+
+// deleteOperatorRolesCommandArgs returns the rosa command arguments to delete the clusters operator
+// roles or, when the cluster id is empty, the operator roles with the prefix
+func deleteOperatorRolesCommandArgs(clusterID, prefix string) []string {
+	if clusterID == "" {
+		return []string{"delete", "operator-roles", "--prefix", prefix, "--mode", "auto", "--yes"}
+	}
+	return operatorRolesCommandArgs("delete", clusterID)
+}
+
+// deleteOperatorRoles deletes the operator roles associated to the cluster or, when the
+// cluster id is empty (e.g. the cluster no longer exists), the operator roles with the prefix
+func (r *Provider) deleteOperatorRoles(ctx context.Context, clusterID, prefix string) error {
+	if clusterID == "" && prefix == "" {
+		return &operatorRoleError{action: "delete", err: fmt.Errorf("cluster id or operator roles prefix is required")}
+	}
+
+	commandArgs := deleteOperatorRolesCommandArgs(clusterID, prefix)
 
 	err := r.awsCredentials.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
-		_, _, err := cmd.Run(exec.CommandContext(ctx, r.rosaBinary, commandArgs...))
+		_, _, err := r.runCommand(r.rosaCommand(ctx, commandArgs...))
 		return err
 	})
 	if err != nil {
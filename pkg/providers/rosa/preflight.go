@@ -0,0 +1,54 @@
+package rosa
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PreflightCheck represents the outcome of a single pre-flight check
+type PreflightCheck struct {
+	Name   string
+	Passed bool
+	Detail string
+}
+
+// PreflightResult represents the outcome of the pre-flight checks performed
+// before attempting to create a cluster
+type PreflightResult struct {
+	Checks []PreflightCheck
+}
+
+// add records the outcome of a check
+func (p *PreflightResult) add(name string, passed bool, detail string) {
+	p.Checks = append(p.Checks, PreflightCheck{Name: name, Passed: passed, Detail: detail})
+}
+
+// Failed returns the checks that did not pass
+func (p *PreflightResult) Failed() []PreflightCheck {
+	var failed []PreflightCheck
+	for _, check := range p.Checks {
+		if !check.Passed {
+			failed = append(failed, check)
+		}
+	}
+	return failed
+}
+
+// String returns the checks formatted as a checklist
+func (p *PreflightResult) String() string {
+	lines := make([]string, 0, len(p.Checks))
+	for _, check := range p.Checks {
+		mark := "[x]"
+		if !check.Passed {
+			mark = "[ ]"
+		}
+
+		line := fmt.Sprintf("%s %s", mark, check.Name)
+		if check.Detail != "" {
+			line = fmt.Sprintf("%s: %s", line, check.Detail)
+		}
+
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
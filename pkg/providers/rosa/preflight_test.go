@@ -0,0 +1,18 @@
+package rosa
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PreflightResult", func() {
+	It("should format the checks as a checklist", func() {
+		result := &PreflightResult{}
+		result.add("subnets are shared with the cluster account", true, "")
+		result.add("private hosted zone is associated with the vpc", false, "hosted zone \"example.com\" is not private")
+
+		Expect(result.Failed()).To(HaveLen(1))
+		Expect(result.String()).To(Equal("[x] subnets are shared with the cluster account\n" +
+			"[ ] private hosted zone is associated with the vpc: hosted zone \"example.com\" is not private"))
+	})
+})
@@ -0,0 +1,72 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openshift/osde2e-framework/pkg/provenance"
+)
+
+// reservedPropertyPrefixes are the cluster property key prefixes set by the framework and
+// ocm that cannot be provided by callers
+var reservedPropertyPrefixes = []string{"osde2e_", "rosa_"}
+
+// validateProperties verifies the cluster properties do not use reserved keys
+func validateProperties(properties map[string]string) error {
+	for key := range properties {
+		if key == "" {
+			return fmt.Errorf("cluster property key is required")
+		}
+
+		if strings.Contains(key, ":") {
+			return fmt.Errorf("cluster property key %q must not contain ':'", key)
+		}
+
+		for _, prefix := range reservedPropertyPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				return fmt.Errorf("cluster property key %q is reserved (%s prefix)", key, prefix)
+			}
+		}
+	}
+
+	return nil
+}
+
+// clusterProperties returns the callers properties merged with the framework provenance properties
+func clusterProperties(properties map[string]string) map[string]string {
+	result := provenance.FromEnvironment().Properties()
+
+	for key, value := range properties {
+		result[key] = value
+	}
+
+	return result
+}
+
+// propertiesCommandArgs returns the properties as the repeated rosa --properties key:value arguments
+func propertiesCommandArgs(properties map[string]string) []string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	commandArgs := make([]string, 0, 2*len(keys))
+	for _, key := range keys {
+		commandArgs = append(commandArgs, "--properties", fmt.Sprintf("%s:%s", key, properties[key]))
+	}
+
+	return commandArgs
+}
+
+// SetClusterProperty sets the cluster property on an existing cluster, keeping its other properties.
+// Keys prefixed with osde2e_ or rosa_ are reserved for the properties set by the framework
+func (r *Provider) SetClusterProperty(ctx context.Context, clusterID, key, value string) error {
+	if err := validateProperties(map[string]string{key: value}); err != nil {
+		return fmt.Errorf("failed to set cluster %q property: %v", clusterID, err)
+	}
+
+	return r.SetClusterProperties(ctx, clusterID, map[string]string{key: value})
+}
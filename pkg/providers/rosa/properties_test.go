@@ -0,0 +1,22 @@
+package rosa
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("cluster properties", func() {
+	It("should reject reserved keys", func() {
+		Expect(validateProperties(map[string]string{"team": "sd-cicd"})).To(Succeed())
+		Expect(validateProperties(map[string]string{"osde2e_job_url": "https://example.com"})).ShouldNot(Succeed())
+		Expect(validateProperties(map[string]string{"rosa_creator_arn": "arn"})).ShouldNot(Succeed())
+		Expect(validateProperties(map[string]string{"a:b": "c"})).ShouldNot(Succeed())
+	})
+
+	It("should serialize to repeated rosa arguments", func() {
+		Expect(propertiesCommandArgs(map[string]string{"team": "sd-cicd", "owner": "osde2e"})).To(Equal([]string{
+			"--properties", "owner:osde2e",
+			"--properties", "team:sd-cicd",
+		}))
+	})
+})
@@ -0,0 +1,97 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+
+	ocmclient "github.com/openshift/osde2e-framework/pkg/clients/ocm"
+)
+
+const (
+	// standardInstancesQuotaCode is the aws service quota of the running on-demand standard
+	// (A, C, D, H, I, M, R, T, Z) instances vcpus
+	standardInstancesQuotaCode = "L-1216C47A"
+	// classicControlPlaneVCPUs are the vcpus of the classic clusters control plane (3 x m5.2xlarge)
+	// and infra (2 x r5.xlarge) nodes
+	classicControlPlaneVCPUs = 3*8 + 2*4
+)
+
+// rosaClusterQuota is the ocm quota resource rosa clusters consume
+var rosaClusterQuota = ocmclient.QuotaResource{Product: "ROSA", ResourceType: "cluster.aws", BYOC: "byoc"}
+
+// ValidateQuota validates the ocm organization has the quota to create a rosa cluster and the aws
+// account has the on-demand standard instances vcpu quota for the clusters nodes (requires the aws
+// cli, skipped when the quota cannot be read). All checks are performed and returned as a checklist,
+// an error is only returned when the checks could not be performed
+//
+//	result, err := provider.ValidateQuota(ctx, options)
+//	Expect(err).ShouldNot(HaveOccurred())
+//	Expect(result.Failed()).To(BeEmpty(), result.String())
+func (r *Provider) ValidateQuota(ctx context.Context, options *CreateClusterOptions) (*PreflightResult, error) {
+	result := &PreflightResult{}
+
+	name := "organization has rosa cluster quota"
+	if err := r.CheckQuota(ctx, rosaClusterQuota, 1); err != nil {
+		result.add(name, false, err.Error())
+	} else {
+		result.add(name, true, "")
+	}
+
+	if options.ComputeMachineType == "" {
+		return result, nil
+	}
+
+	machineType, err := r.getMachineType(ctx, options.ComputeMachineType)
+	if err != nil {
+		return nil, err
+	}
+
+	required := options.Replicas * int(machineType.CPU().Value())
+	if !options.HostedCP {
+		required += classicControlPlaneVCPUs
+	}
+
+	r.checkVCPUQuota(ctx, result, required)
+
+	return result, nil
+}
+
+// checkVCPUQuota checks the aws accounts on-demand standard instances vcpu quota has the required vcpus
+// available in addition to the vcpus of the running instances
+func (r *Provider) checkVCPUQuota(ctx context.Context, result *PreflightResult, required int) {
+	name := fmt.Sprintf("aws account has %d on-demand standard instance vcpus available", required)
+
+	var quota struct {
+		Quota struct {
+			Value float64 `json:"Value"`
+		} `json:"Quota"`
+	}
+
+	err := awsCLI(ctx, r.awsCredentials, &quota, "service-quotas", "get-service-quota",
+		"--service-code", "ec2", "--quota-code", standardInstancesQuotaCode)
+	if err != nil {
+		result.add(name, true, fmt.Sprintf("skipped, %v", err))
+		return
+	}
+
+	var cpuOptions []struct {
+		CoreCount      int `json:"CoreCount"`
+		ThreadsPerCore int `json:"ThreadsPerCore"`
+	}
+
+	err = awsCLI(ctx, r.awsCredentials, &cpuOptions, "ec2", "describe-instances",
+		"--filters", "Name=instance-state-name,Values=pending,running",
+		"--query", "Reservations[].Instances[].CpuOptions")
+	if err != nil {
+		result.add(name, true, fmt.Sprintf("skipped, %v", err))
+		return
+	}
+
+	used := 0
+	for _, cpu := range cpuOptions {
+		used += cpu.CoreCount * cpu.ThreadsPerCore
+	}
+
+	available := int(quota.Quota.Value) - used
+	result.add(name, available >= required, fmt.Sprintf("quota=%d, in use=%d", int(quota.Quota.Value), used))
+}
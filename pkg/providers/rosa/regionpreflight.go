@@ -0,0 +1,93 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+)
+
+// minimumMultiAZZones is the number of availability zones multi-az clusters are spread across
+const minimumMultiAZZones = 3
+
+// ValidateRegion validates the providers region supports the cluster options: the region is
+// enabled, supports hosted control planes (when hosted), supports multi-az with enough
+// availability zones (when multi-az, requires the aws cli) and offers the compute machine type.
+// All checks are performed and returned as a checklist, an error is only returned when the
+// checks could not be performed
+//
+//	result, err := provider.ValidateRegion(ctx, options)
+//	Expect(err).ShouldNot(HaveOccurred())
+//	Expect(result.Failed()).To(BeEmpty(), result.String())
+func (r *Provider) ValidateRegion(ctx context.Context, options *CreateClusterOptions) (*PreflightResult, error) {
+	region := r.awsCredentials.Region
+
+	response, err := r.ClustersMgmt().V1().CloudProviders().CloudProvider("aws").Regions().Region(region).Get().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get region %q: %v", region, err)
+	}
+	cloudRegion := response.Body()
+
+	result := &PreflightResult{}
+
+	result.add(fmt.Sprintf("region %s is enabled", region), cloudRegion.Enabled(), "")
+
+	if options.HostedCP {
+		result.add("region supports hosted control planes", cloudRegion.SupportsHypershift(), "")
+	}
+
+	if options.MultiAZ {
+		result.add("region supports multi-az clusters", cloudRegion.SupportsMultiAZ(), "")
+		r.checkAvailabilityZones(ctx, result)
+	}
+
+	if options.ComputeMachineType != "" {
+		r.checkMachineTypeOffered(ctx, result, options.ComputeMachineType)
+	}
+
+	return result, nil
+}
+
+// checkAvailabilityZones checks the region has enough availability zones for multi-az clusters
+func (r *Provider) checkAvailabilityZones(ctx context.Context, result *PreflightResult) {
+	name := fmt.Sprintf("region has at least %d availability zones", minimumMultiAZZones)
+
+	var output struct {
+		AvailabilityZones []struct {
+			ZoneName string `json:"ZoneName"`
+		} `json:"AvailabilityZones"`
+	}
+
+	err := awsCLI(ctx, r.awsCredentials, &output, "ec2", "describe-availability-zones",
+		"--filters", "Name=state,Values=available", "Name=zone-type,Values=availability-zone")
+	if err != nil {
+		result.add(name, false, err.Error())
+		return
+	}
+
+	zones := len(output.AvailabilityZones)
+	if zones < minimumMultiAZZones {
+		result.add(name, false, fmt.Sprintf("found %d", zones))
+		return
+	}
+
+	result.add(name, true, "")
+}
+
+// checkMachineTypeOffered checks the machine type is offered in the region
+func (r *Provider) checkMachineTypeOffered(ctx context.Context, result *PreflightResult, machineType string) {
+	name := fmt.Sprintf("machine type %s is offered in the region", machineType)
+
+	machineTypes, err := r.regionMachineTypes(ctx, r.awsCredentials.Region)
+	if err != nil {
+		result.add(name, false, err.Error())
+		return
+	}
+
+	for _, regionMachineType := range machineTypes {
+		if regionMachineType.ID == machineType {
+			result.add(name, true, "")
+			return
+		}
+	}
+
+	result.add(name, false, "")
+}
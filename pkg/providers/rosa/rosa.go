@@ -1,184 +1,288 @@
 package rosa
 
 import (
-	"archive/tar"
-	"compress/gzip"
-	"runtime"
-
 	"context"
 	"fmt"
-	"io"
-	"net/http"
+	"log"
+	"net/url"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/Masterminds/semver"
-	"github.com/openshift/osde2e-framework/internal/cmd"
 	ocmclient "github.com/openshift/osde2e-framework/pkg/clients/ocm"
+	"github.com/openshift/osde2e-framework/pkg/events"
 	awscloud "github.com/openshift/osde2e-framework/pkg/providers/clouds/aws"
 )
 
-const (
-	minimumVersion = "1.2.22"
-	tarFilename    = "rosa.tar.gz"
-)
+const minimumVersion = "1.2.22"
 
 // Provider is a rosa provider
 type Provider struct {
 	*ocmclient.Client
 	awsCredentials *awscloud.AWSCredentials
+	configDir      string
+	ownsConfigDir  bool
 	rosaBinary     string
+	cliVersion     string
+	cliCacheDir    string
+	// artifactDir is the directory the rosa and terraform command output and kubeconfigs are written to
+	artifactDir string
+	// kubeConfigDir is the directory kubeconfigs are written to, defaults to the artifact directory
+	kubeConfigDir string
+	// proxyURL is the proxy the ocm requests and rosa commands are sent through, the proxy from the environment when nil
+	proxyURL *url.URL
+
+	// credentials and environment are used to log in again when the rosa session is about to expire
+	credentials ocmclient.Credentials
+	environment ocmclient.Environment
+	sessionMu   sync.Mutex
+
+	// defaultMachineTypes are the default compute machine types per region
+	defaultMachineTypes map[string]string
+	// events notifies the hooks of the clusters provisioning progress
+	events events.Dispatcher
+	// expiryWatcher warns when the ocm token, aws session or cluster kubeconfigs are about to expire
+	expiryWatcher     *events.ExpiryWatcher
+	expiryInterval    time.Duration
+	stopExpiryWatcher context.CancelFunc
 }
 
-// providerError represents the provider custom error
-type providerError struct {
-	err error
-}
+// Option configures optional settings for the rosa provider
+type Option func(*Provider)
 
-// Error returns the formatted error message when providerError is invoked
-func (r *providerError) Error() string {
-	return fmt.Sprintf("failed to construct rosa provider: %v", r.err)
+// WithConfigDir sets the directory the rosa cli stores its login session in.
+// When undefined, a temporary directory is created for the provider. A valid
+// session already stored in the directory for the same environment is reused.
+func WithConfigDir(dir string) Option {
+	return func(p *Provider) {
+		p.configDir = dir
+	}
 }
 
-// cliExist checks if rosa cli is available else it will download it
-func cliCheck() (string, error) {
-	var (
-		url          = fmt.Sprintf("https://mirror.openshift.com/pub/openshift-v4/clients/rosa/%s", minimumVersion)
-		rosaFilename = fmt.Sprintf("%s/rosa", os.TempDir())
-	)
+// WithArtifactDir writes the stdout and stderr of every rosa and terraform command run to
+// timestamped files in the directory, along with the clusters kubeconfigs, so failed
+// provisions can be debugged from the ci artifacts
+func WithArtifactDir(dir string) Option {
+	return func(p *Provider) {
+		p.artifactDir = dir
+	}
+}
 
-	defer func() {
-		_ = os.Remove(tarFilename)
-	}()
+// WithKubeConfigDir writes the clusters kubeconfigs to the directory instead of the artifact directory
+func WithKubeConfigDir(dir string) Option {
+	return func(p *Provider) {
+		p.kubeConfigDir = dir
+	}
+}
 
-	runtimeOS := runtime.GOOS
-	switch runtimeOS {
-	case "linux":
-		url = fmt.Sprintf("%s/rosa-linux.tar.gz", url)
-	case "darwin":
-		url = fmt.Sprintf("%s/rosa-macosx.tar.gz", url)
-	default:
-		return "", fmt.Errorf("operating system %q is not supported", runtimeOS)
+// WithProxy sends the ocm requests and the rosa commands requests through the http or https proxy
+// instead of the proxy from the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables
+func WithProxy(proxyURL *url.URL) Option {
+	return func(p *Provider) {
+		p.proxyURL = proxyURL
 	}
+}
 
-	path, err := exec.LookPath("rosa")
-	if path != "" && err == nil {
-		return path, nil
+// WithEventHooks adds hooks notified when provisioning phases start and end and when
+// the cluster state changes, requires the provisioning-events feature flag
+func WithEventHooks(hooks ...events.Hook) Option {
+	return func(p *Provider) {
+		p.events.Add(hooks...)
 	}
+}
 
-	response, err := http.Get(url)
-	if err != nil || response.StatusCode == http.StatusNotFound {
-		return "", fmt.Errorf("failed to download %s: %v", url, err)
+// WithCredentialExpiryWarnings checks every interval when the ocm token, aws session
+// credentials and cluster kubeconfig certificates expire, logging and sending a warning
+// event (see WithEventHooks) once they expire within warnBefore
+//
+//	rosa.New(ctx, token, env, rosa.WithCredentialExpiryWarnings(30*time.Minute, 5*time.Minute))
+func WithCredentialExpiryWarnings(warnBefore, interval time.Duration) Option {
+	return func(p *Provider) {
+		p.expiryWatcher = events.NewExpiryWatcher(&p.events, warnBefore)
+		p.expiryInterval = interval
 	}
-	defer response.Body.Close()
+}
+
+// providerError represents the provider custom error
+type providerError struct {
+	err error
+}
+
+// Error returns the formatted error message when providerError is invoked
+func (r *providerError) Error() string {
+	return fmt.Sprintf("failed to construct rosa provider: %v", r.err)
+}
 
-	tarFile, err := os.Create(tarFilename)
+// versionCheck verifies the rosa cli version meets the minimal version required
+func versionCheck(ctx context.Context, rosaBinary string) error {
+	currentVersion, err := cliVersion(ctx, rosaBinary)
 	if err != nil {
-		return "", fmt.Errorf("failed to create %s tar file: %v", tarFilename, err)
+		return fmt.Errorf("versionCheck failed: %v", err)
 	}
-	defer tarFile.Close()
 
-	rosaFile, err := os.Create(rosaFilename)
+	minVersion, err := semver.NewVersion(minimumVersion)
 	if err != nil {
-		return "", fmt.Errorf("failed to create %s tar file: %v", rosaFilename, err)
+		return fmt.Errorf("versionCheck failed to parse minimum version to semantic version: %v", err)
 	}
 
-	err = os.Chmod(rosaFilename, 0o755)
-	if err != nil {
-		return "", fmt.Errorf("failed to set file permissions to 0755 for %s: %v", rosaFilename, err)
+	if minVersion.Compare(currentVersion) == 1 {
+		return fmt.Errorf("current rosa version is %q and must be >= %q", currentVersion.String(), minVersion)
 	}
 
-	defer rosaFile.Close()
+	return nil
+}
 
-	_, err = io.Copy(tarFile, response.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to write content to %s: %v", tarFilename, err)
+// rosaCommand returns the rosa cli command isolated to the providers configuration directory
+func (r *Provider) rosaCommand(ctx context.Context, args ...string) *exec.Cmd {
+	if len(args) > 0 && args[0] != "login" && args[0] != "whoami" {
+		r.refreshSession(ctx)
 	}
 
-	tarFileReader, err := os.Open(tarFilename)
-	if err != nil {
-		return "", fmt.Errorf("failed to open %s: %v", tarFilename, err)
+	command := exec.CommandContext(ctx, r.rosaBinary, args...)
+	command.Env = append(os.Environ(), fmt.Sprintf("OCM_CONFIG=%s", filepath.Join(r.configDir, "ocm.json")))
+	if r.proxyURL != nil {
+		command.Env = append(command.Env, fmt.Sprintf("HTTPS_PROXY=%s", r.proxyURL), fmt.Sprintf("HTTP_PROXY=%s", r.proxyURL))
 	}
-	defer tarFileReader.Close()
+	return command
+}
 
-	gzipReader, err := gzip.NewReader(tarFileReader)
+// sessionExist checks if the providers configuration directory has a valid session for the environment
+func (r *Provider) sessionExist(ctx context.Context, environment string) bool {
+	stdout, _, err := r.runCommand(r.rosaCommand(ctx, "whoami"))
 	if err != nil {
-		return "", fmt.Errorf("failed to create gzip reader for %s: %v", tarFilename, err)
+		return false
 	}
-	defer gzipReader.Close()
 
-	tarReader := tar.NewReader(gzipReader)
-
-	for {
-		_, err := tarReader.Next()
-		if err == io.EOF {
-			break
-		}
-
-		if err != nil {
-			break
-		}
-		_, err = io.Copy(rosaFile, tarReader)
-		if err != nil {
-			break
+	for _, line := range strings.Split(fmt.Sprint(stdout), "\n") {
+		if strings.HasPrefix(line, "OCM API:") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "OCM API:")) == environment
 		}
 	}
 
-	return rosaFilename, nil
+	return false
 }
 
-// versionCheck verifies the rosa cli version meets the minimal version required
-func versionCheck(ctx context.Context, rosaBinary string) error {
-	stdout, _, err := cmd.Run(exec.CommandContext(ctx, rosaBinary, "version"))
-	if err != nil {
-		return err
-	}
+// awsIdentity represents the aws identity the rosa cli is operating as
+type awsIdentity struct {
+	accountID string
+	arn       string
+}
 
-	versionSlice := strings.SplitAfter(fmt.Sprint(stdout), "\n")
-	if len(versionSlice) == 0 {
-		return fmt.Errorf("versionCheck failed to get version from cli standard out")
-	}
+// awsIdentity returns the aws identity for the providers aws credentials
+func (r *Provider) awsIdentity(ctx context.Context) (*awsIdentity, error) {
+	identity := &awsIdentity{}
 
-	currentVersion, err := semver.NewVersion(strings.ReplaceAll(versionSlice[0], "\n", ""))
-	if err != nil {
-		return fmt.Errorf("versionCheck failed to parse version to semantic version: %v", err)
-	}
+	err := r.awsCredentials.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
+		stdout, _, err := r.runCommand(r.rosaCommand(ctx, "whoami"))
+		if err != nil {
+			return err
+		}
 
-	minVersion, err := semver.NewVersion(minimumVersion)
+		for _, line := range strings.Split(fmt.Sprint(stdout), "\n") {
+			key, value, found := strings.Cut(line, ":")
+			if !found {
+				continue
+			}
+
+			switch strings.TrimSpace(key) {
+			case "AWS Account ID":
+				identity.accountID = strings.TrimSpace(value)
+			case "AWS ARN":
+				identity.arn = strings.TrimSpace(value)
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("versionCheck failed to parse minimum version to semantic version: %v", err)
+		return nil, fmt.Errorf("failed to get aws identity: %v", err)
 	}
 
-	if minVersion.Compare(currentVersion) == 1 {
-		return fmt.Errorf("current rosa version is %q and must be >= %q", currentVersion.String(), minVersion)
+	if identity.accountID == "" || identity.arn == "" {
+		return nil, fmt.Errorf("failed to get aws identity: account id and/or arn not found")
 	}
 
-	return nil
+	return identity, nil
 }
 
-// verifyCredentials validates the ocm token and aws credentials to ensure they are valid
-func verifyCredentials(ctx context.Context, rosaBinary string, token, environment string, awsCredentials *awscloud.AWSCredentials) error {
-	commandArgs := []string{"login", "--token", token, "--env", environment}
+// verifyCredentials validates the ocm credentials and aws credentials to ensure they are valid
+func (r *Provider) verifyCredentials(ctx context.Context, credentials ocmclient.Credentials, environment ocmclient.Environment) error {
+	r.credentials = credentials
+	r.environment = environment
 
-	return awsCredentials.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
-		_, _, err := cmd.Run(exec.CommandContext(ctx, rosaBinary, commandArgs...))
-		if err != nil {
-			return fmt.Errorf("login failed %v", err)
+	return r.awsCredentials.CallFuncWithCredentials(ctx, func(ctx context.Context) error {
+		if r.sessionExist(ctx, string(environment)) {
+			log.Printf("Reusing existing rosa session from %s", r.configDir)
+			return nil
 		}
-		return nil
+
+		return r.login(ctx)
 	})
 }
 
+// Close closes the ocm connection and removes the temporary rosa configuration directory if one was created
+func (r *Provider) Close() error {
+	if r.stopExpiryWatcher != nil {
+		r.stopExpiryWatcher()
+	}
+
+	if r.ownsConfigDir {
+		_ = os.RemoveAll(r.configDir)
+	}
+
+	return r.Connection.Close()
+}
+
+// NewFromClient returns a provider for the read only operations (ListClusters, DescribeCluster,
+// DescribeClusterSummary) that only use ocm, without requiring the rosa cli or aws credentials
+func NewFromClient(client *ocmclient.Client) *Provider {
+	return &Provider{Client: client, awsCredentials: &awscloud.AWSCredentials{}}
+}
+
 // New handles constructing the rosa provider which creates a connection
 // to openshift cluster manager "ocm". It is the callers responsibility
-// to close the ocm connection when they are finished (defer provider.Connection.Close())
+// to close the provider when they are finished (defer provider.Close())
 func New(ctx context.Context, token string, environment ocmclient.Environment, args ...any) (*Provider, error) {
 	if environment == "" || token == "" {
 		return nil, &providerError{err: fmt.Errorf("some parameters are undefined, unable to construct osd provider")}
 	}
 
-	rosaBinary, err := cliCheck()
+	return NewWithCredentials(ctx, ocmclient.Credentials{Token: token}, environment, args...)
+}
+
+// NewWithCredentials handles constructing the rosa provider authenticating the rosa cli and ocm
+// using the credentials (e.g. service account client credentials). It is the callers responsibility
+// to close the provider when they are finished (defer provider.Close())
+func NewWithCredentials(ctx context.Context, credentials ocmclient.Credentials, environment ocmclient.Environment, args ...any) (*Provider, error) {
+	if environment == "" {
+		return nil, &providerError{err: fmt.Errorf("some parameters are undefined, unable to construct osd provider")}
+	}
+
+	provider := &Provider{
+		awsCredentials: &awscloud.AWSCredentials{},
+	}
+
+	awsCredentialsProvided := false
+	for _, arg := range args {
+		switch arg := arg.(type) {
+		case *awscloud.AWSCredentials:
+			if awsCredentialsProvided {
+				return nil, &providerError{err: fmt.Errorf("only one AWSCredentials can be provided")}
+			}
+			provider.awsCredentials = arg
+			awsCredentialsProvided = true
+		case Option:
+			arg(provider)
+		default:
+			return nil, &providerError{err: fmt.Errorf("unsupported argument type %T", arg)}
+		}
+	}
+
+	rosaBinary, err := cliCheck(ctx, provider.cliVersion, provider.cliCacheDir)
 	if err != nil {
 		return nil, &providerError{err: err}
 	}
@@ -188,31 +292,68 @@ func New(ctx context.Context, token string, environment ocmclient.Environment, a
 		return nil, &providerError{err: err}
 	}
 
-	awsCredentials := &awscloud.AWSCredentials{}
-	if len(args) == 1 {
-		awsCredentials = args[0].(*awscloud.AWSCredentials)
-	} else if len(args) > 1 {
-		return nil, &providerError{err: fmt.Errorf("only one AWSCredentials can be provided")}
+	provider.rosaBinary = rosaBinary
+
+	if provider.artifactDir != "" {
+		if err = os.MkdirAll(provider.artifactDir, 0o755); err != nil {
+			return nil, &providerError{err: fmt.Errorf("failed to create artifact directory: %v", err)}
+		}
 	}
 
-	err = awsCredentials.ValidateAndFetchCredentials()
+	err = provider.awsCredentials.ValidateAndFetchCredentials()
 	if err != nil {
 		return nil, &providerError{err: fmt.Errorf("aws authentication data check failed: %v", err)}
 	}
 
-	err = verifyCredentials(ctx, rosaBinary, token, string(environment), awsCredentials)
+	// the temporary configuration directory holds the ocm login and is removed when the
+	// provider fails to be constructed
+	constructed := false
+	defer func() {
+		if !constructed && provider.ownsConfigDir {
+			_ = os.RemoveAll(provider.configDir)
+		}
+	}()
+
+	if provider.configDir == "" {
+		provider.configDir, err = os.MkdirTemp("", "rosa-")
+		if err != nil {
+			return nil, &providerError{err: fmt.Errorf("failed to create rosa configuration directory: %v", err)}
+		}
+		provider.ownsConfigDir = true
+	} else if err = os.MkdirAll(provider.configDir, 0o700); err != nil {
+		return nil, &providerError{err: fmt.Errorf("failed to create rosa configuration directory: %v", err)}
+	}
+
+	var clientOptions []ocmclient.Option
+	if provider.proxyURL != nil {
+		clientOptions = append(clientOptions, ocmclient.WithProxy(provider.proxyURL))
+	}
+
+	// the ocm client verifies the token before the rosa cli logs in, reporting expired tokens and
+	// tokens for the wrong environment instead of the rosa login failure
+	provider.Client, err = ocmclient.NewWithCredentials(ctx, credentials, environment, clientOptions...)
 	if err != nil {
 		return nil, &providerError{err: err}
 	}
 
-	ocmClient, err := ocmclient.New(ctx, token, environment)
+	err = provider.verifyCredentials(ctx, credentials, environment)
 	if err != nil {
+		_ = provider.Connection.Close()
 		return nil, &providerError{err: err}
 	}
+	provider.Client.ArtifactDir = provider.artifactDir
+	provider.Client.KubeConfigDir = provider.kubeConfigDir
+
+	if provider.expiryWatcher != nil {
+		provider.expiryWatcher.Watch("ocm token", provider.TokenExpiration)
+		provider.expiryWatcher.Watch("aws credentials", provider.awsCredentials.Expiration)
+
+		var watcherCtx context.Context
+		watcherCtx, provider.stopExpiryWatcher = context.WithCancel(context.Background())
+		go provider.expiryWatcher.Run(watcherCtx, provider.expiryInterval)
+	}
+
+	constructed = true
 
-	return &Provider{
-		awsCredentials: awsCredentials,
-		rosaBinary:     rosaBinary,
-		Client:         ocmClient,
-	}, nil
+	return provider, nil
 }
@@ -0,0 +1,327 @@
+package rosa
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/openshift/osde2e-framework/internal/cmd"
+)
+
+const (
+	// LatestCLIVersion requests the latest rosa cli release
+	LatestCLIVersion = "latest"
+
+	rosaMirrorURL     = "https://mirror.openshift.com/pub/openshift-v4/clients/rosa"
+	checksumsFilename = "sha256sum.txt"
+	checksumExtension = ".sha256"
+)
+
+// WithCLIVersion sets the rosa cli version (e.g. 1.2.30 or LatestCLIVersion) the provider uses.
+// The version is downloaded, verified against the published sha256 sums and cached. When
+// undefined, the rosa cli found in the path is used or the minimum version is downloaded
+func WithCLIVersion(version string) Option {
+	return func(p *Provider) {
+		p.cliVersion = version
+	}
+}
+
+// WithCLICacheDir sets the directory rosa cli versions are cached in, it defaults to
+// osde2e-framework/rosa in the users cache directory
+func WithCLICacheDir(dir string) Option {
+	return func(p *Provider) {
+		p.cliCacheDir = dir
+	}
+}
+
+// defaultCLICacheDir returns the default rosa cli cache directory
+func defaultCLICacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "osde2e-framework", "rosa")
+}
+
+// cliArchiveName returns the rosa cli release archive name for the operating system
+func cliArchiveName() (string, error) {
+	switch runtime.GOOS {
+	case "linux":
+		return "rosa-linux.tar.gz", nil
+	case "darwin":
+		return "rosa-macosx.tar.gz", nil
+	default:
+		return "", fmt.Errorf("operating system %q is not supported", runtime.GOOS)
+	}
+}
+
+// cliCheck returns the rosa cli binary for the version, when no version is requested
+// the rosa cli found in the path is used otherwise the minimum version is installed
+func cliCheck(ctx context.Context, version, cacheDir string) (string, error) {
+	if version == "" {
+		path, err := exec.LookPath("rosa")
+		if path != "" && err == nil {
+			return path, nil
+		}
+		version = minimumVersion
+	}
+
+	if cacheDir == "" {
+		cacheDir = defaultCLICacheDir()
+	}
+
+	return installCLI(ctx, version, cacheDir)
+}
+
+// installCLI returns the cached rosa cli binary for the version, downloading and
+// verifying it against the published sha256 sums when it is not cached
+func installCLI(ctx context.Context, version, cacheDir string) (string, error) {
+	archive, err := cliArchiveName()
+	if err != nil {
+		return "", err
+	}
+
+	releaseURL := fmt.Sprintf("%s/%s", rosaMirrorURL, version)
+
+	checksum, err := cliChecksum(ctx, releaseURL, archive)
+	if err != nil {
+		return "", err
+	}
+
+	if binary := cachedCLI(cacheDir, version, checksum); binary != "" {
+		return binary, nil
+	}
+
+	log.Printf("Downloading rosa cli %s from %s", version, releaseURL)
+
+	tempDir, err := os.MkdirTemp("", "rosa-cli-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary directory: %v", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+
+	downloaded := filepath.Join(tempDir, "rosa")
+	if err = downloadCLI(ctx, fmt.Sprintf("%s/%s", releaseURL, archive), checksum, downloaded); err != nil {
+		return "", err
+	}
+
+	// The latest release is cached under the version it resolves to
+	if version == LatestCLIVersion {
+		resolved, err := cliVersion(ctx, downloaded)
+		if err != nil {
+			return "", err
+		}
+		version = resolved.String()
+	}
+
+	versionDir := filepath.Join(cacheDir, version)
+	if err = os.MkdirAll(versionDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create rosa cli cache directory %s: %v", versionDir, err)
+	}
+
+	binary := filepath.Join(versionDir, "rosa")
+	if err = moveFile(downloaded, binary); err != nil {
+		return "", err
+	}
+
+	if err = os.WriteFile(binary+checksumExtension, []byte(checksum), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write rosa cli checksum: %v", err)
+	}
+
+	log.Printf("Cached rosa cli %s in %s", version, versionDir)
+
+	return binary, nil
+}
+
+// cachedCLI returns the cached rosa cli binary downloaded from an archive with the checksum,
+// the latest release is matched against all cached versions
+func cachedCLI(cacheDir, version, checksum string) string {
+	pattern := filepath.Join(cacheDir, version, "rosa"+checksumExtension)
+	if version == LatestCLIVersion {
+		pattern = filepath.Join(cacheDir, "*", "rosa"+checksumExtension)
+	}
+
+	matches, _ := filepath.Glob(pattern)
+	for _, match := range matches {
+		cached, err := os.ReadFile(match)
+		if err != nil || strings.TrimSpace(string(cached)) != checksum {
+			continue
+		}
+
+		binary := strings.TrimSuffix(match, checksumExtension)
+		if _, err = os.Stat(binary); err == nil {
+			return binary
+		}
+	}
+
+	return ""
+}
+
+// cliChecksum returns the published sha256 sum for the release archive
+func cliChecksum(ctx context.Context, releaseURL, archive string) (string, error) {
+	url := fmt.Sprintf("%s/%s", releaseURL, checksumsFilename)
+
+	body, err := httpGet(ctx, url)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	return parseChecksum(body, archive)
+}
+
+// parseChecksum returns the checksum for the file from sha256sum formatted content
+func parseChecksum(content io.Reader, filename string) (string, error) {
+	scanner := bufio.NewScanner(content)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == filename {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read checksums: %v", err)
+	}
+
+	return "", fmt.Errorf("checksum for %s not found", filename)
+}
+
+// downloadCLI downloads the release archive, verifies its checksum and extracts the rosa binary
+func downloadCLI(ctx context.Context, url, checksum, binary string) error {
+	body, err := httpGet(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	archive, err := os.CreateTemp(filepath.Dir(binary), "rosa-*.tar.gz")
+	if err != nil {
+		return fmt.Errorf("failed to create archive file: %v", err)
+	}
+	defer archive.Close()
+
+	hash := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(archive, hash), body); err != nil {
+		return fmt.Errorf("failed to download %s: %v", url, err)
+	}
+
+	if actual := hex.EncodeToString(hash.Sum(nil)); actual != checksum {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", url, checksum, actual)
+	}
+
+	if _, err = archive.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read archive: %v", err)
+	}
+
+	return extractCLI(archive, binary)
+}
+
+// extractCLI extracts the rosa binary from the release archive
+func extractCLI(archive io.Reader, binary string) error {
+	gzipReader, err := gzip.NewReader(archive)
+	if err != nil {
+		return fmt.Errorf("failed to create gzip reader: %v", err)
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return fmt.Errorf("rosa binary not found in archive")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %v", err)
+		}
+
+		if header.Typeflag != tar.TypeReg || filepath.Base(header.Name) != "rosa" {
+			continue
+		}
+
+		file, err := os.OpenFile(binary, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o755)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %v", binary, err)
+		}
+		defer file.Close()
+
+		if _, err = io.Copy(file, tarReader); err != nil {
+			return fmt.Errorf("failed to write %s: %v", binary, err)
+		}
+
+		return nil
+	}
+}
+
+// httpGet returns the body of the url, it is the callers responsibility to close it
+func httpGet(ctx context.Context, url string) (io.ReadCloser, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request for %s: %v", url, err)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %v", url, err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, fmt.Errorf("failed to download %s: status %d", url, response.StatusCode)
+	}
+
+	return response.Body, nil
+}
+
+// moveFile moves the file, copying it when the destination is on another file system
+func moveFile(source, destination string) error {
+	if err := os.Rename(source, destination); err == nil {
+		return nil
+	}
+
+	content, err := os.ReadFile(source)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", source, err)
+	}
+
+	if err = os.WriteFile(destination, content, 0o755); err != nil {
+		return fmt.Errorf("failed to write %s: %v", destination, err)
+	}
+
+	return nil
+}
+
+// cliVersion returns the rosa cli binary version
+func cliVersion(ctx context.Context, rosaBinary string) (*semver.Version, error) {
+	stdout, _, err := cmd.Run(exec.CommandContext(ctx, rosaBinary, "version"))
+	if err != nil {
+		return nil, err
+	}
+
+	versionSlice := strings.SplitAfter(fmt.Sprint(stdout), "\n")
+	if len(versionSlice) == 0 {
+		return nil, fmt.Errorf("failed to get version from cli standard out")
+	}
+
+	version, err := semver.NewVersion(strings.ReplaceAll(versionSlice[0], "\n", ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse version to semantic version: %v", err)
+	}
+
+	return version, nil
+}
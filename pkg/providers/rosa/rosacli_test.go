@@ -0,0 +1,40 @@
+package rosa
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("rosa cli", func() {
+	It("should parse the archive checksum", func() {
+		checksums := "ABC123  rosa-linux.tar.gz\ndef456 *rosa-macosx.tar.gz\n"
+
+		checksum, err := parseChecksum(strings.NewReader(checksums), "rosa-linux.tar.gz")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(checksum).To(Equal("abc123"))
+
+		checksum, err = parseChecksum(strings.NewReader(checksums), "rosa-macosx.tar.gz")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(checksum).To(Equal("def456"))
+
+		_, err = parseChecksum(strings.NewReader(checksums), "rosa-windows.zip")
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should find cached versions by checksum", func() {
+		cacheDir := GinkgoT().TempDir()
+		versionDir := filepath.Join(cacheDir, "1.2.30")
+		Expect(os.MkdirAll(versionDir, 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(versionDir, "rosa"), []byte{}, 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(versionDir, "rosa"+checksumExtension), []byte("abc123"), 0o644)).To(Succeed())
+
+		Expect(cachedCLI(cacheDir, "1.2.30", "abc123")).To(Equal(filepath.Join(versionDir, "rosa")))
+		Expect(cachedCLI(cacheDir, LatestCLIVersion, "abc123")).To(Equal(filepath.Join(versionDir, "rosa")))
+		Expect(cachedCLI(cacheDir, "1.2.30", "def456")).To(BeEmpty())
+		Expect(cachedCLI(cacheDir, "1.2.31", "abc123")).To(BeEmpty())
+	})
+})
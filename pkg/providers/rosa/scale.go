@@ -0,0 +1,141 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+)
+
+const (
+	// defaultMachinePoolID is the id of the machine pool created with classic clusters
+	defaultMachinePoolID = "worker"
+	// defaultNodePoolID is the id (or id prefix for multiple availability zones) of the
+	// node pools created with hosted control plane clusters
+	defaultNodePoolID = "workers"
+	// defaultScaleTimeout is how long to wait for the nodes to join or drain
+	defaultScaleTimeout = 45 * time.Minute
+)
+
+// ScaleCluster sets the replicas of the clusters default machine pool (classic) or default node
+// pools (hosted control plane, spread evenly across them) and waits for the nodes to join or drain.
+// The timeout defaults to 45 minutes
+//
+//	client, err := cluster.Client()
+//	Expect(err).ShouldNot(HaveOccurred())
+//	Expect(provider.ScaleCluster(ctx, client, cluster.ID, 6, 0)).To(Succeed())
+func (r *Provider) ScaleCluster(ctx context.Context, client *openshift.Client, clusterID string, replicas int, timeout time.Duration) error {
+	const action = "scale"
+
+	if timeout == 0 {
+		timeout = defaultScaleTimeout
+	}
+
+	err := r.runPhase(action, clusterID, "", func() error {
+		cluster, err := r.getCluster(ctx, clusterID)
+		if err != nil {
+			return err
+		}
+
+		_, workers, err := client.WorkerNodes(ctx)
+		if err != nil {
+			return err
+		}
+
+		var current int
+		if cluster.Hypershift().Enabled() {
+			current, err = r.scaleNodePools(ctx, clusterID, replicas)
+		} else {
+			current, err = r.scaleMachinePool(ctx, clusterID, replicas)
+		}
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Cluster %q scaling from %d to %d replicas", clusterID, current, replicas)
+
+		return client.WaitForWorkerNodes(ctx, workers-current+replicas, timeout)
+	})
+	if err != nil {
+		return &clusterError{action: action, err: err}
+	}
+
+	return nil
+}
+
+// scaleMachinePool sets the replicas of the classic clusters default machine pool and returns
+// its previous replicas
+func (r *Provider) scaleMachinePool(ctx context.Context, clusterID string, replicas int) (int, error) {
+	machinePoolClient := r.ClustersMgmt().V1().Clusters().Cluster(clusterID).MachinePools().MachinePool(defaultMachinePoolID)
+
+	response, err := machinePoolClient.Get().SendContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get cluster %q machine pool %q: %v", clusterID, defaultMachinePoolID, err)
+	}
+
+	if response.Body().Autoscaling() != nil {
+		return 0, fmt.Errorf("cluster %q machine pool %q is autoscaling", clusterID, defaultMachinePoolID)
+	}
+
+	machinePool, err := clustersmgmtv1.NewMachinePool().ID(defaultMachinePoolID).Replicas(replicas).Build()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build machine pool: %v", err)
+	}
+
+	if _, err = machinePoolClient.Update().Body(machinePool).SendContext(ctx); err != nil {
+		return 0, fmt.Errorf("failed to update cluster %q machine pool %q: %v", clusterID, defaultMachinePoolID, err)
+	}
+
+	return response.Body().Replicas(), nil
+}
+
+// scaleNodePools spreads the replicas evenly across the hosted control plane clusters default
+// node pools and returns their previous total replicas
+func (r *Provider) scaleNodePools(ctx context.Context, clusterID string, replicas int) (int, error) {
+	nodePoolsClient := r.ClustersMgmt().V1().Clusters().Cluster(clusterID).NodePools()
+
+	response, err := nodePoolsClient.List().SendContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list cluster %q node pools: %v", clusterID, err)
+	}
+
+	var nodePools []*clustersmgmtv1.NodePool
+	for _, nodePool := range response.Items().Slice() {
+		if nodePool.ID() == defaultNodePoolID || strings.HasPrefix(nodePool.ID(), defaultNodePoolID+"-") {
+			nodePools = append(nodePools, nodePool)
+		}
+	}
+
+	if len(nodePools) == 0 {
+		return 0, fmt.Errorf("cluster %q has no %q node pools", clusterID, defaultNodePoolID)
+	}
+
+	if replicas%len(nodePools) != 0 {
+		return 0, fmt.Errorf("replicas %d must be a multiple of the %d default node pools", replicas, len(nodePools))
+	}
+
+	current := 0
+	for _, nodePool := range nodePools {
+		if nodePool.Autoscaling() != nil {
+			return 0, fmt.Errorf("cluster %q node pool %q is autoscaling", clusterID, nodePool.ID())
+		}
+		current += nodePool.Replicas()
+	}
+
+	for _, nodePool := range nodePools {
+		update, err := clustersmgmtv1.NewNodePool().ID(nodePool.ID()).Replicas(replicas / len(nodePools)).Build()
+		if err != nil {
+			return 0, fmt.Errorf("failed to build node pool: %v", err)
+		}
+
+		if _, err = nodePoolsClient.NodePool(nodePool.ID()).Update().Body(update).SendContext(ctx); err != nil {
+			return 0, fmt.Errorf("failed to update cluster %q node pool %q: %v", clusterID, nodePool.ID(), err)
+		}
+	}
+
+	return current, nil
+}
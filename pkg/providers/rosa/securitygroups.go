@@ -0,0 +1,251 @@
+package rosa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+)
+
+const machineAPINamespace = "openshift-machine-api"
+
+// securityGroupsError represents the custom error
+type securityGroupsError struct {
+	action string
+	err    error
+}
+
+// Error returns the formatted error message when securityGroupsError is invoked
+func (s *securityGroupsError) Error() string {
+	return fmt.Sprintf("%s machine pool security groups failed: %v", s.action, s.err)
+}
+
+// machinePoolSecurityGroups represents the aws security group fields of an ocm machine pool
+type machinePoolSecurityGroups struct {
+	AWS struct {
+		AdditionalSecurityGroupIDs []string `json:"additional_security_group_ids"`
+	} `json:"aws"`
+}
+
+// machinePoolPath returns the ocm api path for the clusters machine pool
+func machinePoolPath(clusterID, machinePoolID string) string {
+	return fmt.Sprintf("/api/clusters_mgmt/v1/clusters/%s/machine_pools/%s", clusterID, machinePoolID)
+}
+
+// MachinePoolSecurityGroups returns the additional aws security group ids attached to the machine pool
+func (r *Provider) MachinePoolSecurityGroups(ctx context.Context, clusterID, machinePoolID string) ([]string, error) {
+	response, err := r.Connection.Get().Path(machinePoolPath(clusterID, machinePoolID)).SendContext(ctx)
+	if err != nil {
+		return nil, &securityGroupsError{action: "get", err: err}
+	}
+
+	if response.Status() != http.StatusOK {
+		return nil, &securityGroupsError{action: "get", err: fmt.Errorf("unexpected status %d: %s", response.Status(), response.String())}
+	}
+
+	var machinePool machinePoolSecurityGroups
+	if err = json.Unmarshal(response.Bytes(), &machinePool); err != nil {
+		return nil, &securityGroupsError{action: "get", err: fmt.Errorf("failed to parse machine pool: %v", err)}
+	}
+
+	return machinePool.AWS.AdditionalSecurityGroupIDs, nil
+}
+
+// AttachMachinePoolSecurityGroups attaches additional aws security groups to the machine pool
+func (r *Provider) AttachMachinePoolSecurityGroups(ctx context.Context, clusterID, machinePoolID string, securityGroupIDs ...string) error {
+	current, err := r.MachinePoolSecurityGroups(ctx, clusterID, machinePoolID)
+	if err != nil {
+		return err
+	}
+
+	for _, securityGroupID := range securityGroupIDs {
+		if !contains(current, securityGroupID) {
+			current = append(current, securityGroupID)
+		}
+	}
+
+	return r.updateMachinePoolSecurityGroups(ctx, "attach", clusterID, machinePoolID, current)
+}
+
+// DetachMachinePoolSecurityGroups detaches additional aws security groups from the machine pool
+func (r *Provider) DetachMachinePoolSecurityGroups(ctx context.Context, clusterID, machinePoolID string, securityGroupIDs ...string) error {
+	current, err := r.MachinePoolSecurityGroups(ctx, clusterID, machinePoolID)
+	if err != nil {
+		return err
+	}
+
+	remaining := []string{}
+	for _, securityGroupID := range current {
+		if !contains(securityGroupIDs, securityGroupID) {
+			remaining = append(remaining, securityGroupID)
+		}
+	}
+
+	return r.updateMachinePoolSecurityGroups(ctx, "detach", clusterID, machinePoolID, remaining)
+}
+
+// updateMachinePoolSecurityGroups sets the machine pools additional aws security groups. The ocm sdk
+// aws machine pool type has no additional security groups attribute, the body is sent directly
+func (r *Provider) updateMachinePoolSecurityGroups(ctx context.Context, action, clusterID, machinePoolID string, securityGroupIDs []string) error {
+	var body machinePoolSecurityGroups
+	body.AWS.AdditionalSecurityGroupIDs = securityGroupIDs
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return &securityGroupsError{action: action, err: fmt.Errorf("failed to build request body: %v", err)}
+	}
+
+	response, err := r.Connection.Patch().Path(machinePoolPath(clusterID, machinePoolID)).Bytes(data).SendContext(ctx)
+	if err != nil {
+		return &securityGroupsError{action: action, err: err}
+	}
+
+	if response.Status() != http.StatusOK {
+		return &securityGroupsError{action: action, err: fmt.Errorf("unexpected status %d: %s", response.Status(), response.String())}
+	}
+
+	log.Printf("Machine pool %q additional security groups set to %v", machinePoolID, securityGroupIDs)
+
+	return nil
+}
+
+// WaitForMachinePoolSecurityGroups waits for the machine pools instances to have the attached security groups
+// and no longer have the detached security groups (requires the aws cli)
+func (r *Provider) WaitForMachinePoolSecurityGroups(ctx context.Context, client *openshift.Client, machinePoolID string, attached, detached []string, timeout time.Duration) error {
+	dynamicClient, err := dynamic.NewForConfig(client.GetConfig())
+	if err != nil {
+		return &securityGroupsError{action: "verify", err: fmt.Errorf("failed to create kubernetes dynamic client: %v", err)}
+	}
+
+	machinesResource := schema.GroupVersionResource{Group: "machine.openshift.io", Version: "v1beta1", Resource: "machines"}
+
+	err = wait.PollUntilContextTimeout(ctx, 30*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		machines, err := dynamicClient.Resource(machinesResource).Namespace(machineAPINamespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			log.Printf("Failed to list machines: %v", err)
+			return false, nil
+		}
+
+		var instanceIDs []string
+		for _, machine := range machines.Items {
+			if !strings.Contains(machine.GetLabels()["machine.openshift.io/cluster-api-machineset"], fmt.Sprintf("-%s-", machinePoolID)) {
+				continue
+			}
+
+			instanceID := machineInstanceID(machine)
+			if instanceID == "" {
+				log.Printf("Machine %q has no instance yet", machine.GetName())
+				return false, nil
+			}
+			instanceIDs = append(instanceIDs, instanceID)
+		}
+
+		if len(instanceIDs) == 0 {
+			return false, nil
+		}
+
+		instanceSecurityGroups, err := r.instanceSecurityGroupIDs(ctx, instanceIDs)
+		if err != nil {
+			log.Printf("Failed to get machine pool %q instance security groups: %v", machinePoolID, err)
+			return false, nil
+		}
+
+		pending := pendingSecurityGroupChanges(instanceSecurityGroups, attached, detached)
+		for _, change := range pending {
+			log.Printf("Machine pool %q security groups not updated yet: %s", machinePoolID, change)
+		}
+
+		return len(pending) == 0, nil
+	})
+	if err != nil {
+		return &securityGroupsError{action: "verify", err: err}
+	}
+
+	return nil
+}
+
+// machineInstanceID returns the aws instance id from the machines provider id, formatted
+// as aws:///<availability zone>/<instance id>, empty when the instance is not created yet
+func machineInstanceID(machine unstructured.Unstructured) string {
+	providerID, _, _ := unstructured.NestedString(machine.Object, "spec", "providerID")
+	if !strings.HasPrefix(providerID, "aws://") {
+		return ""
+	}
+	return providerID[strings.LastIndex(providerID, "/")+1:]
+}
+
+// instanceSecurityGroupIDs returns the security group ids of the aws instances keyed by instance id
+func (r *Provider) instanceSecurityGroupIDs(ctx context.Context, instanceIDs []string) (map[string][]string, error) {
+	var output struct {
+		Reservations []struct {
+			Instances []struct {
+				InstanceID     string `json:"InstanceId"`
+				SecurityGroups []struct {
+					GroupID string `json:"GroupId"`
+				} `json:"SecurityGroups"`
+			} `json:"Instances"`
+		} `json:"Reservations"`
+	}
+
+	args := append([]string{"ec2", "describe-instances", "--instance-ids"}, instanceIDs...)
+	if err := awsCLI(ctx, r.awsCredentials, &output, args...); err != nil {
+		return nil, fmt.Errorf("failed to describe instances: %v", err)
+	}
+
+	securityGroupIDs := map[string][]string{}
+	for _, reservation := range output.Reservations {
+		for _, instance := range reservation.Instances {
+			ids := []string{}
+			for _, securityGroup := range instance.SecurityGroups {
+				ids = append(ids, securityGroup.GroupID)
+			}
+			securityGroupIDs[instance.InstanceID] = ids
+		}
+	}
+
+	return securityGroupIDs, nil
+}
+
+// pendingSecurityGroupChanges returns the attached security groups missing from the instances
+// and the detached security groups still present on them, sorted by instance id
+func pendingSecurityGroupChanges(instanceSecurityGroups map[string][]string, attached, detached []string) []string {
+	var pending []string
+
+	for instanceID, securityGroupIDs := range instanceSecurityGroups {
+		for _, securityGroupID := range attached {
+			if !contains(securityGroupIDs, securityGroupID) {
+				pending = append(pending, fmt.Sprintf("instance %s does not have security group %s attached", instanceID, securityGroupID))
+			}
+		}
+		for _, securityGroupID := range detached {
+			if contains(securityGroupIDs, securityGroupID) {
+				pending = append(pending, fmt.Sprintf("instance %s still has security group %s attached", instanceID, securityGroupID))
+			}
+		}
+	}
+
+	sort.Strings(pending)
+
+	return pending
+}
+
+// contains checks if the value exists in the slice
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
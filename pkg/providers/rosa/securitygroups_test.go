@@ -0,0 +1,29 @@
+package rosa
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("Security Groups", func() {
+	It("should return the machines instance id once created", func() {
+		machine := unstructured.Unstructured{Object: map[string]any{"spec": map[string]any{"providerID": "aws:///us-east-1a/i-0123"}}}
+		Expect(machineInstanceID(machine)).To(Equal("i-0123"))
+		Expect(machineInstanceID(unstructured.Unstructured{Object: map[string]any{}})).To(BeEmpty())
+	})
+
+	It("should report the security groups not yet attached or detached", func() {
+		instanceSecurityGroups := map[string][]string{
+			"i-1": {"sg-default", "sg-new"},
+			"i-2": {"sg-default", "sg-old"},
+		}
+
+		Expect(pendingSecurityGroupChanges(instanceSecurityGroups, []string{"sg-new"}, []string{"sg-old"})).To(Equal([]string{
+			"instance i-2 does not have security group sg-new attached",
+			"instance i-2 still has security group sg-old attached",
+		}))
+		Expect(pendingSecurityGroupChanges(map[string][]string{"i-1": {"sg-default", "sg-new"}}, []string{"sg-new"}, []string{"sg-old"})).To(BeEmpty())
+	})
+})
@@ -0,0 +1,93 @@
+package rosa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	ocmclient "github.com/openshift/osde2e-framework/pkg/clients/ocm"
+)
+
+// sessionRefreshMargin is how long before the rosa session expires it is logged in again
+const sessionRefreshMargin = 10 * time.Minute
+
+// login logs the rosa cli in to the providers environment using the providers credentials
+func (r *Provider) login(ctx context.Context) error {
+	commandArgs := []string{"login", "--env", string(r.environment)}
+	if r.credentials.ClientCredentials() {
+		commandArgs = append(commandArgs, "--client-id", r.credentials.ClientID, "--client-secret", r.credentials.ClientSecret)
+	} else {
+		token, err := r.credentials.OfflineToken()
+		if err != nil {
+			return err
+		}
+		commandArgs = append(commandArgs, "--token", token)
+	}
+	if r.environment.FedRAMP() {
+		commandArgs = append(commandArgs, "--govcloud")
+	}
+
+	_, _, err := r.runCommand(r.rosaCommand(ctx, commandArgs...))
+	if err != nil {
+		return fmt.Errorf("login failed %v", err)
+	}
+	return nil
+}
+
+// sessionExpiration returns when the rosa session stored in the configuration directory can no
+// longer be refreshed: the refresh tokens expiry when one is stored, otherwise the access tokens
+// expiry. Zero is returned when the tokens do not expire (e.g. offline tokens)
+func (r *Provider) sessionExpiration() (time.Time, error) {
+	data, err := os.ReadFile(filepath.Join(r.configDir, "ocm.json"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read rosa session: %v", err)
+	}
+
+	var session struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err = json.Unmarshal(data, &session); err != nil {
+		return time.Time{}, fmt.Errorf("failed to decode rosa session: %v", err)
+	}
+
+	token := session.AccessToken
+	if session.RefreshToken != "" {
+		token = session.RefreshToken
+	}
+
+	return ocmclient.ParseTokenExpiration(token)
+}
+
+// refreshSession logs the rosa cli in again when its session expires within the refresh margin, so
+// multi hour provisions and upgrades do not fail once the session obtained at construction expires.
+// Client credential sessions are refreshed by the rosa cli itself. Failures are logged, the command
+// about to run reports the authentication error
+func (r *Provider) refreshSession(ctx context.Context) {
+	if r.environment == "" || r.credentials.ClientCredentials() {
+		return
+	}
+
+	r.sessionMu.Lock()
+	defer r.sessionMu.Unlock()
+
+	expiration, err := r.sessionExpiration()
+	if err != nil {
+		log.Printf("Failed to check the rosa session expiration: %v", err)
+		return
+	}
+
+	if expiration.IsZero() || time.Until(expiration) > sessionRefreshMargin {
+		return
+	}
+
+	log.Printf("Rosa session expires at %s, logging in again", expiration.Format(time.RFC3339))
+
+	if err = r.login(ctx); err != nil {
+		log.Printf("Failed to refresh the rosa session: %v", err)
+	}
+}
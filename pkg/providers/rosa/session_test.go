@@ -0,0 +1,67 @@
+package rosa
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	ocmclient "github.com/openshift/osde2e-framework/pkg/clients/ocm"
+)
+
+var _ = Describe("rosa session", func() {
+	var (
+		provider *Provider
+		logins   string
+	)
+
+	jwt := func(expiration time.Time) string {
+		claims := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, expiration.Unix())))
+		return "eyJhbGciOiJub25lIn0." + claims + ".signature"
+	}
+
+	writeSession := func(refreshTokenExpiration time.Time) {
+		session := fmt.Sprintf(`{"access_token":%q,"refresh_token":%q}`, jwt(time.Now().Add(time.Minute)), jwt(refreshTokenExpiration))
+		Expect(os.WriteFile(filepath.Join(provider.configDir, "ocm.json"), []byte(session), 0o600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		dir := GinkgoT().TempDir()
+		logins = filepath.Join(dir, "logins")
+
+		rosaBinary := filepath.Join(dir, "rosa")
+		script := fmt.Sprintf("#!/bin/sh\n[ \"$1\" = login ] && echo \"$@\" >> %s\nexit 0\n", logins)
+		Expect(os.WriteFile(rosaBinary, []byte(script), 0o755)).To(Succeed())
+
+		provider = &Provider{
+			rosaBinary:  rosaBinary,
+			configDir:   dir,
+			credentials: ocmclient.Credentials{Token: "offline-token"},
+			environment: ocmclient.Stage,
+		}
+	})
+
+	It("should log in again when the session is about to expire", func(ctx context.Context) {
+		writeSession(time.Now().Add(5 * time.Minute))
+
+		_, _, err := provider.runCommand(provider.rosaCommand(ctx, "list", "clusters"))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		data, err := os.ReadFile(logins)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(data)).To(Equal("login --env https://api.stage.openshift.com --token offline-token\n"))
+	})
+
+	It("should reuse the session until it is about to expire", func(ctx context.Context) {
+		writeSession(time.Now().Add(2 * time.Hour))
+
+		_, _, err := provider.runCommand(provider.rosaCommand(ctx, "list", "clusters"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(logins).ToNot(BeAnExistingFile())
+	})
+})
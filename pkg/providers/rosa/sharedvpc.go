@@ -0,0 +1,244 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	awscloud "github.com/openshift/osde2e-framework/pkg/providers/clouds/aws"
+)
+
+// SharedVPCOptions represents the data used to install a cluster into a vpc
+// shared from another (host) aws account
+type SharedVPCOptions struct {
+	// HostCredentials are the credentials for the aws account owning the vpc
+	HostCredentials *awscloud.AWSCredentials
+	// HostedZoneID is the private hosted zone in the host account for the cluster domain
+	HostedZoneID string
+	// RoleARN is the role in the host account assumed to manage the private hosted zone
+	RoleARN string
+	// SubnetIDs are the subnets shared with the cluster account the cluster is installed into
+	SubnetIDs []string
+}
+
+// sharedVPCError represents the custom error
+type sharedVPCError struct {
+	err error
+}
+
+// Error returns the formatted error message when sharedVPCError is invoked
+func (s *sharedVPCError) Error() string {
+	return fmt.Sprintf("shared vpc validation failed: %v", s.err)
+}
+
+// ValidateSharedVPC validates the subnets are shared (ram) with the cluster account, the
+// subnets route tables provide egress, the private hosted zone is associated with the vpc
+// and the host account role trusts the cluster account. All checks are performed and
+// returned as a checklist, an error is only returned when the checks could not be performed
+//
+//	result, err := provider.ValidateSharedVPC(ctx, sharedVPCOptions)
+//	Expect(err).ShouldNot(HaveOccurred())
+//	Expect(result.Failed()).To(BeEmpty(), result.String())
+func (r *Provider) ValidateSharedVPC(ctx context.Context, options *SharedVPCOptions) (*PreflightResult, error) {
+	if options.HostCredentials == nil || options.HostedZoneID == "" || options.RoleARN == "" || len(options.SubnetIDs) == 0 {
+		return nil, &sharedVPCError{err: fmt.Errorf("host credentials, hosted zone id, role arn and subnet ids are required")}
+	}
+
+	if err := options.HostCredentials.ValidateAndFetchCredentials(); err != nil {
+		return nil, &sharedVPCError{err: fmt.Errorf("host account credentials: %v", err)}
+	}
+
+	identity, err := r.awsIdentity(ctx)
+	if err != nil {
+		return nil, &sharedVPCError{err: err}
+	}
+
+	result := &PreflightResult{}
+
+	vpcID := r.checkSubnetsShared(ctx, result, options, identity.accountID)
+	if vpcID == "" {
+		return result, nil
+	}
+
+	checkRouteTables(ctx, result, options, vpcID)
+	checkHostedZone(ctx, result, options, vpcID)
+	checkSharedVPCRole(ctx, result, options, identity.accountID)
+
+	return result, nil
+}
+
+// checkSubnetsShared checks the subnets are visible from the cluster account, owned by another
+// account and belong to a single vpc, returning the vpc id when they do
+func (r *Provider) checkSubnetsShared(ctx context.Context, result *PreflightResult, options *SharedVPCOptions, accountID string) string {
+	const name = "subnets are shared with the cluster account"
+
+	var output struct {
+		Subnets []struct {
+			SubnetID string `json:"SubnetId"`
+			OwnerID  string `json:"OwnerId"`
+			VpcID    string `json:"VpcId"`
+		} `json:"Subnets"`
+	}
+
+	args := append([]string{"ec2", "describe-subnets", "--subnet-ids"}, options.SubnetIDs...)
+	if err := awsCLI(ctx, r.awsCredentials, &output, args...); err != nil {
+		result.add(name, false, fmt.Sprintf("subnets are not visible from account %s, verify the ram resource share: %v", accountID, err))
+		return ""
+	}
+
+	vpcIDs := map[string]bool{}
+	var notShared []string
+	for _, subnet := range output.Subnets {
+		vpcIDs[subnet.VpcID] = true
+		if subnet.OwnerID == accountID {
+			notShared = append(notShared, subnet.SubnetID)
+		}
+	}
+
+	if len(notShared) > 0 {
+		result.add(name, false, fmt.Sprintf("subnets %v are owned by the cluster account %s", notShared, accountID))
+	} else {
+		result.add(name, true, "")
+	}
+
+	if len(vpcIDs) != 1 {
+		result.add("subnets belong to a single vpc", false, fmt.Sprintf("found %d vpcs", len(vpcIDs)))
+		return ""
+	}
+
+	result.add("subnets belong to a single vpc", true, "")
+
+	for vpcID := range vpcIDs {
+		return vpcID
+	}
+
+	return ""
+}
+
+// checkRouteTables checks each subnet routes egress traffic through an internet or nat gateway
+func checkRouteTables(ctx context.Context, result *PreflightResult, options *SharedVPCOptions, vpcID string) {
+	const name = "subnet route tables provide egress"
+
+	var output struct {
+		RouteTables []struct {
+			Associations []struct {
+				Main     bool   `json:"Main"`
+				SubnetID string `json:"SubnetId"`
+			} `json:"Associations"`
+			Routes []struct {
+				DestinationCidrBlock string `json:"DestinationCidrBlock"`
+				GatewayID            string `json:"GatewayId"`
+				NatGatewayID         string `json:"NatGatewayId"`
+				TransitGatewayID     string `json:"TransitGatewayId"`
+			} `json:"Routes"`
+		} `json:"RouteTables"`
+	}
+
+	err := awsCLI(ctx, options.HostCredentials, &output, "ec2", "describe-route-tables", "--filters", fmt.Sprintf("Name=vpc-id,Values=%s", vpcID))
+	if err != nil {
+		result.add(name, false, err.Error())
+		return
+	}
+
+	egress := map[string]bool{}
+	mainEgress := false
+	for _, routeTable := range output.RouteTables {
+		hasEgress := false
+		for _, route := range routeTable.Routes {
+			if route.DestinationCidrBlock == "0.0.0.0/0" &&
+				(strings.HasPrefix(route.GatewayID, "igw-") || route.NatGatewayID != "" || route.TransitGatewayID != "") {
+				hasEgress = true
+			}
+		}
+
+		for _, association := range routeTable.Associations {
+			if association.Main {
+				mainEgress = hasEgress
+			}
+			if association.SubnetID != "" {
+				egress[association.SubnetID] = hasEgress
+			}
+		}
+	}
+
+	var missing []string
+	for _, subnetID := range options.SubnetIDs {
+		hasEgress, associated := egress[subnetID]
+		if !associated {
+			hasEgress = mainEgress
+		}
+		if !hasEgress {
+			missing = append(missing, subnetID)
+		}
+	}
+
+	if len(missing) > 0 {
+		result.add(name, false, fmt.Sprintf("subnets %v have no 0.0.0.0/0 route through an internet, nat or transit gateway", missing))
+		return
+	}
+
+	result.add(name, true, "")
+}
+
+// checkHostedZone checks the hosted zone is private and associated with the vpc
+func checkHostedZone(ctx context.Context, result *PreflightResult, options *SharedVPCOptions, vpcID string) {
+	const name = "private hosted zone is associated with the vpc"
+
+	var output struct {
+		HostedZone struct {
+			Name   string `json:"Name"`
+			Config struct {
+				PrivateZone bool `json:"PrivateZone"`
+			} `json:"Config"`
+		} `json:"HostedZone"`
+		VPCs []struct {
+			VPCID string `json:"VPCId"`
+		} `json:"VPCs"`
+	}
+
+	err := awsCLI(ctx, options.HostCredentials, &output, "route53", "get-hosted-zone", "--id", options.HostedZoneID)
+	if err != nil {
+		result.add(name, false, err.Error())
+		return
+	}
+
+	if !output.HostedZone.Config.PrivateZone {
+		result.add(name, false, fmt.Sprintf("hosted zone %q is not private", output.HostedZone.Name))
+		return
+	}
+
+	for _, vpc := range output.VPCs {
+		if vpc.VPCID == vpcID {
+			result.add(name, true, "")
+			return
+		}
+	}
+
+	result.add(name, false, fmt.Sprintf("hosted zone %q is not associated with vpc %s", output.HostedZone.Name, vpcID))
+}
+
+// checkSharedVPCRole checks the host account role trusts the cluster account
+func checkSharedVPCRole(ctx context.Context, result *PreflightResult, options *SharedVPCOptions, accountID string) {
+	const name = "shared vpc role trusts the cluster account"
+
+	policy, err := roleTrustPolicy(ctx, options.HostCredentials, options.RoleARN)
+	if err != nil {
+		result.add(name, false, err.Error())
+		return
+	}
+
+	for _, statement := range policy.Statement {
+		if statement.Effect != "Allow" || !contains(statement.Action, assumeRoleAction) {
+			continue
+		}
+
+		for _, principal := range statement.Principal["AWS"] {
+			if strings.Contains(principal, fmt.Sprintf(":%s:", accountID)) || principal == accountID {
+				result.add(name, true, "")
+				return
+			}
+		}
+	}
+
+	result.add(name, false, fmt.Sprintf("role %q does not allow principals from account %s to assume it", options.RoleARN, accountID))
+}
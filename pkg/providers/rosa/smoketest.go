@@ -0,0 +1,101 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/openshift/osde2e-framework/internal/workload"
+	"github.com/openshift/osde2e-framework/pkg/summary"
+)
+
+// SmokeTestOptions represents data used to run the smoke test
+type SmokeTestOptions struct {
+	// ChannelGroup is the channel group the version is resolved from, defaults to stable
+	ChannelGroup string
+	// ClusterName defaults to osde2e-smoke-<random suffix>
+	ClusterName string
+	// LeaseSubnets leases a registered subnet set instead of creating a vpc
+	LeaseSubnets bool
+	// Version defaults to the channel groups default version
+	Version string
+}
+
+// SmokeTestResult represents the outcome of the smoke test
+type SmokeTestResult struct {
+	ClusterID   string
+	ClusterName string
+	Duration    time.Duration
+	// Report is the run summary of the phases, failures and artifacts of the smoke test
+	Report string
+}
+
+// SmokeTest creates the smallest viable hosted control plane cluster, runs the install
+// health checks and deletes it, validating the accounts credentials, quota and environment
+// (e.g. a new ci account) end to end. The cluster is deleted even when the health checks
+// fail, the result report includes all phases of the run
+//
+//	result, err := provider.SmokeTest(ctx, &rosa.SmokeTestOptions{})
+//	Expect(err).ShouldNot(HaveOccurred(), result.Report)
+func (r *Provider) SmokeTest(ctx context.Context, options *SmokeTestOptions) (*SmokeTestResult, error) {
+	if options == nil {
+		options = &SmokeTestOptions{}
+	}
+
+	if options.ClusterName == "" {
+		options.ClusterName = fmt.Sprintf("osde2e-smoke-%s", workload.RandomSuffix(4))
+	}
+
+	result := &SmokeTestResult{ClusterName: options.ClusterName}
+	start := time.Now()
+	report := summary.New()
+
+	log.Printf("Starting smoke test using cluster %q", options.ClusterName)
+
+	clusterID, err := r.CreateCluster(ctx, &CreateClusterOptions{
+		ChannelGroup:   options.ChannelGroup,
+		ClusterName:    options.ClusterName,
+		HostedCP:       true,
+		LeaseSubnets:   options.LeaseSubnets,
+		Replicas:       2,
+		UniquePrefixes: true,
+		Version:        options.Version,
+	})
+	report.Phase("create", options.ClusterName, start, err)
+	result.ClusterID = clusterID
+
+	if clusterID != "" {
+		report.ClusterCreated(clusterID, options.ClusterName)
+
+		deleteStart := time.Now()
+		deleteErr := r.DeleteCluster(ctx, &DeleteClusterOptions{
+			ClusterID:   clusterID,
+			ClusterName: options.ClusterName,
+			HostedCP:    true,
+		})
+		report.Phase("delete", options.ClusterName, deleteStart, deleteErr)
+		if deleteErr == nil {
+			report.ClusterDeleted(clusterID, options.ClusterName)
+		} else if err == nil {
+			err = deleteErr
+		} else {
+			err = fmt.Errorf("%v (delete failed: %v)", err, deleteErr)
+		}
+	}
+
+	if err != nil {
+		report.Failure("smoke test", err)
+	}
+
+	result.Duration = time.Since(start)
+	result.Report = report.String()
+
+	if err != nil {
+		return result, fmt.Errorf("smoke test failed: %v", err)
+	}
+
+	log.Printf("Smoke test passed in %s", result.Duration.Round(time.Second))
+
+	return result, nil
+}
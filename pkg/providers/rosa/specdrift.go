@@ -0,0 +1,92 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// SpecDrift represents a cluster setting that differs from the value it was requested with
+type SpecDrift struct {
+	Field     string
+	Requested string
+	Actual    string
+}
+
+// String returns a human readable description of the drift
+func (d SpecDrift) String() string {
+	return fmt.Sprintf("%s was requested as %q but the cluster has %q", d.Field, d.Requested, d.Actual)
+}
+
+// CompareClusterSpec compares the ocm cluster with the options it was created with, returning
+// the settings ocm silently defaulted or changed (e.g. version, region, machine type, networking)
+func (r *Provider) CompareClusterSpec(ctx context.Context, clusterID string, options *CreateClusterOptions) ([]SpecDrift, error) {
+	cluster, err := r.getCluster(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	return compareClusterSpec(options, r.awsCredentials.Region, cluster), nil
+}
+
+// warnClusterSpecDrift reports the clusters drift from the options it was created with as warnings
+func (r *Provider) warnClusterSpecDrift(ctx context.Context, clusterID string, options *CreateClusterOptions) {
+	drifts, err := r.CompareClusterSpec(ctx, clusterID, options)
+	if err != nil {
+		log.Printf("Failed to compare cluster %q spec: %v", clusterID, err)
+		return
+	}
+
+	source := fmt.Sprintf("cluster %s spec", clusterID)
+	for _, drift := range drifts {
+		log.Printf("WARNING: cluster %q %s", clusterID, drift)
+		r.events.Warning(source, drift.String(), time.Time{})
+	}
+}
+
+// compareClusterSpec returns the requested settings that differ on the cluster, settings
+// left undefined in the options are not compared
+func compareClusterSpec(options *CreateClusterOptions, region string, cluster *clustersmgmtv1.Cluster) []SpecDrift {
+	var drifts []SpecDrift
+
+	compare := func(field, requested, actual string) {
+		if requested != "" && requested != actual {
+			drifts = append(drifts, SpecDrift{Field: field, Requested: requested, Actual: actual})
+		}
+	}
+
+	itoa := func(value int) string {
+		if value == 0 {
+			return ""
+		}
+		return strconv.Itoa(value)
+	}
+
+	version := cluster.Version().RawID()
+	if version == "" {
+		version = cluster.OpenshiftVersion()
+	}
+
+	compare("version", strings.TrimPrefix(options.Version, "openshift-v"), version)
+	compare("channel group", options.ChannelGroup, cluster.Version().ChannelGroup())
+	compare("region", region, cluster.Region().ID())
+	compare("compute machine type", options.ComputeMachineType, cluster.Nodes().ComputeMachineType().ID())
+	compare("compute replicas", itoa(options.Replicas), itoa(cluster.Nodes().Compute()))
+	compare("machine cidr", options.MachineCidr, cluster.Network().MachineCIDR())
+
+	// hosted control planes are always spread across availability zones
+	if !options.HostedCP && options.MultiAZ != cluster.MultiAZ() {
+		compare("multi az", strconv.FormatBool(options.MultiAZ), strconv.FormatBool(cluster.MultiAZ()))
+	}
+
+	if options.EtcdEncryption && !cluster.EtcdEncryption() {
+		compare("etcd encryption", "true", "false")
+	}
+
+	return drifts
+}
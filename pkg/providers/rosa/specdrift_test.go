@@ -0,0 +1,40 @@
+package rosa
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Spec Drift", func() {
+	var cluster *clustersmgmtv1.Cluster
+
+	BeforeEach(func() {
+		var err error
+		cluster, err = clustersmgmtv1.NewCluster().
+			Version(clustersmgmtv1.NewVersion().RawID("4.13.4").ChannelGroup("stable")).
+			Region(clustersmgmtv1.NewCloudRegion().ID("us-east-1")).
+			Nodes(clustersmgmtv1.NewClusterNodes().
+				ComputeMachineType(clustersmgmtv1.NewMachineType().ID("m5.xlarge")).
+				Compute(2)).
+			Network(clustersmgmtv1.NewNetwork().MachineCIDR("10.0.0.0/16")).
+			Build()
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should report no drift when the cluster matches the options", func() {
+		options := &CreateClusterOptions{Version: "4.13.4", ChannelGroup: "stable", ComputeMachineType: "m5.xlarge", Replicas: 2}
+		Expect(compareClusterSpec(options, "us-east-1", cluster)).To(BeEmpty())
+	})
+
+	It("should report the settings that differ from the options", func() {
+		options := &CreateClusterOptions{ComputeMachineType: "m5.2xlarge", MachineCidr: "10.1.0.0/16", MultiAZ: true}
+		Expect(compareClusterSpec(options, "us-west-2", cluster)).To(ConsistOf(
+			SpecDrift{Field: "region", Requested: "us-west-2", Actual: "us-east-1"},
+			SpecDrift{Field: "compute machine type", Requested: "m5.2xlarge", Actual: "m5.xlarge"},
+			SpecDrift{Field: "machine cidr", Requested: "10.1.0.0/16", Actual: "10.0.0.0/16"},
+			SpecDrift{Field: "multi az", Requested: "true", Actual: "false"},
+		))
+	})
+})
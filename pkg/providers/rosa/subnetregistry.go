@@ -0,0 +1,401 @@
+package rosa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	accountsmgmtv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	"github.com/openshift/osde2e-framework/internal/workload"
+)
+
+const (
+	// subnetSetLabelPrefix is the organization label key prefix of the registered subnet sets
+	subnetSetLabelPrefix = "osde2e-subnets-"
+	// subnetSetProperty is the cluster property recording the subnet set leased by the cluster
+	subnetSetProperty = "osde2e_subnet_set"
+	// subnetLeaseLabelPrefix is the organization label key prefix of the subnet set leases
+	subnetLeaseLabelPrefix = "osde2e-lease-"
+	// subnetTakeoverLabelPrefix is the organization label key prefix of the expired lease takeovers
+	subnetTakeoverLabelPrefix = "osde2e-takeover-"
+	// subnetSetLeaseProperty is the cluster property recording the id of the subnet set lease
+	subnetSetLeaseProperty = "osde2e_subnet_set_lease"
+	// subnetLeaseExpiration is the time after which a lease is considered abandoned
+	subnetLeaseExpiration = 24 * time.Hour
+	// subnetLeaseReturnMargin is the time before the lease expires after which its holder no longer
+	// returns it, leaving the lease to be taken over so clock skew between hosts cannot release it twice
+	subnetLeaseReturnMargin = time.Hour
+)
+
+// SubnetSet represents a pre-created vpc subnet set hosted control plane clusters can lease
+// instead of creating a vpc with terraform for each cluster. The lease is stored in its own
+// label so it can only be taken by one holder, LeasedBy and LeasedAt are read from it
+type SubnetSet struct {
+	Name          string     `json:"-"`
+	Region        string     `json:"region"`
+	PrivateSubnet string     `json:"private_subnet"`
+	PublicSubnet  string     `json:"public_subnet"`
+	LeasedBy      string     `json:"-"`
+	LeasedAt      *time.Time `json:"-"`
+}
+
+// subnetLease is the lease of a subnet set, stored in the subnet set lease label
+type subnetLease struct {
+	LeasedBy string    `json:"leased_by"`
+	LeasedAt time.Time `json:"leased_at"`
+}
+
+// leased returns true when the subnet set is leased and the lease has not expired
+func (s *SubnetSet) leased() bool {
+	return s.LeasedBy != "" && s.LeasedAt != nil && time.Since(*s.LeasedAt) < subnetLeaseExpiration
+}
+
+// subnetRegistryError represents the custom error
+type subnetRegistryError struct {
+	action string
+	err    error
+}
+
+// Error returns the formatted error message when subnetRegistryError is invoked
+func (s *subnetRegistryError) Error() string {
+	return fmt.Sprintf("%s subnet set failed: %v", s.action, s.err)
+}
+
+// organizationLabels returns the labels client for the current accounts organization,
+// the registry is stored as organization labels so it is shared by all its accounts
+func (r *Provider) organizationLabels(ctx context.Context) (*accountsmgmtv1.GenericLabelsClient, error) {
+	response, err := r.AccountsMgmt().V1().CurrentAccount().Get().SendContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current account: %v", err)
+	}
+
+	return r.AccountsMgmt().V1().Organizations().Organization(response.Body().Organization().ID()).Labels(), nil
+}
+
+// saveSubnetSet creates the subnet set label
+func (r *Provider) saveSubnetSet(ctx context.Context, labels *accountsmgmtv1.GenericLabelsClient, subnetSet *SubnetSet) error {
+	value, err := json.Marshal(subnetSet)
+	if err != nil {
+		return fmt.Errorf("failed to encode subnet set %q: %v", subnetSet.Name, err)
+	}
+
+	label, err := accountsmgmtv1.NewLabel().Key(subnetSetLabelPrefix + subnetSet.Name).Value(string(value)).Build()
+	if err != nil {
+		return fmt.Errorf("failed to build subnet set %q label: %v", subnetSet.Name, err)
+	}
+
+	if _, err = labels.Add().Body(label).SendContext(ctx); err != nil {
+		return fmt.Errorf("failed to save subnet set %q: %v", subnetSet.Name, err)
+	}
+
+	return nil
+}
+
+// addSubnetLease takes the lease on the subnet set by adding its lease label, it returns false
+// when the label already exists as the subnet set was leased by another holder
+func (r *Provider) addSubnetLease(ctx context.Context, labels *accountsmgmtv1.GenericLabelsClient, name string, lease subnetLease) (bool, error) {
+	value, err := json.Marshal(lease)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode subnet set %q lease: %v", name, err)
+	}
+
+	return r.addLabel(ctx, labels, subnetLeaseLabelPrefix+name, string(value))
+}
+
+// addLabel adds the label, it returns false when the label already exists. Labels are only
+// added when missing so adding one is used to take the subnet set leases and takeovers
+func (r *Provider) addLabel(ctx context.Context, labels *accountsmgmtv1.GenericLabelsClient, key, value string) (bool, error) {
+	label, err := accountsmgmtv1.NewLabel().Key(key).Value(value).Build()
+	if err != nil {
+		return false, fmt.Errorf("failed to build label %q: %v", key, err)
+	}
+
+	response, err := labels.Add().Body(label).SendContext(ctx)
+	if err != nil {
+		if response != nil && response.Status() == http.StatusConflict {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to add label %q: %v", key, err)
+	}
+
+	return true, nil
+}
+
+// subnetLease returns the subnet set lease, nil when the subnet set is not leased
+func (r *Provider) subnetLease(ctx context.Context, labels *accountsmgmtv1.GenericLabelsClient, name string) (*subnetLease, error) {
+	response, err := labels.Label(subnetLeaseLabelPrefix + name).Get().SendContext(ctx)
+	if err != nil {
+		if response != nil && response.Status() == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get subnet set %q lease: %v", name, err)
+	}
+
+	return parseSubnetLease(response.Body())
+}
+
+// deleteSubnetLease releases the subnet set lease, a lease already released is ignored
+func (r *Provider) deleteSubnetLease(ctx context.Context, labels *accountsmgmtv1.GenericLabelsClient, name string) error {
+	response, err := labels.Label(subnetLeaseLabelPrefix + name).Delete().SendContext(ctx)
+	if err != nil && (response == nil || response.Status() != http.StatusNotFound) {
+		return fmt.Errorf("failed to delete subnet set %q lease: %v", name, err)
+	}
+	return nil
+}
+
+// parseSubnetSet decodes the subnet set stored in the label
+func parseSubnetSet(label *accountsmgmtv1.Label) (*SubnetSet, error) {
+	subnetSet := &SubnetSet{Name: strings.TrimPrefix(label.Key(), subnetSetLabelPrefix)}
+	if err := json.Unmarshal([]byte(label.Value()), subnetSet); err != nil {
+		return nil, fmt.Errorf("failed to decode subnet set %q: %v", subnetSet.Name, err)
+	}
+	return subnetSet, nil
+}
+
+// parseSubnetLease decodes the subnet set lease stored in the label
+func parseSubnetLease(label *accountsmgmtv1.Label) (*subnetLease, error) {
+	var lease subnetLease
+	if err := json.Unmarshal([]byte(label.Value()), &lease); err != nil {
+		return nil, fmt.Errorf("failed to decode subnet set %q lease: %v", strings.TrimPrefix(label.Key(), subnetLeaseLabelPrefix), err)
+	}
+	return &lease, nil
+}
+
+// RegisterSubnetSet adds a pre-created subnet set to the registry so clusters can lease it
+func (r *Provider) RegisterSubnetSet(ctx context.Context, subnetSet *SubnetSet) error {
+	const action = "register"
+
+	if subnetSet.Name == "" || subnetSet.Region == "" || subnetSet.PrivateSubnet == "" || subnetSet.PublicSubnet == "" {
+		return &subnetRegistryError{action: action, err: fmt.Errorf("name, region, private and public subnets are required")}
+	}
+
+	labels, err := r.organizationLabels(ctx)
+	if err != nil {
+		return &subnetRegistryError{action: action, err: err}
+	}
+
+	if err = r.saveSubnetSet(ctx, labels, subnetSet); err != nil {
+		return &subnetRegistryError{action: action, err: err}
+	}
+
+	return nil
+}
+
+// UnregisterSubnetSet removes the subnet set and its lease from the registry
+func (r *Provider) UnregisterSubnetSet(ctx context.Context, name string) error {
+	const action = "unregister"
+
+	labels, err := r.organizationLabels(ctx)
+	if err != nil {
+		return &subnetRegistryError{action: action, err: err}
+	}
+
+	_, err = labels.Label(subnetSetLabelPrefix + name).Delete().SendContext(ctx)
+	if err != nil {
+		return &subnetRegistryError{action: action, err: fmt.Errorf("failed to delete subnet set %q: %v", name, err)}
+	}
+
+	if err = r.deleteSubnetLease(ctx, labels, name); err != nil {
+		return &subnetRegistryError{action: action, err: err}
+	}
+
+	return nil
+}
+
+// SubnetSets returns the registered subnet sets for the region, all regions when empty
+func (r *Provider) SubnetSets(ctx context.Context, region string) ([]*SubnetSet, error) {
+	const action = "list"
+	var (
+		subnetSets []*SubnetSet
+		leases     = map[string]*subnetLease{}
+	)
+
+	labels, err := r.organizationLabels(ctx)
+	if err != nil {
+		return nil, &subnetRegistryError{action: action, err: err}
+	}
+
+	for page := 1; ; page++ {
+		response, err := labels.List().Page(page).Size(100).SendContext(ctx)
+		if err != nil {
+			return nil, &subnetRegistryError{action: action, err: fmt.Errorf("failed to list organization labels: %v", err)}
+		}
+
+		for _, label := range response.Items().Slice() {
+			if strings.HasPrefix(label.Key(), subnetLeaseLabelPrefix) {
+				lease, err := parseSubnetLease(label)
+				if err != nil {
+					return nil, &subnetRegistryError{action: action, err: err}
+				}
+				leases[strings.TrimPrefix(label.Key(), subnetLeaseLabelPrefix)] = lease
+				continue
+			}
+
+			if !strings.HasPrefix(label.Key(), subnetSetLabelPrefix) {
+				continue
+			}
+
+			subnetSet, err := parseSubnetSet(label)
+			if err != nil {
+				return nil, &subnetRegistryError{action: action, err: err}
+			}
+
+			if region == "" || subnetSet.Region == region {
+				subnetSets = append(subnetSets, subnetSet)
+			}
+		}
+
+		if response.Size() < 100 {
+			break
+		}
+	}
+
+	for _, subnetSet := range subnetSets {
+		if lease, ok := leases[subnetSet.Name]; ok {
+			subnetSet.LeasedBy, subnetSet.LeasedAt = lease.LeasedBy, &lease.LeasedAt
+		}
+	}
+
+	sort.Slice(subnetSets, func(i, j int) bool { return subnetSets[i].Name < subnetSets[j].Name })
+
+	return subnetSets, nil
+}
+
+// LeaseSubnetSet leases an available subnet set in the region for the holder (e.g. cluster name), the
+// returned subnet sets LeasedBy is the lease id required to return it. Leases not returned within 24
+// hours are considered abandoned and can be leased again
+func (r *Provider) LeaseSubnetSet(ctx context.Context, region, holder string) (*SubnetSet, error) {
+	const action = "lease"
+
+	subnetSets, err := r.SubnetSets(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := r.organizationLabels(ctx)
+	if err != nil {
+		return nil, &subnetRegistryError{action: action, err: err}
+	}
+
+	leaseID := fmt.Sprintf("%s-%s", holder, workload.RandomSuffix(5))
+
+	for _, subnetSet := range subnetSets {
+		if subnetSet.leased() {
+			continue
+		}
+
+		if subnetSet.LeasedBy != "" {
+			released, err := r.releaseExpiredLease(ctx, labels, subnetSet)
+			if err != nil {
+				return nil, &subnetRegistryError{action: action, err: err}
+			}
+			if !released {
+				continue
+			}
+		}
+
+		// adding the lease label fails when it exists, only one holder can take the lease
+		lease := subnetLease{LeasedBy: leaseID, LeasedAt: time.Now().UTC()}
+		taken, err := r.addSubnetLease(ctx, labels, subnetSet.Name, lease)
+		if err != nil {
+			return nil, &subnetRegistryError{action: action, err: err}
+		}
+		if !taken {
+			continue
+		}
+
+		subnetSet.LeasedBy, subnetSet.LeasedAt = lease.LeasedBy, &lease.LeasedAt
+		log.Printf("Leased subnet set %q for %q", subnetSet.Name, holder)
+
+		return subnetSet, nil
+	}
+
+	return nil, &subnetRegistryError{action: action, err: fmt.Errorf("no subnet sets available in region %q", region)}
+}
+
+// releaseExpiredLease deletes the expired lease of the subnet set, it returns false when the
+// subnet set was leased again or its expired lease is being taken over by another holder. Labels
+// have no conditional delete, the takeover label of the expired lease ensures only one holder
+// reads and deletes it so a lease taken by another holder in between is never deleted
+func (r *Provider) releaseExpiredLease(ctx context.Context, labels *accountsmgmtv1.GenericLabelsClient, subnetSet *SubnetSet) (bool, error) {
+	takeoverKey := subnetTakeoverLabelPrefix + subnetSet.LeasedBy
+
+	takeover, err := r.addLabel(ctx, labels, takeoverKey, subnetSet.Name)
+	if err != nil || !takeover {
+		return false, err
+	}
+
+	defer func() {
+		response, err := labels.Label(takeoverKey).Delete().SendContext(ctx)
+		if err != nil && (response == nil || response.Status() != http.StatusNotFound) {
+			log.Printf("Failed to delete subnet set %q lease takeover: %v", subnetSet.Name, err)
+		}
+	}()
+
+	lease, err := r.subnetLease(ctx, labels, subnetSet.Name)
+	if err != nil {
+		return false, err
+	}
+
+	if lease == nil {
+		return true, nil
+	}
+
+	if lease.LeasedBy != subnetSet.LeasedBy {
+		return false, nil
+	}
+
+	log.Printf("Subnet set %q lease by %q expired, releasing it", subnetSet.Name, lease.LeasedBy)
+
+	if err = r.deleteSubnetLease(ctx, labels, subnetSet.Name); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ReturnSubnetSet releases the lease on the subnet set so it can be leased again. The lease is only
+// released when it is held by the lease id (the leased subnet sets LeasedBy), a lease that expired
+// (or is about to) is left in place to be taken over by another holder
+func (r *Provider) ReturnSubnetSet(ctx context.Context, name, leaseID string) error {
+	const action = "return"
+
+	if leaseID == "" {
+		return &subnetRegistryError{action: action, err: fmt.Errorf("subnet set %q lease id is required", name)}
+	}
+
+	labels, err := r.organizationLabels(ctx)
+	if err != nil {
+		return &subnetRegistryError{action: action, err: err}
+	}
+
+	lease, err := r.subnetLease(ctx, labels, name)
+	if err != nil {
+		return &subnetRegistryError{action: action, err: err}
+	}
+
+	switch {
+	case lease == nil:
+		log.Printf("Subnet set %q is not leased", name)
+		return nil
+	case lease.LeasedBy != leaseID:
+		log.Printf("Subnet set %q is leased by %q, not %q, leaving the lease in place", name, lease.LeasedBy, leaseID)
+		return nil
+	case time.Since(lease.LeasedAt) > subnetLeaseExpiration-subnetLeaseReturnMargin:
+		log.Printf("Subnet set %q lease by %q expired, leaving it to be taken over", name, leaseID)
+		return nil
+	}
+
+	if err = r.deleteSubnetLease(ctx, labels, name); err != nil {
+		return &subnetRegistryError{action: action, err: err}
+	}
+
+	log.Printf("Returned subnet set %q", name)
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package rosa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	awscloud "github.com/openshift/osde2e-framework/pkg/providers/clouds/aws"
+)
+
+const (
+	assumeRoleAction                = "sts:AssumeRole"
+	assumeRoleWithWebIdentityAction = "sts:AssumeRoleWithWebIdentity"
+	ec2ServicePrincipal             = "ec2.amazonaws.com"
+)
+
+// trustPolicyError represents the custom error
+type trustPolicyError struct {
+	differences []string
+	err         error
+}
+
+// Error returns the formatted error message when trustPolicyError is invoked
+func (t *trustPolicyError) Error() string {
+	if t.err != nil {
+		return fmt.Sprintf("trust policy verification failed: %v", t.err)
+	}
+	return fmt.Sprintf("trust policy verification failed:\n%s", strings.Join(t.differences, "\n"))
+}
+
+// stringOrSlice represents an iam policy value that can be either a string or list of strings
+type stringOrSlice []string
+
+// UnmarshalJSON decodes either a string or list of strings
+func (s *stringOrSlice) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*s = []string{value}
+		return nil
+	}
+
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*s = values
+
+	return nil
+}
+
+// trustPolicy represents an iam role trust (assume role) policy document
+type trustPolicy struct {
+	Statement []struct {
+		Effect    string                              `json:"Effect"`
+		Principal map[string]stringOrSlice            `json:"Principal"`
+		Action    stringOrSlice                       `json:"Action"`
+		Condition map[string]map[string]stringOrSlice `json:"Condition"`
+	} `json:"Statement"`
+}
+
+// trustExpectation represents what a role trust policy must allow
+type trustExpectation struct {
+	// principalType is the principal type (AWS, Federated, Service) that must be trusted
+	principalType string
+	// principal is the principal that must be trusted, any principal of the type is accepted when empty
+	principal string
+	action    string
+	// subjects are the values the "<issuer>:sub" condition key must allow
+	conditionKey string
+	subjects     []string
+}
+
+// differences returns a description of each way the trust policy does not meet the expectation
+func (e *trustExpectation) differences(roleARN string, policy *trustPolicy) []string {
+	var (
+		found      []string
+		conditions = map[string]bool{}
+	)
+
+	for _, statement := range policy.Statement {
+		if statement.Effect != "Allow" || !contains(statement.Action, e.action) {
+			continue
+		}
+
+		principals := statement.Principal[e.principalType]
+		found = append(found, principals...)
+
+		if e.principal != "" && !contains(principals, e.principal) {
+			continue
+		}
+		if e.principal == "" && len(principals) == 0 {
+			continue
+		}
+
+		for _, operator := range statement.Condition {
+			for _, value := range operator[e.conditionKey] {
+				conditions[value] = true
+			}
+		}
+
+		var missing []string
+		for _, subject := range e.subjects {
+			if !conditions[subject] {
+				missing = append(missing, subject)
+			}
+		}
+
+		if len(missing) == 0 {
+			return nil
+		}
+
+		return []string{fmt.Sprintf("role %q: condition %q is missing subjects %v", roleARN, e.conditionKey, missing)}
+	}
+
+	sort.Strings(found)
+
+	expected := e.principal
+	if expected == "" {
+		expected = "<any>"
+	}
+
+	return []string{fmt.Sprintf("role %q: expected %s principal %q allowed %q, found %s principals %v",
+		roleARN, e.principalType, expected, e.action, e.principalType, found)}
+}
+
+// roleTrustPolicy returns the trust policy for the iam role in the aws account of the credentials
+func roleTrustPolicy(ctx context.Context, credentials *awscloud.AWSCredentials, roleARN string) (*trustPolicy, error) {
+	var role struct {
+		Role struct {
+			AssumeRolePolicyDocument trustPolicy `json:"AssumeRolePolicyDocument"`
+		} `json:"Role"`
+	}
+
+	roleName := roleARN[strings.LastIndex(roleARN, "/")+1:]
+
+	err := awsCLI(ctx, credentials, &role, "iam", "get-role", "--role-name", roleName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role %q trust policy: %v", roleARN, err)
+	}
+
+	return &role.Role.AssumeRolePolicyDocument, nil
+}
+
+// verifyTrustPolicies verifies each role trust policy meets its expectation,
+// returning all differences found
+func (r *Provider) verifyTrustPolicies(ctx context.Context, expectations map[string]*trustExpectation) error {
+	var differences []string
+
+	roleARNs := make([]string, 0, len(expectations))
+	for roleARN := range expectations {
+		roleARNs = append(roleARNs, roleARN)
+	}
+	sort.Strings(roleARNs)
+
+	for _, roleARN := range roleARNs {
+		policy, err := roleTrustPolicy(ctx, r.awsCredentials, roleARN)
+		if err != nil {
+			return &trustPolicyError{err: err}
+		}
+
+		differences = append(differences, expectations[roleARN].differences(roleARN, policy)...)
+	}
+
+	if len(differences) > 0 {
+		return &trustPolicyError{differences: differences}
+	}
+
+	return nil
+}
+
+// verifyAccountRolesTrustPolicies verifies the account roles trust the expected principals
+func (r *Provider) verifyAccountRolesTrustPolicies(ctx context.Context, roles *accountRoles) error {
+	expectations := map[string]*trustExpectation{
+		roles.installerRoleARN: {principalType: "AWS", action: assumeRoleAction},
+		roles.supportRoleARN:   {principalType: "AWS", action: assumeRoleAction},
+		roles.workerRoleARN:    {principalType: "Service", principal: ec2ServicePrincipal, action: assumeRoleAction},
+	}
+
+	if roles.controlPlaneRoleARN != "" {
+		expectations[roles.controlPlaneRoleARN] = &trustExpectation{principalType: "Service", principal: ec2ServicePrincipal, action: assumeRoleAction}
+	}
+
+	return r.verifyTrustPolicies(ctx, expectations)
+}
+
+// verifyOperatorRolesTrustPolicies verifies the clusters operator roles trust the clusters
+// oidc provider and are restricted to the operators service accounts
+func (r *Provider) verifyOperatorRolesTrustPolicies(ctx context.Context, cluster *clustersmgmtv1.Cluster) error {
+	issuer := strings.TrimPrefix(cluster.AWS().STS().OIDCEndpointURL(), "https://")
+	if issuer == "" {
+		return &trustPolicyError{err: fmt.Errorf("cluster %q has no oidc endpoint url", cluster.ID())}
+	}
+
+	response, err := r.ClustersMgmt().V1().AWSInquiries().STSCredentialRequests().List().
+		Parameter("is_hypershift", cluster.Hypershift().Enabled()).
+		SendContext(ctx)
+	if err != nil {
+		return &trustPolicyError{err: fmt.Errorf("failed to get sts credential requests: %v", err)}
+	}
+
+	serviceAccounts := map[string][]string{}
+	for _, credentialRequest := range response.Items().Slice() {
+		operator := credentialRequest.Operator()
+		for _, serviceAccount := range operator.ServiceAccounts() {
+			key := operator.Namespace() + "/" + operator.Name()
+			serviceAccounts[key] = append(serviceAccounts[key], fmt.Sprintf("system:serviceaccount:%s:%s", operator.Namespace(), serviceAccount))
+		}
+	}
+
+	expectations := map[string]*trustExpectation{}
+	for _, operatorRole := range cluster.AWS().STS().OperatorIAMRoles() {
+		expectations[operatorRole.RoleARN()] = &trustExpectation{
+			principalType: "Federated",
+			principal:     fmt.Sprintf("arn:%s:iam::%s:oidc-provider/%s", awsPartition(cluster.Region().ID()), cluster.AWS().AccountID(), issuer),
+			action:        assumeRoleWithWebIdentityAction,
+			conditionKey:  issuer + ":sub",
+			subjects:      serviceAccounts[operatorRole.Namespace()+"/"+operatorRole.Name()],
+		}
+	}
+
+	return r.verifyTrustPolicies(ctx, expectations)
+}
@@ -0,0 +1,63 @@
+package rosa
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const operatorTrustPolicy = `{
+	"Version": "2012-10-17",
+	"Statement": [{
+		"Effect": "Allow",
+		"Principal": {"Federated": "arn:aws:iam::123456789012:oidc-provider/oidc.example.com/abc"},
+		"Action": "sts:AssumeRoleWithWebIdentity",
+		"Condition": {"StringEquals": {"oidc.example.com/abc:sub": ["system:serviceaccount:openshift-image-registry:registry"]}}
+	}]
+}`
+
+var _ = Describe("trustExpectation", func() {
+	var policy *trustPolicy
+
+	BeforeEach(func() {
+		policy = &trustPolicy{}
+		Expect(json.Unmarshal([]byte(operatorTrustPolicy), policy)).To(Succeed())
+	})
+
+	It("should report no differences when the trust policy matches", func() {
+		expectation := &trustExpectation{
+			principalType: "Federated",
+			principal:     "arn:aws:iam::123456789012:oidc-provider/oidc.example.com/abc",
+			action:        assumeRoleWithWebIdentityAction,
+			conditionKey:  "oidc.example.com/abc:sub",
+			subjects:      []string{"system:serviceaccount:openshift-image-registry:registry"},
+		}
+		Expect(expectation.differences("role", policy)).To(BeEmpty())
+	})
+
+	It("should report the wrong oidc provider", func() {
+		expectation := &trustExpectation{
+			principalType: "Federated",
+			principal:     "arn:aws:iam::123456789012:oidc-provider/oidc.example.com/xyz",
+			action:        assumeRoleWithWebIdentityAction,
+		}
+		Expect(expectation.differences("role", policy)).To(ConsistOf(ContainSubstring("oidc.example.com/abc")))
+	})
+
+	It("should report missing service account subjects", func() {
+		expectation := &trustExpectation{
+			principalType: "Federated",
+			principal:     "arn:aws:iam::123456789012:oidc-provider/oidc.example.com/abc",
+			action:        assumeRoleWithWebIdentityAction,
+			conditionKey:  "oidc.example.com/abc:sub",
+			subjects:      []string{"system:serviceaccount:openshift-image-registry:installer-cloud-credentials"},
+		}
+		Expect(expectation.differences("role", policy)).To(ConsistOf(ContainSubstring("installer-cloud-credentials")))
+	})
+
+	It("should report a missing service principal", func() {
+		expectation := &trustExpectation{principalType: "Service", principal: ec2ServicePrincipal, action: assumeRoleAction}
+		Expect(expectation.differences("role", policy)).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,71 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+)
+
+// verifyClusterConfiguration verifies the cluster reflects the options it was created with
+func (r *Provider) verifyClusterConfiguration(ctx context.Context, cluster *ClusterHandle, options *CreateClusterOptions) error {
+	client, err := openshift.NewFromKubeconfig(cluster.KubeConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to construct openshift client: %v", err)
+	}
+
+	log.Println("Start: ROSA Cluster configuration verification..")
+
+	if options.EtcdEncryption {
+		if err = verifyEtcdEncryption(ctx, client); err != nil {
+			return err
+		}
+	}
+
+	if options.BaseDomain != "" {
+		if err = verifyBaseDomain(ctx, client, options.BaseDomain); err != nil {
+			return err
+		}
+	}
+
+	if options.Ec2MetadataHttpTokens != "" {
+		if err = r.verifyEc2MetadataHTTPTokens(ctx, client, options.Ec2MetadataHttpTokens); err != nil {
+			return err
+		}
+	}
+
+	if len(options.WorkerLabels) > 0 {
+		if err = verifyWorkerLabels(ctx, client, options.WorkerLabels); err != nil {
+			return err
+		}
+	}
+
+	if options.AuditLogForwarding != nil {
+		if err = r.VerifyAuditLogForwarding(ctx, cluster.ID, options.AuditLogForwarding); err != nil {
+			return err
+		}
+	}
+
+	log.Println("End: ROSA Cluster configuration verification..")
+
+	return nil
+}
+
+// verifyEtcdEncryption verifies the cluster api server reports etcd encryption is enabled
+func verifyEtcdEncryption(ctx context.Context, client *openshift.Client) error {
+	var apiServer configv1.APIServer
+	if err := client.Get(ctx, "cluster", "", &apiServer); err != nil {
+		return fmt.Errorf("failed to get api server configuration: %v", err)
+	}
+
+	encryptionType := apiServer.Spec.Encryption.Type
+	if encryptionType == "" || encryptionType == configv1.EncryptionTypeIdentity {
+		return fmt.Errorf("etcd encryption is not enabled (type=%q)", encryptionType)
+	}
+
+	log.Printf("Etcd encryption is enabled (type=%s)", encryptionType)
+
+	return nil
+}
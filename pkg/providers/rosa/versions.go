@@ -0,0 +1,114 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	ocmclient "github.com/openshift/osde2e-framework/pkg/clients/ocm"
+)
+
+// Version represents an openshift version available for rosa clusters
+type Version = ocmclient.Version
+
+// Versions represents the openshift versions available for rosa clusters in a channel group,
+// the versions HostedCP is only set when hosted control plane clusters are supported in the region
+type Versions struct {
+	*ocmclient.Versions
+	Region string
+	// RegionSupportsHostedCP is true when hosted control plane clusters are supported in the region
+	RegionSupportsHostedCP bool
+}
+
+// versionsError represents the custom error
+type versionsError struct {
+	err error
+}
+
+// Error returns the formatted error message when versionsError is invoked
+func (v *versionsError) Error() string {
+	return fmt.Sprintf("get versions failed: %v", v.err)
+}
+
+// HostedCP returns the versions hosted control plane clusters can be created with in the region
+func (v *Versions) HostedCP() []*Version {
+	var versions []*Version
+	for _, version := range v.Available {
+		if version.HostedCP {
+			versions = append(versions, version)
+		}
+	}
+	return versions
+}
+
+// Latest returns the newest version of the minor release minorsBehind the newest minor
+// release (0 is the newest, 1 is y-1), limited to hosted control plane versions when hostedCP is set
+//
+//	versions, err := provider.Versions(ctx, "stable")
+//	Expect(err).ShouldNot(HaveOccurred())
+//	previous, err := versions.Latest(1, false)
+func (v *Versions) Latest(minorsBehind int, hostedCP bool) (*Version, error) {
+	if !hostedCP {
+		return v.Versions.Latest(minorsBehind)
+	}
+
+	return (&ocmclient.Versions{ChannelGroup: v.ChannelGroup, Available: v.HostedCP()}).Latest(minorsBehind)
+}
+
+// Versions returns the enabled rosa versions for the channel group (e.g. stable, candidate)
+// and which of them hosted control plane clusters can be created with in the providers region
+func (r *Provider) Versions(ctx context.Context, channelGroup string) (*Versions, error) {
+	enabled, err := r.Client.Versions(ctx, channelGroup)
+	if err != nil {
+		return nil, &versionsError{err: err}
+	}
+
+	versions := &Versions{
+		Versions: &ocmclient.Versions{ChannelGroup: enabled.ChannelGroup},
+		Region:   r.awsCredentials.Region,
+	}
+
+	if versions.Region != "" {
+		response, err := r.ClustersMgmt().V1().CloudProviders().CloudProvider("aws").Regions().Region(versions.Region).Get().SendContext(ctx)
+		if err != nil {
+			return nil, &versionsError{err: fmt.Errorf("failed to get region %q: %v", versions.Region, err)}
+		}
+		versions.RegionSupportsHostedCP = response.Body().SupportsHypershift()
+	}
+
+	for _, version := range enabled.Available {
+		if !version.ROSA {
+			continue
+		}
+		version.HostedCP = version.HostedCP && versions.RegionSupportsHostedCP
+		versions.Available = append(versions.Available, version)
+	}
+
+	return versions, nil
+}
+
+// resolveVersion sets the version when undefined to the channel groups default version,
+// otherwise the latest version (limited to hosted control plane versions when hosted)
+func (r *Provider) resolveVersion(ctx context.Context, options *CreateClusterOptions) error {
+	if options.Version != "" {
+		return nil
+	}
+
+	versions, err := r.Versions(ctx, options.ChannelGroup)
+	if err != nil {
+		return err
+	}
+
+	version := versions.Default()
+	if version == nil || (options.HostedCP && !version.HostedCP) {
+		version, err = versions.Latest(0, options.HostedCP)
+		if err != nil {
+			return fmt.Errorf("failed to resolve version: %v", err)
+		}
+	}
+
+	log.Printf("Resolved cluster version to %q from channel group %q", version.ID, versions.ChannelGroup)
+	options.Version = version.ID
+
+	return nil
+}
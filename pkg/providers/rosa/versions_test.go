@@ -0,0 +1,45 @@
+package rosa
+
+import (
+	"github.com/Masterminds/semver"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	ocmclient "github.com/openshift/osde2e-framework/pkg/clients/ocm"
+)
+
+var _ = Describe("Versions", func() {
+	version := func(id string, hostedCP bool) *Version {
+		return &Version{ID: id, Version: semver.MustParse(id), HostedCP: hostedCP}
+	}
+
+	versions := &Versions{Versions: &ocmclient.Versions{
+		ChannelGroup: "stable",
+		Available: []*Version{
+			version("4.12.40", false),
+			version("4.13.10", true),
+			version("4.13.21", true),
+			version("4.14.1", false),
+			version("4.14.2", false),
+		},
+	}}
+
+	It("should return the latest version of the minor release", func() {
+		latest, err := versions.Latest(0, false)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(latest.ID).To(Equal("4.14.2"))
+
+		previous, err := versions.Latest(1, false)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(previous.ID).To(Equal("4.13.21"))
+	})
+
+	It("should limit to hosted control plane versions", func() {
+		latest, err := versions.Latest(0, true)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(latest.ID).To(Equal("4.13.21"))
+
+		_, err = versions.Latest(1, true)
+		Expect(err).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,71 @@
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	workerNodeRoleLabel = "node-role.kubernetes.io/worker"
+	infraNodeRoleLabel  = "node-role.kubernetes.io/infra"
+)
+
+// validateWorkerLabels verifies the worker labels are valid kubernetes labels
+func validateWorkerLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("worker label key %q is invalid: %s", key, strings.Join(errs, ", "))
+		}
+
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("worker label %q value %q is invalid: %s", key, value, strings.Join(errs, ", "))
+		}
+	}
+
+	return nil
+}
+
+// verifyWorkerLabels verifies the clusters worker nodes (excluding infra nodes) carry the labels
+func verifyWorkerLabels(ctx context.Context, client *openshift.Client, labels map[string]string) error {
+	var nodes corev1.NodeList
+	if err := client.List(ctx, &nodes); err != nil {
+		return fmt.Errorf("failed to list nodes: %v", err)
+	}
+
+	workers := 0
+	var missing []string
+	for _, node := range nodes.Items {
+		_, worker := node.Labels[workerNodeRoleLabel]
+		_, infra := node.Labels[infraNodeRoleLabel]
+		if !worker || infra {
+			continue
+		}
+		workers++
+
+		for key, value := range labels {
+			if actual, ok := node.Labels[key]; !ok || actual != value {
+				missing = append(missing, fmt.Sprintf("%s (%s=%s)", node.Name, key, value))
+			}
+		}
+	}
+
+	if workers == 0 {
+		return fmt.Errorf("no worker nodes found to verify the worker labels")
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("worker nodes are missing labels: %s", strings.Join(missing, ", "))
+	}
+
+	log.Printf("Worker nodes carry the worker labels %v", labels)
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package rosa
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/osde2e-framework/pkg/clients/kubernetesfake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Worker Labels", func() {
+	It("should validate the labels", func() {
+		Expect(validateWorkerLabels(map[string]string{"osde2e.io/pool": "default"})).To(Succeed())
+		Expect(validateWorkerLabels(map[string]string{"invalid key": "default"})).ToNot(Succeed())
+		Expect(validateWorkerLabels(map[string]string{"pool": "invalid value"})).ToNot(Succeed())
+	})
+
+	It("should verify the worker nodes carry the labels", func() {
+		node := func(name string, labels map[string]string) *corev1.Node {
+			return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+		}
+
+		server, err := kubernetesfake.NewServer(
+			node("worker-1", map[string]string{workerNodeRoleLabel: "", "pool": "default"}),
+			node("worker-2", map[string]string{workerNodeRoleLabel: ""}),
+			node("infra-1", map[string]string{workerNodeRoleLabel: "", infraNodeRoleLabel: ""}),
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		DeferCleanup(server.Close)
+
+		client, err := server.Client()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		err = verifyWorkerLabels(context.Background(), client, map[string]string{"pool": "default"})
+		Expect(err).To(MatchError("worker nodes are missing labels: worker-2 (pool=default)"))
+	})
+})
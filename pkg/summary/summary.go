@@ -0,0 +1,270 @@
+package summary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Classification represents the category of a failure
+type Classification string
+
+const (
+	Timeout        Classification = "timeout"
+	Cancelled      Classification = "cancelled"
+	Authentication Classification = "authentication"
+	Quota          Classification = "quota"
+	Transient      Classification = "transient"
+	Unknown        Classification = "unknown"
+)
+
+// Cluster represents a cluster created or deleted during the run
+type Cluster struct {
+	ID      string
+	Name    string
+	Created time.Time
+	Deleted time.Time
+}
+
+// Phase represents a timed step performed during the run
+type Phase struct {
+	Name     string
+	Cluster  string
+	Duration time.Duration
+	Failed   bool
+}
+
+// Failure represents an operation that failed during the run
+type Failure struct {
+	Operation      string
+	Classification Classification
+	Err            error
+}
+
+// Cost represents an estimated cost incurred during the run
+type Cost struct {
+	Description string
+	USD         float64
+}
+
+// Snapshot represents point in time details captured during the run (e.g. cluster metrics)
+type Snapshot struct {
+	Name    string
+	Time    time.Time
+	Details string
+}
+
+// Summary collects the clusters, phases, failures, artifacts, snapshots and costs of a run
+// so they can be printed as a single block once the run is finished
+type Summary struct {
+	// ClusterHourlyCost is the estimated cost in USD per cluster hour, cluster costs
+	// are not estimated when it is zero
+	ClusterHourlyCost float64
+
+	mu        sync.Mutex
+	start     time.Time
+	clusters  map[string]*Cluster
+	phases    []Phase
+	failures  []Failure
+	artifacts []string
+	snapshots []Snapshot
+	costs     []Cost
+}
+
+var globalSummary = New()
+
+// New returns an empty summary starting now
+func New() *Summary {
+	return &Summary{start: time.Now(), clusters: map[string]*Cluster{}}
+}
+
+// Global returns the summary the providers record to
+func Global() *Summary {
+	return globalSummary
+}
+
+// ClusterCreated records a cluster was created
+func (s *Summary) ClusterCreated(id, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cluster(id, name).Created = time.Now()
+}
+
+// ClusterDeleted records a cluster was deleted
+func (s *Summary) ClusterDeleted(id, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cluster(id, name).Deleted = time.Now()
+}
+
+// cluster returns the recorded cluster, adding it when it does not exist
+func (s *Summary) cluster(id, name string) *Cluster {
+	cluster, ok := s.clusters[id]
+	if !ok {
+		cluster = &Cluster{ID: id}
+		s.clusters[id] = cluster
+	}
+	if name != "" {
+		cluster.Name = name
+	}
+	return cluster
+}
+
+// Phase records the duration of a step since start, recording a failure when err is not nil
+//
+//	start := time.Now()
+//	err := doSomething()
+//	summary.Global().Phase("do something", clusterName, start, err)
+func (s *Summary) Phase(name, cluster string, start time.Time, err error) {
+	s.mu.Lock()
+	s.phases = append(s.phases, Phase{Name: name, Cluster: cluster, Duration: time.Since(start), Failed: err != nil})
+	s.mu.Unlock()
+
+	if err != nil {
+		s.Failure(name, err)
+	}
+}
+
+// Failure records a failed operation and classifies the error
+func (s *Summary) Failure(operation string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, Failure{Operation: operation, Classification: Classify(err), Err: err})
+}
+
+// Artifact records a file written during the run
+func (s *Summary) Artifact(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.artifacts = append(s.artifacts, path)
+}
+
+// Snapshot records point in time details, multi line details are indented in the summary
+func (s *Summary) Snapshot(name, details string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshots = append(s.snapshots, Snapshot{Name: name, Time: time.Now(), Details: details})
+}
+
+// Cost records an estimated cost incurred during the run
+func (s *Summary) Cost(description string, usd float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.costs = append(s.costs, Cost{Description: description, USD: usd})
+}
+
+// Classify returns the classification of the error
+func Classify(err error) Classification {
+	var temporary interface{ Temporary() bool }
+	message := strings.ToLower(err.Error())
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), strings.Contains(message, "timed out"), strings.Contains(message, "timeout"):
+		return Timeout
+	case errors.Is(err, context.Canceled):
+		return Cancelled
+	case strings.Contains(message, "401"), strings.Contains(message, "403"), strings.Contains(message, "unauthorized"),
+		strings.Contains(message, "forbidden"), strings.Contains(message, "expired"):
+		return Authentication
+	case strings.Contains(message, "quota"), strings.Contains(message, "limitexceeded"):
+		return Quota
+	case errors.As(err, &temporary) && temporary.Temporary(), strings.Contains(message, "503"),
+		strings.Contains(message, "connection reset"), strings.Contains(message, "throttl"):
+		return Transient
+	default:
+		return Unknown
+	}
+}
+
+// Print writes the summary to the writer, it is typically deferred in main
+// or called from a ginkgo ReportAfterSuite node
+//
+//	defer summary.Global().Print(os.Stdout)
+func (s *Summary) Print(w io.Writer) {
+	fmt.Fprint(w, s.String())
+}
+
+// String returns the summary formatted as a single block
+func (s *Summary) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var b strings.Builder
+	now := time.Now()
+
+	fmt.Fprintf(&b, "===== osde2e run summary (%s) =====\n", now.Sub(s.start).Round(time.Second))
+
+	clusters := make([]*Cluster, 0, len(s.clusters))
+	for _, cluster := range s.clusters {
+		clusters = append(clusters, cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].ID < clusters[j].ID })
+
+	var clusterHours float64
+	fmt.Fprintf(&b, "Clusters (%d):\n", len(clusters))
+	for _, cluster := range clusters {
+		status := "created"
+		end := now
+		switch {
+		case !cluster.Deleted.IsZero() && cluster.Created.IsZero():
+			status = "deleted"
+		case !cluster.Deleted.IsZero():
+			status = "created, deleted"
+			end = cluster.Deleted
+		}
+		if !cluster.Created.IsZero() {
+			clusterHours += end.Sub(cluster.Created).Hours()
+		}
+		fmt.Fprintf(&b, "  %s (%s): %s\n", cluster.Name, cluster.ID, status)
+	}
+
+	fmt.Fprintf(&b, "Phases (%d):\n", len(s.phases))
+	for _, phase := range s.phases {
+		result := "ok"
+		if phase.Failed {
+			result = "failed"
+		}
+		fmt.Fprintf(&b, "  %s %s: %s (%s)\n", phase.Cluster, phase.Name, phase.Duration.Round(time.Second), result)
+	}
+
+	fmt.Fprintf(&b, "Failures (%d):\n", len(s.failures))
+	for _, failure := range s.failures {
+		fmt.Fprintf(&b, "  [%s] %s: %v\n", failure.Classification, failure.Operation, failure.Err)
+	}
+
+	fmt.Fprintf(&b, "Artifacts (%d):\n", len(s.artifacts))
+	for _, artifact := range s.artifacts {
+		fmt.Fprintf(&b, "  %s\n", artifact)
+	}
+
+	fmt.Fprintf(&b, "Snapshots (%d):\n", len(s.snapshots))
+	for _, snapshot := range s.snapshots {
+		fmt.Fprintf(&b, "  %s (%s):\n", snapshot.Name, snapshot.Time.Format(time.RFC3339))
+		for _, line := range strings.Split(strings.TrimRight(snapshot.Details, "\n"), "\n") {
+			fmt.Fprintf(&b, "    %s\n", line)
+		}
+	}
+
+	costs := s.costs
+	if s.ClusterHourlyCost > 0 && clusterHours > 0 {
+		costs = append(costs[:len(costs):len(costs)], Cost{
+			Description: fmt.Sprintf("%.2f cluster hours", clusterHours),
+			USD:         clusterHours * s.ClusterHourlyCost,
+		})
+	}
+
+	var total float64
+	fmt.Fprintf(&b, "Estimated costs:\n")
+	for _, cost := range costs {
+		total += cost.USD
+		fmt.Fprintf(&b, "  %s: $%.2f\n", cost.Description, cost.USD)
+	}
+	fmt.Fprintf(&b, "  total: $%.2f\n", total)
+
+	return b.String()
+}
@@ -0,0 +1,41 @@
+package summary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Summary", func() {
+	It("should classify failures", func() {
+		Expect(Classify(fmt.Errorf("wait: %w", context.DeadlineExceeded))).To(Equal(Timeout))
+		Expect(Classify(fmt.Errorf("status 403: forbidden"))).To(Equal(Authentication))
+		Expect(Classify(fmt.Errorf("cluster quota exceeded"))).To(Equal(Quota))
+		Expect(Classify(fmt.Errorf("unexpected"))).To(Equal(Unknown))
+	})
+
+	It("should print the recorded run", func() {
+		s := New()
+		s.ClusterHourlyCost = 1
+		s.ClusterCreated("abc", "my-cluster")
+		s.ClusterDeleted("abc", "my-cluster")
+		s.Phase("install", "my-cluster", time.Now().Add(-time.Minute), nil)
+		s.Phase("health checks", "my-cluster", time.Now(), fmt.Errorf("request timed out"))
+		s.Artifact("abc-install.log")
+		s.Snapshot("my-cluster metrics", "cpu: 1/4 cores\nnodes: 3")
+		s.Cost("vpc", 0.5)
+
+		output := s.String()
+		Expect(output).To(ContainSubstring("my-cluster (abc): created, deleted"))
+		Expect(output).To(ContainSubstring("my-cluster install: 1m0s (ok)"))
+		Expect(output).To(ContainSubstring("[timeout] health checks: request timed out"))
+		Expect(output).To(ContainSubstring("abc-install.log"))
+		Expect(output).To(ContainSubstring("my-cluster metrics"))
+		Expect(output).To(ContainSubstring("    nodes: 3"))
+		Expect(output).To(ContainSubstring("vpc: $0.50"))
+		Expect(output).To(ContainSubstring("cluster hours"))
+	})
+})
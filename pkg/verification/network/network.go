@@ -0,0 +1,339 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/openshift/osde2e-framework/internal/workload"
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	defaultImage     = "registry.access.redhat.com/ubi9/python-311"
+	defaultTimeout   = 5 * time.Minute
+	namespacePrefix  = "osde2e-network"
+	serverName       = "network-server"
+	clientName       = "network-client"
+	serverPort       = 8080
+	workerNodeLabel  = "node-role.kubernetes.io/worker"
+	requestAttempts  = 5
+	requestRetryWait = 5 * time.Second
+)
+
+// Path represents the network path that was verified
+type Path string
+
+const (
+	PodToPod     Path = "pod-to-pod"
+	PodToService Path = "pod-to-service"
+	Ingress      Path = "ingress"
+)
+
+// Options represents the data used to configure the network verification
+type Options struct {
+	// Image is the container image used for the client and server pods, it must provide python3 and curl
+	Image string
+	// Timeout is the maximum time to wait for each resource to become ready
+	Timeout time.Duration
+	// SkipIngress skips verifying the ingress (north-south) path
+	SkipIngress bool
+}
+
+// CheckResult represents the outcome of verifying a single network path
+type CheckResult struct {
+	Path    Path
+	Target  string
+	Latency time.Duration
+	Error   error
+}
+
+// Result represents the outcome of the network verification
+type Result struct {
+	ClientNode string
+	ServerNode string
+	Checks     []CheckResult
+}
+
+// Failed returns the checks that failed
+func (r *Result) Failed() []CheckResult {
+	var failed []CheckResult
+	for _, check := range r.Checks {
+		if check.Error != nil {
+			failed = append(failed, check)
+		}
+	}
+	return failed
+}
+
+// String returns a human readable summary of the network verification
+func (r *Result) String() string {
+	lines := []string{fmt.Sprintf("client node: %s, server node: %s", r.ClientNode, r.ServerNode)}
+	for _, check := range r.Checks {
+		if check.Error != nil {
+			lines = append(lines, fmt.Sprintf("%s (%s): failed: %v", check.Path, check.Target, check.Error))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s (%s): %s", check.Path, check.Target, check.Latency))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// networkError represents the custom error
+type networkError struct {
+	err error
+}
+
+// Error returns the formatted error message when networkError is invoked
+func (n *networkError) Error() string {
+	return fmt.Sprintf("network verification failed: %v", n.err)
+}
+
+// setDefaults sets default options when undefined
+func (o *Options) setDefaults() {
+	if o.Image == "" {
+		o.Image = defaultImage
+	}
+	if o.Timeout == 0 {
+		o.Timeout = defaultTimeout
+	}
+}
+
+// Verify deploys a server and client pod on different worker nodes (when
+// available) and verifies the pod-to-pod, pod-to-service and ingress network
+// paths, reporting the latency or failure of each. All resources created are
+// removed once finished. An error is returned when the verification could not
+// be performed or any of the network paths failed
+//
+//	result, err := network.Verify(ctx, client, &network.Options{})
+//	Expect(err).ShouldNot(HaveOccurred(), result.String())
+func Verify(ctx context.Context, client *openshift.Client, options *Options) (*Result, error) {
+	if options == nil {
+		options = &Options{}
+	}
+	options.setDefaults()
+
+	serverNode, clientNode, err := selectNodes(ctx, client)
+	if err != nil {
+		return nil, &networkError{err: err}
+	}
+
+	result := &Result{ClientNode: clientNode, ServerNode: serverNode}
+
+	namespace, err := workload.CreateNamespace(ctx, client, namespacePrefix)
+	if err != nil {
+		return result, &networkError{err: err}
+	}
+
+	defer func() {
+		if err := workload.DeleteNamespace(ctx, client, namespace); err != nil {
+			log.Printf("Failed to clean up network verification resources: %v", err)
+		}
+	}()
+
+	server, err := createServer(ctx, client, namespace.Name, serverNode, options)
+	if err != nil {
+		return result, &networkError{err: err}
+	}
+
+	service, err := createService(ctx, client, namespace.Name)
+	if err != nil {
+		return result, &networkError{err: err}
+	}
+
+	clientPod, err := createPod(ctx, client, clientName, namespace.Name, clientNode, []string{"sleep", "infinity"}, options)
+	if err != nil {
+		return result, &networkError{err: err}
+	}
+
+	targets := []struct {
+		path Path
+		url  string
+	}{
+		{path: PodToPod, url: fmt.Sprintf("http://%s:%d", server.Status.PodIP, serverPort)},
+		{path: PodToService, url: fmt.Sprintf("http://%s.%s.svc:%d", service.Name, service.Namespace, serverPort)},
+	}
+
+	if !options.SkipIngress {
+		host, err := createRoute(ctx, client, namespace.Name, options.Timeout)
+		if err != nil {
+			result.Checks = append(result.Checks, CheckResult{Path: Ingress, Error: err})
+		} else {
+			targets = append(targets, struct {
+				path Path
+				url  string
+			}{path: Ingress, url: fmt.Sprintf("http://%s", host)})
+		}
+	}
+
+	for _, target := range targets {
+		latency, err := request(ctx, client, clientPod, target.url)
+		result.Checks = append(result.Checks, CheckResult{Path: target.path, Target: target.url, Latency: latency, Error: err})
+	}
+
+	if failed := result.Failed(); len(failed) > 0 {
+		return result, &networkError{err: fmt.Errorf("%d network path(s) failed:\n%s", len(failed), result.String())}
+	}
+
+	return result, nil
+}
+
+// selectNodes selects the ready worker nodes for the server and client pods, preferring different nodes
+func selectNodes(ctx context.Context, client *openshift.Client) (string, string, error) {
+	var nodes corev1.NodeList
+	if err := client.List(ctx, &nodes); err != nil {
+		return "", "", fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	var workers []string
+	for _, node := range nodes.Items {
+		if _, ok := node.Labels[workerNodeLabel]; !ok || node.Spec.Unschedulable {
+			continue
+		}
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				workers = append(workers, node.Name)
+			}
+		}
+	}
+
+	switch len(workers) {
+	case 0:
+		return "", "", fmt.Errorf("no ready worker nodes available")
+	case 1:
+		log.Printf("Only one ready worker node available, client and server pods will share node %q", workers[0])
+		return workers[0], workers[0], nil
+	default:
+		return workers[0], workers[1], nil
+	}
+}
+
+// createServer creates the http server pod
+func createServer(ctx context.Context, client *openshift.Client, namespace, node string, options *Options) (*corev1.Pod, error) {
+	return createPod(ctx, client, serverName, namespace, node, []string{"python3", "-m", "http.server", strconv.Itoa(serverPort)}, options)
+}
+
+// createPod creates a pod pinned to the node and waits for it to be running
+func createPod(ctx context.Context, client *openshift.Client, name, namespace, node string, command []string, options *Options) (*corev1.Pod, error) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"app": name}},
+		Spec: corev1.PodSpec{
+			NodeName: node,
+			Containers: []corev1.Container{
+				{
+					Name:            name,
+					Image:           options.Image,
+					Command:         command,
+					Ports:           []corev1.ContainerPort{{ContainerPort: serverPort}},
+					SecurityContext: workload.RestrictedSecurityContext(),
+				},
+			},
+		},
+	}
+
+	if err := client.Create(ctx, pod); err != nil {
+		return nil, fmt.Errorf("failed to create pod %q: %w", name, err)
+	}
+
+	return workload.WaitForPodRunning(ctx, client, name, namespace, options.Timeout)
+}
+
+// createService creates the service targeting the http server pod
+func createService(ctx context.Context, client *openshift.Client, namespace string) (*corev1.Service, error) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: serverName, Namespace: namespace},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": serverName},
+			Ports:    []corev1.ServicePort{{Port: serverPort, TargetPort: intstr.FromInt(serverPort)}},
+		},
+	}
+
+	if err := client.Create(ctx, service); err != nil {
+		return nil, fmt.Errorf("failed to create service: %w", err)
+	}
+
+	return service, nil
+}
+
+// createRoute creates the route exposing the service and waits for it to be admitted
+func createRoute(ctx context.Context, client *openshift.Client, namespace string, timeout time.Duration) (string, error) {
+	route := &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{Name: serverName, Namespace: namespace},
+		Spec: routev1.RouteSpec{
+			To:   routev1.RouteTargetReference{Kind: "Service", Name: serverName},
+			Port: &routev1.RoutePort{TargetPort: intstr.FromInt(serverPort)},
+		},
+	}
+
+	if err := client.Create(ctx, route); err != nil {
+		return "", fmt.Errorf("failed to create route: %w", err)
+	}
+
+	err := wait.PollUntilContextTimeout(ctx, 5*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		if err := client.Get(ctx, route.Name, route.Namespace, route); err != nil {
+			return false, nil
+		}
+		for _, ingress := range route.Status.Ingress {
+			for _, condition := range ingress.Conditions {
+				if condition.Type == routev1.RouteAdmitted && condition.Status == corev1.ConditionTrue {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("route was not admitted: %w", err)
+	}
+
+	return route.Spec.Host, nil
+}
+
+// request performs a http request from the client pod to the url returning the latency
+func request(ctx context.Context, client *openshift.Client, pod *corev1.Pod, url string) (time.Duration, error) {
+	command := []string{"curl", "--silent", "--fail", "--output", "/dev/null", "--max-time", "10", "--write-out", "%{time_total}", url}
+
+	var lastErr error
+	for attempt := 1; attempt <= requestAttempts; attempt++ {
+		var stdout, stderr bytes.Buffer
+
+		err := client.ExecInPod(ctx, pod.Namespace, pod.Name, pod.Spec.Containers[0].Name, command, &stdout, &stderr)
+		if err == nil {
+			return parseLatency(stdout.String())
+		}
+
+		lastErr = fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+		if attempt == requestAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, fmt.Errorf("request to %s failed after %d attempts: %w", url, attempt, ctx.Err())
+		case <-time.After(requestRetryWait):
+		}
+	}
+
+	return 0, fmt.Errorf("request to %s failed after %d attempts: %w", url, requestAttempts, lastErr)
+}
+
+// parseLatency parses the total request time in seconds reported by curl
+func parseLatency(output string) (time.Duration, error) {
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(output), 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse request latency %q: %w", output, err)
+	}
+	if seconds < 0 {
+		return 0, fmt.Errorf("invalid request latency %q", output)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
@@ -0,0 +1,111 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/osde2e-framework/pkg/clients/kubernetesfake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Network Verification", func() {
+	DescribeTable("parseLatency",
+		func(output string, expected time.Duration, valid bool) {
+			latency, err := parseLatency(output)
+			if !valid {
+				Expect(err).Should(HaveOccurred())
+				return
+			}
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(latency).To(Equal(expected))
+		},
+		Entry("should parse the curl total time", "0.125", 125*time.Millisecond, true),
+		Entry("should ignore surrounding whitespace", " 1.5\n", 1500*time.Millisecond, true),
+		Entry("should parse a zero latency", "0", time.Duration(0), true),
+		Entry("should reject empty output", "", time.Duration(0), false),
+		Entry("should reject non numeric output", "curl: (7) failed", time.Duration(0), false),
+		Entry("should reject a negative latency", "-1", time.Duration(0), false),
+	)
+
+	Describe("Result", func() {
+		result := &Result{
+			ClientNode: "worker-1",
+			ServerNode: "worker-2",
+			Checks: []CheckResult{
+				{Path: PodToPod, Target: "http://10.0.0.1:8080", Latency: 2 * time.Millisecond},
+				{Path: Ingress, Target: "http://example.com", Error: errors.New("timed out")},
+			},
+		}
+
+		It("should return the failed checks", func() {
+			Expect(result.Failed()).To(ConsistOf(result.Checks[1]))
+		})
+
+		It("should summarize each check", func() {
+			Expect(result.String()).To(Equal("client node: worker-1, server node: worker-2\n" +
+				"pod-to-pod (http://10.0.0.1:8080): 2ms\n" +
+				"ingress (http://example.com): failed: timed out"))
+		})
+
+		It("should return no failed checks when all pass", func() {
+			Expect((&Result{Checks: result.Checks[:1]}).Failed()).To(BeEmpty())
+		})
+	})
+
+	Describe("selectNodes", func() {
+		node := func(name string, worker, unschedulable bool, ready corev1.ConditionStatus) *corev1.Node {
+			labels := map[string]string{}
+			if worker {
+				labels[workerNodeLabel] = ""
+			}
+			return &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+				Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+				Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}}},
+			}
+		}
+
+		DescribeTable("should select the ready schedulable worker nodes",
+			func(nodes []*corev1.Node, expectedServer, expectedClient string, valid bool) {
+				server, err := kubernetesfake.NewServer()
+				Expect(err).ShouldNot(HaveOccurred())
+				DeferCleanup(server.Close)
+
+				for _, n := range nodes {
+					Expect(server.Add(n)).To(Succeed())
+				}
+
+				client, err := server.Client()
+				Expect(err).ShouldNot(HaveOccurred())
+
+				serverNode, clientNode, err := selectNodes(context.Background(), client)
+				if !valid {
+					Expect(err).Should(HaveOccurred())
+					return
+				}
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(serverNode).To(Equal(expectedServer))
+				Expect(clientNode).To(Equal(expectedClient))
+			},
+			Entry("on different nodes", []*corev1.Node{
+				node("master-1", false, false, corev1.ConditionTrue),
+				node("worker-1", true, false, corev1.ConditionTrue),
+				node("worker-2", true, false, corev1.ConditionTrue),
+			}, "worker-1", "worker-2", true),
+			Entry("on the same node when only one is available", []*corev1.Node{
+				node("worker-1", true, false, corev1.ConditionFalse),
+				node("worker-2", true, true, corev1.ConditionTrue),
+				node("worker-3", true, false, corev1.ConditionTrue),
+			}, "worker-3", "worker-3", true),
+			Entry("and fail when none are available", []*corev1.Node{
+				node("master-1", false, false, corev1.ConditionTrue),
+				node("worker-1", true, false, corev1.ConditionFalse),
+			}, "", "", false),
+		)
+	})
+})
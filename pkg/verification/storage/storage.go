@@ -0,0 +1,266 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/openshift/osde2e-framework/internal/workload"
+	"github.com/openshift/osde2e-framework/pkg/clients/openshift"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	defaultImage                  = "registry.access.redhat.com/ubi9/ubi-minimal"
+	defaultSize                   = "1Gi"
+	defaultExpandedSize           = "2Gi"
+	defaultTimeout                = 5 * time.Minute
+	defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+	namespacePrefix               = "osde2e-storage"
+	claimName                     = "storage-verification"
+	podName                       = "storage-verification"
+	mountPath                     = "/data"
+)
+
+// Options represents the data used to configure the storage verification
+type Options struct {
+	// Image is the container image used for the i/o pod
+	Image string
+	// Size is the requested size of the persistent volume claim
+	Size string
+	// Expand verifies the persistent volume claim can be expanded to ExpandedSize
+	Expand bool
+	// ExpandedSize is the size the persistent volume claim is expanded to
+	ExpandedSize string
+	// Timeout is the maximum time to wait for each step to complete
+	Timeout time.Duration
+}
+
+// Result represents the outcome of the storage verification
+type Result struct {
+	StorageClass     string
+	ProvisionLatency time.Duration
+	Expanded         bool
+}
+
+// storageError represents the custom error
+type storageError struct {
+	err error
+}
+
+// Error returns the formatted error message when storageError is invoked
+func (s *storageError) Error() string {
+	return fmt.Sprintf("storage verification failed: %v", s.err)
+}
+
+// setDefaults sets default options when undefined
+func (o *Options) setDefaults() {
+	if o.Image == "" {
+		o.Image = defaultImage
+	}
+	if o.Size == "" {
+		o.Size = defaultSize
+	}
+	if o.ExpandedSize == "" {
+		o.ExpandedSize = defaultExpandedSize
+	}
+	if o.Timeout == 0 {
+		o.Timeout = defaultTimeout
+	}
+}
+
+// Verify provisions a persistent volume claim using the default storage
+// class, attaches it to a pod that writes and reads data back and optionally
+// expands the claim. All resources created are removed once finished
+//
+//	result, err := storage.Verify(ctx, client, &storage.Options{Expand: true})
+//	Expect(err).ShouldNot(HaveOccurred())
+func Verify(ctx context.Context, client *openshift.Client, options *Options) (*Result, error) {
+	if options == nil {
+		options = &Options{}
+	}
+	options.setDefaults()
+
+	size, err := resource.ParseQuantity(options.Size)
+	if err != nil {
+		return nil, &storageError{err: fmt.Errorf("invalid size %q: %w", options.Size, err)}
+	}
+
+	storageClass, err := defaultStorageClass(ctx, client)
+	if err != nil {
+		return nil, &storageError{err: err}
+	}
+
+	result := &Result{StorageClass: storageClass.Name}
+
+	namespace, err := workload.CreateNamespace(ctx, client, namespacePrefix)
+	if err != nil {
+		return result, &storageError{err: err}
+	}
+
+	defer func() {
+		if err := workload.DeleteNamespace(ctx, client, namespace); err != nil {
+			log.Printf("Failed to clean up storage verification resources: %v", err)
+		}
+	}()
+
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: claimName, Namespace: namespace.Name},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: &storageClass.Name,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+		},
+	}
+
+	if err = client.Create(ctx, claim); err != nil {
+		return result, &storageError{err: fmt.Errorf("failed to create persistent volume claim: %w", err)}
+	}
+
+	start := time.Now()
+
+	pod, err := createPod(ctx, client, namespace.Name, options)
+	if err != nil {
+		return result, &storageError{err: err}
+	}
+
+	if err = waitForClaimBound(ctx, client, claim, options.Timeout); err != nil {
+		return result, &storageError{err: err}
+	}
+
+	result.ProvisionLatency = time.Since(start)
+
+	if err = readWrite(ctx, client, pod); err != nil {
+		return result, &storageError{err: err}
+	}
+
+	if !options.Expand {
+		return result, nil
+	}
+
+	if storageClass.AllowVolumeExpansion == nil || !*storageClass.AllowVolumeExpansion {
+		return result, &storageError{err: fmt.Errorf("storage class %q does not allow volume expansion", storageClass.Name)}
+	}
+
+	if err = expand(ctx, client, claim, options); err != nil {
+		return result, &storageError{err: err}
+	}
+
+	result.Expanded = true
+
+	return result, nil
+}
+
+// defaultStorageClass returns the clusters default storage class
+func defaultStorageClass(ctx context.Context, client *openshift.Client) (*storagev1.StorageClass, error) {
+	var storageClasses storagev1.StorageClassList
+	if err := client.List(ctx, &storageClasses); err != nil {
+		return nil, fmt.Errorf("failed to list storage classes: %w", err)
+	}
+
+	for i, storageClass := range storageClasses.Items {
+		if storageClass.Annotations[defaultStorageClassAnnotation] == "true" {
+			return &storageClasses.Items[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no default storage class found")
+}
+
+// createPod creates the pod mounting the persistent volume claim and waits for it to be running
+func createPod(ctx context.Context, client *openshift.Client, namespace string, options *Options) (*corev1.Pod, error) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: namespace},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:            podName,
+					Image:           options.Image,
+					Command:         []string{"sleep", "infinity"},
+					VolumeMounts:    []corev1.VolumeMount{{Name: claimName, MountPath: mountPath}},
+					SecurityContext: workload.RestrictedSecurityContext(),
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: claimName,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+					},
+				},
+			},
+		},
+	}
+
+	if err := client.Create(ctx, pod); err != nil {
+		return nil, fmt.Errorf("failed to create pod: %w", err)
+	}
+
+	return workload.WaitForPodRunning(ctx, client, pod.Name, pod.Namespace, options.Timeout)
+}
+
+// waitForClaimBound waits for the persistent volume claim to be bound
+func waitForClaimBound(ctx context.Context, client *openshift.Client, claim *corev1.PersistentVolumeClaim, timeout time.Duration) error {
+	err := wait.PollUntilContextTimeout(ctx, 5*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		if err := client.Get(ctx, claim.Name, claim.Namespace, claim); err != nil {
+			return false, nil
+		}
+		return claim.Status.Phase == corev1.ClaimBound, nil
+	})
+	if err != nil {
+		return fmt.Errorf("persistent volume claim was not bound: %w", err)
+	}
+	return nil
+}
+
+// readWrite writes data to the mounted volume and verifies it can be read back
+func readWrite(ctx context.Context, client *openshift.Client, pod *corev1.Pod) error {
+	const data = "osde2e-storage-verification"
+	var stdout, stderr bytes.Buffer
+
+	command := []string{"sh", "-c", fmt.Sprintf("echo %s > %s/verify && sync && cat %s/verify", data, mountPath, mountPath)}
+	if err := client.ExecInPod(ctx, pod.Namespace, pod.Name, pod.Spec.Containers[0].Name, command, &stdout, &stderr); err != nil {
+		return fmt.Errorf("failed to write to volume: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	if strings.TrimSpace(stdout.String()) != data {
+		return fmt.Errorf("data read from volume %q does not match data written %q", strings.TrimSpace(stdout.String()), data)
+	}
+
+	return nil
+}
+
+// expand expands the persistent volume claim and waits for the new capacity to be reported
+func expand(ctx context.Context, client *openshift.Client, claim *corev1.PersistentVolumeClaim, options *Options) error {
+	expandedSize, err := resource.ParseQuantity(options.ExpandedSize)
+	if err != nil {
+		return fmt.Errorf("invalid expanded size %q: %w", options.ExpandedSize, err)
+	}
+
+	claim.Spec.Resources.Requests[corev1.ResourceStorage] = expandedSize
+	if err = client.Update(ctx, claim); err != nil {
+		return fmt.Errorf("failed to expand persistent volume claim: %w", err)
+	}
+
+	err = wait.PollUntilContextTimeout(ctx, 10*time.Second, options.Timeout, true, func(ctx context.Context) (bool, error) {
+		if err := client.Get(ctx, claim.Name, claim.Namespace, claim); err != nil {
+			return false, nil
+		}
+		capacity := claim.Status.Capacity[corev1.ResourceStorage]
+		return capacity.Cmp(expandedSize) >= 0, nil
+	})
+	if err != nil {
+		return fmt.Errorf("persistent volume claim was not expanded to %s: %w", options.ExpandedSize, err)
+	}
+
+	return nil
+}